@@ -27,6 +27,9 @@ var (
 	NotFindBindPhone            = msError.NewError(105, errors.New("该手机号未绑定"))
 	PhoneAlreadyBind            = msError.NewError(106, errors.New("该手机号已被绑定，无法重复绑定"))
 	NotFindUser                 = msError.NewError(107, errors.New("用户不存在"))
+	ContentIllegal              = msError.NewError(108, errors.New("内容包含敏感词"))
+	TooManyRequests             = msError.NewError(109, errors.New("请求过于频繁，请稍后再试"))
+	LoginLocked                 = msError.NewError(110, errors.New("密码错误次数过多，请稍后再试"))
 	TokenInfoError              = msError.NewError(201, errors.New("无效的token"))
 	NotEnoughVipLevel           = msError.NewError(202, errors.New("vip等级不足"))
 	BlockedAccount              = msError.NewError(203, errors.New("帐号已冻结"))
@@ -49,4 +52,7 @@ var (
 	RoomNotExist                = msError.NewError(308, errors.New("房间不存在"))
 	CanNotEnterNotLocation      = msError.NewError(309, errors.New("无法进入房间，获取定位信息失败"))
 	CanNotEnterTooNear          = msError.NewError(310, errors.New("无法进入房间，与房间中的其他玩家太近"))
+	NotYourTurn                 = msError.NewError(311, errors.New("还没有轮到你操作"))
+	InvalidAction               = msError.NewError(312, errors.New("不符合规则的操作"))
+	GameNotPlaying              = msError.NewError(313, errors.New("对局还没有开始"))
 )
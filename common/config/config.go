@@ -3,19 +3,37 @@ package config
 import (
 	"fmt"
 	"log"
+	"sync"
+	"sync/atomic"
 
 	"github.com/fsnotify/fsnotify"
 	"github.com/spf13/viper"
 )
 
-var Conf *Config
+// 当前的配置，重新加载时整体替换为新的快照，读取方不会读到加载了一半的配置
+var current atomic.Pointer[Config]
+
+// Current 当前配置的快照，调用方不能修改，需要读取最新配置的地方每次使用时调用
+func Current() *Config {
+	return current.Load()
+}
+
+// Set 替换当前配置，用于单元测试
+func Set(c *Config) {
+	current.Store(c)
+}
+
+// 加载配置文件的viper实例，重新加载配置时使用
+var v *viper.Viper
 
 type Config struct {
 	Log        LogConf                 `mapstructure:"logs"`
 	Port       int                     `mapstructure:"port"`
 	WsPort     int                     `mapstructure:"wsPort"`
 	MetricPort int                     `mapstructure:"metricPort"`
+	Pprof      bool                    `mapstructure:"pprof"` // 是否在监控端口上开启pprof
 	HttpPort   int                     `mapstructure:"httpPort"`
+	Http       HttpConf                `mapstructure:"http"`
 	AppName    string                  `mapstructure:"appName"`
 	Database   Database                `mapstructure:"db"`
 	Jwt        JwtConf                 `mapstructure:"jwt"`
@@ -23,6 +41,11 @@ type Config struct {
 	Etcd       EtcdConf                `mapstructure:"etcd"`
 	Domain     map[string]Domain       `mapstructure:"domain"`
 	Services   map[string]ServicesConf `mapstructure:"services"`
+	Trace      TraceConf               `mapstructure:"trace"`
+	Sensitive  []string                `mapstructure:"sensitive"` // 敏感词，昵称、签名等用户输入的内容过滤
+	RateLimit  RateLimitConf           `mapstructure:"rateLimit"`
+	Games      []GameConf              `mapstructure:"games"` // 大厅展示的游戏列表
+	Room       RoomConf                `mapstructure:"room"`
 }
 type ServicesConf struct {
 	Id         string `mapstructure:"id"`
@@ -34,34 +57,118 @@ type Domain struct {
 	LoadBalance bool   `mapstructure:"loadBalance"`
 }
 type JwtConf struct {
-	Secret string `mapstructure:"secret"`
-	Exp    int64  `mapstructure:"exp"`
+	Secret    string            `mapstructure:"secret"`
+	Exp       int64             `mapstructure:"exp"`       // refresh token有效期，单位天
+	AccessExp int64             `mapstructure:"accessExp"` // access token有效期，单位分钟
+	Kid       string            `mapstructure:"kid"`       // 当前密钥secret的id
+	Keys      map[string]string `mapstructure:"keys"`      // 轮换下来的旧密钥，kid -> secret，旧access token全部过期后可以删除
+}
+
+// HttpConf http服务配置，超时时间单位为秒
+type HttpConf struct {
+	ReadTimeout       int     `mapstructure:"readTimeout"`
+	ReadHeaderTimeout int     `mapstructure:"readHeaderTimeout"`
+	WriteTimeout      int     `mapstructure:"writeTimeout"`
+	IdleTimeout       int     `mapstructure:"idleTimeout"`
+	H2c               bool    `mapstructure:"h2c"` // 未开启tls时是否支持明文http2，用于内网负载均衡
+	Tls               TlsConf `mapstructure:"tls"`
+	// TrustedProxies 可信的反向代理ip或cidr，只有来自这些地址的请求才使用X-Forwarded-For中的客户端ip，修改后需要重启
+	TrustedProxies []string `mapstructure:"trustedProxies"`
+}
+type TlsConf struct {
+	Enable   bool   `mapstructure:"enable"`
+	CertFile string `mapstructure:"certFile"`
+	KeyFile  string `mapstructure:"keyFile"`
 }
 type LogConf struct {
 	Level string `mapstructure:"level"`
 }
 
+// TraceConf 链路追踪配置
+type TraceConf struct {
+	Exporter    string  `mapstructure:"exporter"`    // none stdout memory otlp
+	Endpoint    string  `mapstructure:"endpoint"`    // otlp collector地址
+	SampleRatio float64 `mapstructure:"sampleRatio"` // 采样率，0~1，不配置或为0时不采样
+}
+
+// GameConf 大厅中的游戏
+type GameConf struct {
+	GameType int    `mapstructure:"gameType"`
+	Name     string `mapstructure:"name"`
+	Icon     string `mapstructure:"icon"`
+	Sort     int    `mapstructure:"sort"` // 越大越靠前
+	Enable   bool   `mapstructure:"enable"`
+	// Options 创建房间的规则选项及可选值，第一个为默认值，players为房间人数
+	Options map[string][]int `mapstructure:"options"`
+	Tiers   []TierConf       `mapstructure:"tiers"` // 金币场场次，为空时不开放匹配
+}
+
+// TierConf 金币场场次（初级、中级、高级），进入和每局开始前要求金币在[minGold, maxGold]之间
+type TierConf struct {
+	Level   int    `mapstructure:"level"` // 场次等级，从1开始，越大越高级
+	Name    string `mapstructure:"name"`
+	MinGold int64  `mapstructure:"minGold"`
+	MaxGold int64  `mapstructure:"maxGold"` // 0为不限制
+	BaseBet int64  `mapstructure:"baseBet"` // 底分
+}
+
+// RoomConf 房间配置
+type RoomConf struct {
+	UnionRoomLimit int64 `mapstructure:"unionRoomLimit"` // 每个联盟同时存在的房间数上限，0为不限制
+	GpsRadius      int64 `mapstructure:"gpsRadius"`      // 开启定位检查的房间，与其他玩家的距离小于该值（米）时不能进入
+	SameIpCheck    bool  `mapstructure:"sameIpCheck"`    // 在距离矩阵中标记同一ip的玩家
+	TurnTimeout    int64 `mapstructure:"turnTimeout"`    // 对局中每次操作的时间（秒），超时后自动操作并托管，0为默认15秒
+}
+
+// RateLimitConf gate接口限流配置，window单位为秒
+type RateLimitConf struct {
+	Enable    bool             `mapstructure:"enable"`
+	Ip        LimitRule        `mapstructure:"ip"` // 每个ip所有接口合计
+	Routes    []RouteLimitConf `mapstructure:"routes"`
+	LoginLock LoginLockConf    `mapstructure:"loginLock"`
+}
+type LimitRule struct {
+	Limit  int64 `mapstructure:"limit"` // 窗口内最多请求次数，0为不限制
+	Window int64 `mapstructure:"window"`
+}
+type RouteLimitConf struct {
+	Path    string    `mapstructure:"path"`
+	Ip      LimitRule `mapstructure:"ip"`      // 每个ip
+	Account LimitRule `mapstructure:"account"` // 每个账号，登录后的接口按uid
+}
+
+// LoginLockConf 窗口内同一账号在同一ip上密码错误次数达到maxFails后锁定，直到窗口结束
+type LoginLockConf struct {
+	MaxFails int64 `mapstructure:"maxFails"` // 0为不锁定
+	Window   int64 `mapstructure:"window"`
+}
+
 // Database 数据库配置
 type Database struct {
 	MongoConf MongoConf `mapstructure:"mongo"`
 	RedisConf RedisConf `mapstructure:"redis"`
 }
 type MongoConf struct {
-	Url         string `mapstructure:"url"`
-	Db          string `mapstructure:"db"`
-	UserName    string `mapstructure:"userName"`
-	Password    string `mapstructure:"password"`
-	MinPoolSize int    `mapstructure:"minPoolSize"`
-	MaxPoolSize int    `mapstructure:"maxPoolSize"`
+	Url                string `mapstructure:"url"`
+	Db                 string `mapstructure:"db"`
+	UserName           string `mapstructure:"userName"`
+	Password           string `mapstructure:"password"`
+	MinPoolSize        int    `mapstructure:"minPoolSize"`
+	MaxPoolSize        int    `mapstructure:"maxPoolSize"`
+	DisableTransaction bool   `mapstructure:"disableTransaction"` // 未部署副本集时不支持事务，开启后事务退化为补偿写
 }
 type RedisConf struct {
-	Addr         string   `mapstructure:"addr"`
-	ClusterAddrs []string `mapstructure:"clusterAddrs"`
-	Password     string   `mapstructure:"password"`
-	PoolSize     int      `mapstructure:"poolSize"`
-	MinIdleConns int      `mapstructure:"minIdleConns"`
-	Host         string   `mapstructure:"host"`
-	Port         int      `mapstructure:"port"`
+	Addr             string   `mapstructure:"addr"`
+	ClusterAddrs     []string `mapstructure:"clusterAddrs"`
+	MasterName       string   `mapstructure:"masterName"`    // 哨兵模式的主节点名称
+	SentinelAddrs    []string `mapstructure:"sentinelAddrs"` // 哨兵地址
+	SentinelPassword string   `mapstructure:"sentinelPassword"`
+	Password         string   `mapstructure:"password"`
+	Db               int      `mapstructure:"db"`
+	PoolSize         int      `mapstructure:"poolSize"`
+	MinIdleConns     int      `mapstructure:"minIdleConns"`
+	Host             string   `mapstructure:"host"`
+	Port             int      `mapstructure:"port"`
 }
 
 // etcd相关配置
@@ -79,27 +186,67 @@ type RegisterServer struct {
 	Ttl     int64  `mapstructure:"ttl"` //租约时长
 }
 type GrpcConf struct {
-	Addr string `mapstructure:"addr"`
+	Addr       string `mapstructure:"addr"`
+	AdminAddr  string `mapstructure:"adminAddr"`  // 后台管理服务单独监听的地址，只在内网开放，不注册到etcd，为空时不启动
+	AdminToken string `mapstructure:"adminToken"` // 调用后台管理服务时metadata中需要携带的令牌，为空时拒绝所有请求
 }
 
 // InitConfig 加载配置文件
 func InitConfig(configFile string) {
-	Conf = new(Config)
-	v := viper.New()
+	v = viper.New()
 	v.SetConfigFile(configFile)
 	v.WatchConfig()
 	v.OnConfigChange(func(e fsnotify.Event) {
+		// 和SIGHUP走同样的重新加载流程，配置文件有错误时保留旧的配置
 		log.Println("配置文件被修改")
-		err := v.Unmarshal(&Conf)
-		if err != nil {
-			panic(fmt.Errorf("配置文件被修改以后，报错，err:%v \n", err))
+		if err := Reload(); err != nil {
+			log.Printf("配置文件被修改以后重新加载失败，继续使用旧的配置，err:%v \n", err)
 		}
 	})
 	err := v.ReadInConfig()
 	if err != nil {
 		panic(fmt.Errorf("读取配置文件报错，err:%v \n", err))
 	}
-	if err := v.Unmarshal(&Conf); err != nil {
+	if err := load(); err != nil {
 		panic(fmt.Errorf("Unmarshal data to Conf failed ，err:%v \n", err))
 	}
 }
+
+var (
+	reloadLock  sync.Mutex // 文件变化和SIGHUP可能同时触发重新加载
+	reloadHooks []func()
+)
+
+// OnReload 添加重新加载配置成功后执行的函数，如修改日志级别
+func OnReload(fn func()) {
+	reloadLock.Lock()
+	defer reloadLock.Unlock()
+	reloadHooks = append(reloadHooks, fn)
+}
+
+// Reload 重新读取配置文件，成功后依次执行OnReload添加的函数，失败时保留旧的配置
+// 配置文件被修改或者收到SIGHUP信号时调用
+func Reload() error {
+	reloadLock.Lock()
+	defer reloadLock.Unlock()
+	if err := v.ReadInConfig(); err != nil {
+		return err
+	}
+	if err := load(); err != nil {
+		return err
+	}
+	for _, fn := range reloadHooks {
+		fn()
+	}
+	return nil
+}
+
+// load 解析到新的Config后再替换，不修改正在被读取的旧配置
+func load() error {
+	c := new(Config)
+	if err := v.Unmarshal(c); err != nil {
+		return err
+	}
+	current.Store(c)
+	return nil
+}
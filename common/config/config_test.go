@@ -0,0 +1,66 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// 重新加载时替换为新的配置，之前取到的快照不会被修改
+func TestReload(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "application.yml")
+	if err := os.WriteFile(file, []byte("appName: a\nroom:\n  turnTimeout: 10\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	InitConfig(file)
+	old := Current()
+	if err := os.WriteFile(file, []byte("appName: a\nroom:\n  turnTimeout: 20\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := Reload(); err != nil {
+		t.Fatal(err)
+	}
+	if old.Room.TurnTimeout != 10 {
+		t.Fatalf("old turnTimeout = %d", old.Room.TurnTimeout)
+	}
+	if c := Current(); c == old || c.Room.TurnTimeout != 20 {
+		t.Fatalf("current turnTimeout = %d", c.Room.TurnTimeout)
+	}
+}
+
+// 配置文件有错误时保留旧的配置，文件修改和SIGHUP都执行重新加载的函数
+func TestReload_Hooks(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "application.yml")
+	write := func(content string) {
+		if err := os.WriteFile(file, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("appName: a\nroom:\n  turnTimeout: 10\n")
+	InitConfig(file)
+	var reloaded atomic.Int32
+	reloadLock.Lock()
+	reloadHooks = nil
+	reloadLock.Unlock()
+	OnReload(func() { reloaded.Add(1) })
+
+	write("appName: a\nroom: [\n")
+	if err := Reload(); err == nil {
+		t.Fatal("reload bad file should fail")
+	}
+	if Current().Room.TurnTimeout != 10 || reloaded.Load() != 0 {
+		t.Fatalf("turnTimeout = %d, reloaded = %d", Current().Room.TurnTimeout, reloaded.Load())
+	}
+
+	// 文件监听触发的重新加载不会panic，成功后执行同样的函数
+	write("appName: a\nroom:\n  turnTimeout: 30\n")
+	deadline := time.Now().Add(2 * time.Second)
+	for Current().Room.TurnTimeout != 30 || reloaded.Load() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("turnTimeout = %d, reloaded = %d", Current().Room.TurnTimeout, reloaded.Load())
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
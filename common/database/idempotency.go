@@ -0,0 +1,87 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrRequestProcessing 相同幂等key的请求正在处理中
+var ErrRequestProcessing = errors.New("request is processing")
+
+// 处理中状态的占位值，处理完成后替换为处理结果
+const idempotencyPending = "\x00pending"
+
+// Idempotency 幂等key，同一个key的请求只处理一次，重复提交直接返回第一次的处理结果
+// 如：客户端重复点击注册，网络重试导致的重复扣款
+type Idempotency struct {
+	redis  *RedisManager
+	prefix string
+	ttl    time.Duration
+}
+
+// NewIdempotency prefix用于区分业务，ttl为处理结果的保存时间
+func (r *RedisManager) NewIdempotency(prefix string, ttl time.Duration) *Idempotency {
+	return &Idempotency{
+		redis:  r,
+		prefix: prefix,
+		ttl:    ttl,
+	}
+}
+
+func (i *Idempotency) buildKey(key string) string {
+	return "idempotency:" + i.prefix + ":" + key
+}
+
+// Begin 开始处理请求
+// 第一次请求返回done=false，调用方处理完后调用Complete保存结果，处理失败调用Abort允许重试；
+// 已处理完成的请求返回done=true和保存的结果；正在处理中的请求返回ErrRequestProcessing
+func (i *Idempotency) Begin(ctx context.Context, key string) (result string, done bool, err error) {
+	ok, err := i.redis.SetNX(ctx, i.buildKey(key), idempotencyPending, i.ttl)
+	if err != nil {
+		return "", false, err
+	}
+	if ok {
+		return "", false, nil
+	}
+	result, err = i.redis.Get(ctx, i.buildKey(key))
+	if err != nil {
+		if IsNil(err) {
+			// 刚好过期或者被Abort，让调用方重试
+			return "", false, ErrRequestProcessing
+		}
+		return "", false, err
+	}
+	if result == idempotencyPending {
+		return "", false, ErrRequestProcessing
+	}
+	return result, true, nil
+}
+
+// Complete 保存处理结果
+func (i *Idempotency) Complete(ctx context.Context, key, result string) error {
+	return i.redis.Set(ctx, i.buildKey(key), result, i.ttl)
+}
+
+// Abort 处理失败，删除key允许客户端重试
+func (i *Idempotency) Abort(ctx context.Context, key string) error {
+	_, err := i.redis.Del(ctx, i.buildKey(key))
+	return err
+}
+
+// Do 同一个key只执行一次fn，重复请求直接返回第一次的结果，fn失败时删除key允许重试
+// 保存结果失败时key保持处理中直到过期，宁可让重试失败也不重复执行
+func (i *Idempotency) Do(ctx context.Context, key string, fn func(ctx context.Context) (string, error)) (string, error) {
+	result, done, err := i.Begin(ctx, key)
+	if err != nil || done {
+		return result, err
+	}
+	result, err = fn(ctx)
+	if err != nil {
+		// 业务ctx可能已经取消，使用新的ctx删除
+		_ = i.Abort(context.Background(), key)
+		return "", err
+	}
+	_ = i.Complete(context.Background(), key, result)
+	return result, nil
+}
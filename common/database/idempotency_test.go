@@ -0,0 +1,60 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestIdempotency(t *testing.T) {
+	ctx := context.Background()
+	r, server := newTestRedis(t)
+	idem := r.NewIdempotency("test", time.Minute)
+
+	// 处理中的请求不能重复处理
+	if _, done, err := idem.Begin(ctx, "k1"); done || err != nil {
+		t.Fatalf("begin = %v, %v", done, err)
+	}
+	if _, _, err := idem.Begin(ctx, "k1"); !errors.Is(err, ErrRequestProcessing) {
+		t.Fatalf("begin processing err = %v", err)
+	}
+	// 处理完成后重放保存的结果
+	if err := idem.Complete(ctx, "k1", "result"); err != nil {
+		t.Fatal(err)
+	}
+	if result, done, err := idem.Begin(ctx, "k1"); result != "result" || !done || err != nil {
+		t.Fatalf("replay = %s, %v, %v", result, done, err)
+	}
+	// 结果过期后重新处理
+	server.FastForward(2 * time.Minute)
+	if _, done, err := idem.Begin(ctx, "k1"); done || err != nil {
+		t.Fatalf("begin after expire = %v, %v", done, err)
+	}
+}
+
+func TestIdempotency_Do(t *testing.T) {
+	ctx := context.Background()
+	r, _ := newTestRedis(t)
+	idem := r.NewIdempotency("test", time.Minute)
+	calls := 0
+	fn := func(context.Context) (string, error) {
+		calls++
+		if calls == 1 {
+			return "", errors.New("failed")
+		}
+		return "ok", nil
+	}
+	// 失败后允许重试，成功后重复请求不再执行
+	if _, err := idem.Do(ctx, "k1", fn); err == nil {
+		t.Fatal("first call should fail")
+	}
+	for i := 0; i < 2; i++ {
+		if result, err := idem.Do(ctx, "k1", fn); result != "ok" || err != nil {
+			t.Fatalf("do = %s, %v", result, err)
+		}
+	}
+	if calls != 2 {
+		t.Fatalf("calls = %d, want 2", calls)
+	}
+}
@@ -0,0 +1,198 @@
+package database
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sort"
+	"time"
+)
+
+var (
+	ErrLockNotObtained = errors.New("lock not obtained")
+	ErrLockNotHeld     = errors.New("lock not held")
+	ErrLockTTL         = errors.New("lock ttl must be at least 1ms")
+)
+
+const (
+	lockRetryInterval = 50 * time.Millisecond // 获取锁失败后的重试间隔
+	minLockTTL        = time.Millisecond      // redis的过期时间精确到毫秒
+)
+
+// 只有持有者才能释放锁，避免锁过期后误删别人的锁
+var unlockScript = NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+
+// 只有持有者才能续期
+var refreshScript = NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0`)
+
+// Lock 基于redis的分布式锁，value为持有者的随机token，用于跨user、game节点串行化对同一数据的操作
+// 如：扣减同一个用户的金币，同一个房间的加入
+type Lock struct {
+	redis *RedisManager
+	key   string
+	token string
+	ttl   time.Duration
+}
+
+// NewLock 创建分布式锁，ttl为锁的过期时间，持有者宕机后锁会自动释放，ttl小于1ms时返回ErrLockTTL
+func (r *RedisManager) NewLock(key string, ttl time.Duration) (*Lock, error) {
+	if ttl < minLockTTL {
+		return nil, ErrLockTTL
+	}
+	return &Lock{
+		redis: r,
+		key:   key,
+		token: randomToken(),
+		ttl:   ttl,
+	}, nil
+}
+
+// Token 持有者token
+func (l *Lock) Token() string {
+	return l.token
+}
+
+// TryLock 尝试获取一次锁
+func (l *Lock) TryLock(ctx context.Context) (bool, error) {
+	return l.redis.SetNX(ctx, l.key, l.token, l.ttl)
+}
+
+// Lock 获取锁，获取不到时重试，直到ctx结束
+func (l *Lock) Lock(ctx context.Context) error {
+	ticker := time.NewTicker(lockRetryInterval)
+	defer ticker.Stop()
+	for {
+		ok, err := l.TryLock(ctx)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ErrLockNotObtained
+		case <-ticker.C:
+		}
+	}
+}
+
+// Unlock 释放锁，锁已过期或被别人持有时返回ErrLockNotHeld
+func (l *Lock) Unlock(ctx context.Context) error {
+	res, err := l.redis.Eval(ctx, unlockScript, []string{l.key}, l.token)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.(int64); n == 0 {
+		return ErrLockNotHeld
+	}
+	return nil
+}
+
+// Refresh 续期，锁已过期或被别人持有时返回ErrLockNotHeld
+func (l *Lock) Refresh(ctx context.Context) error {
+	res, err := l.redis.Eval(ctx, refreshScript, []string{l.key}, l.token, l.ttl.Milliseconds())
+	if err != nil {
+		return err
+	}
+	if n, _ := res.(int64); n == 0 {
+		return ErrLockNotHeld
+	}
+	return nil
+}
+
+// AutoRefresh 每隔ttl/3自动续期，适用于执行时间不确定的操作，返回的函数用于停止续期
+// 锁被别人持有，或者续期一直失败到锁过期时，返回的ctx被取消，context.Cause为ErrLockNotHeld，持有者需要停止操作
+func (l *Lock) AutoRefresh(ctx context.Context) (context.Context, func()) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	go func() {
+		ticker := time.NewTicker(l.ttl / 3)
+		defer ticker.Stop()
+		expireAt := time.Now().Add(l.ttl)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				err := l.Refresh(ctx)
+				if err == nil {
+					expireAt = time.Now().Add(l.ttl)
+					continue
+				}
+				if ctx.Err() != nil {
+					return
+				}
+				// redis暂时不可用时继续重试，直到锁已经过期
+				if errors.Is(err, ErrLockNotHeld) || time.Now().After(expireAt) {
+					cancel(ErrLockNotHeld)
+					return
+				}
+			}
+		}
+	}()
+	return ctx, func() { cancel(nil) }
+}
+
+// WithLock 持有锁执行fn，执行期间自动续期，执行完释放锁；wait为获取锁的最长等待时间
+// 执行期间丢失锁时取消fn的ctx并返回ErrLockNotHeld
+func (r *RedisManager) WithLock(ctx context.Context, key string, ttl, wait time.Duration, fn func(ctx context.Context) error) error {
+	lock, err := r.NewLock(key, ttl)
+	if err != nil {
+		return err
+	}
+	lockCtx, cancel := context.WithTimeout(ctx, wait)
+	err = lock.Lock(lockCtx)
+	cancel()
+	if err != nil {
+		return err
+	}
+	// 执行期间丢失锁时fn的ctx被取消
+	ctx, stop := lock.AutoRefresh(ctx)
+	defer func() {
+		stop()
+		// 业务ctx可能已经取消，释放锁使用新的ctx
+		_ = lock.Unlock(context.Background())
+	}()
+	err = fn(ctx)
+	if cause := context.Cause(ctx); errors.Is(cause, ErrLockNotHeld) {
+		return cause
+	}
+	return err
+}
+
+// WithLocks 持有多个锁执行fn，key排序去重后依次获取，避免多个节点以不同的顺序加锁导致死锁
+func (r *RedisManager) WithLocks(ctx context.Context, keys []string, ttl, wait time.Duration, fn func(ctx context.Context) error) error {
+	sorted := append([]string(nil), keys...)
+	sort.Strings(sorted)
+	unique := sorted[:0]
+	for i, key := range sorted {
+		if i == 0 || key != sorted[i-1] {
+			unique = append(unique, key)
+		}
+	}
+	var run func(ctx context.Context, i int) error
+	run = func(ctx context.Context, i int) error {
+		if i == len(unique) {
+			return fn(ctx)
+		}
+		return r.WithLock(ctx, unique[i], ttl, wait, func(ctx context.Context) error {
+			return run(ctx, i+1)
+		})
+	}
+	return run(ctx, 0)
+}
+
+func randomToken() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
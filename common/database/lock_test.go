@@ -0,0 +1,164 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func newTestRedis(t *testing.T) (*RedisManager, *miniredis.Miniredis) {
+	server := miniredis.RunT(t)
+	return &RedisManager{Cli: redis.NewClient(&redis.Options{Addr: server.Addr()})}, server
+}
+
+func newTestLock(t *testing.T, r *RedisManager, key string, ttl time.Duration) *Lock {
+	t.Helper()
+	l, err := r.NewLock(key, ttl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return l
+}
+
+func TestLock_Contention(t *testing.T) {
+	ctx := context.Background()
+	r, _ := newTestRedis(t)
+	a := newTestLock(t, r, "lock:test", time.Minute)
+	b := newTestLock(t, r, "lock:test", time.Minute)
+	if ok, err := a.TryLock(ctx); !ok || err != nil {
+		t.Fatalf("a try lock = %v, %v", ok, err)
+	}
+	if ok, err := b.TryLock(ctx); ok || err != nil {
+		t.Fatalf("b try lock = %v, %v", ok, err)
+	}
+	// 别人持有的锁不能释放
+	if err := b.Unlock(ctx); !errors.Is(err, ErrLockNotHeld) {
+		t.Fatalf("b unlock err = %v", err)
+	}
+	err := r.WithLock(ctx, "lock:test", time.Minute, 100*time.Millisecond, func(context.Context) error {
+		t.Fatal("fn called without lock")
+		return nil
+	})
+	if !errors.Is(err, ErrLockNotObtained) {
+		t.Fatalf("with lock err = %v", err)
+	}
+	if err = a.Unlock(ctx); err != nil {
+		t.Fatal(err)
+	}
+	called := false
+	err = r.WithLock(ctx, "lock:test", time.Minute, time.Second, func(context.Context) error {
+		called = true
+		return nil
+	})
+	if err != nil || !called {
+		t.Fatalf("with lock after unlock = %v, %v", called, err)
+	}
+	// 执行完释放锁
+	if ok, _ := b.TryLock(ctx); !ok {
+		t.Fatal("lock not released")
+	}
+}
+
+func TestLock_Expire(t *testing.T) {
+	ctx := context.Background()
+	r, server := newTestRedis(t)
+	a := newTestLock(t, r, "lock:test", time.Second)
+	if ok, _ := a.TryLock(ctx); !ok {
+		t.Fatal("a not locked")
+	}
+	// 持有者宕机，锁过期后其他人可以获取，原持有者不能再释放、续期
+	server.FastForward(2 * time.Second)
+	b := newTestLock(t, r, "lock:test", time.Second)
+	if ok, _ := b.TryLock(ctx); !ok {
+		t.Fatal("b not locked after expire")
+	}
+	if err := a.Unlock(ctx); !errors.Is(err, ErrLockNotHeld) {
+		t.Fatalf("a unlock err = %v", err)
+	}
+	if err := a.Refresh(ctx); !errors.Is(err, ErrLockNotHeld) {
+		t.Fatalf("a refresh err = %v", err)
+	}
+	if err := b.Refresh(ctx); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWithLocks(t *testing.T) {
+	ctx := context.Background()
+	r, server := newTestRedis(t)
+	var held []string
+	err := r.WithLocks(ctx, []string{"lock:b", "lock:a", "lock:b"}, time.Minute, time.Second, func(context.Context) error {
+		held = server.Keys()
+		return nil
+	})
+	if err != nil || len(held) != 2 {
+		t.Fatalf("held = %v, %v", held, err)
+	}
+	if keys := server.Keys(); len(keys) != 0 {
+		t.Fatalf("locks not released: %v", keys)
+	}
+	// 其中一个锁被占用时全部不执行，已经获取的锁被释放
+	if ok, _ := newTestLock(t, r, "lock:b", time.Minute).TryLock(ctx); !ok {
+		t.Fatal("lock b not obtained")
+	}
+	err = r.WithLocks(ctx, []string{"lock:a", "lock:b"}, time.Minute, 100*time.Millisecond, func(context.Context) error {
+		t.Fatal("fn called without lock")
+		return nil
+	})
+	if !errors.Is(err, ErrLockNotObtained) || server.Exists("lock:a") {
+		t.Fatalf("err = %v, keys = %v", err, server.Keys())
+	}
+}
+
+func TestNewLock_TTL(t *testing.T) {
+	r, _ := newTestRedis(t)
+	for _, ttl := range []time.Duration{-time.Second, 0, time.Microsecond} {
+		if _, err := r.NewLock("lock:test", ttl); !errors.Is(err, ErrLockTTL) {
+			t.Fatalf("ttl %v err = %v", ttl, err)
+		}
+	}
+	if err := r.WithLock(context.Background(), "lock:test", 0, time.Second, func(context.Context) error {
+		t.Fatal("fn called with invalid ttl")
+		return nil
+	}); !errors.Is(err, ErrLockTTL) {
+		t.Fatalf("with lock err = %v", err)
+	}
+}
+
+// 续期时发现锁已经丢失，取消持有者的ctx
+func TestLock_AutoRefreshLost(t *testing.T) {
+	ctx := context.Background()
+	r, server := newTestRedis(t)
+	err := r.WithLock(ctx, "lock:test", 30*time.Millisecond, time.Second, func(ctx context.Context) error {
+		server.Del("lock:test")
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Second):
+			t.Error("ctx not cancelled after lock lost")
+			return nil
+		}
+	})
+	if !errors.Is(err, ErrLockNotHeld) {
+		t.Fatalf("with lock err = %v", err)
+	}
+
+	// 正常续期时不会取消
+	l := newTestLock(t, r, "lock:test", 30*time.Millisecond)
+	if ok, _ := l.TryLock(ctx); !ok {
+		t.Fatal("not locked")
+	}
+	lockCtx, stop := l.AutoRefresh(ctx)
+	time.Sleep(100 * time.Millisecond)
+	if lockCtx.Err() != nil {
+		t.Fatalf("ctx cancelled while holding the lock: %v", context.Cause(lockCtx))
+	}
+	stop()
+	if context.Cause(lockCtx) != context.Canceled {
+		t.Fatalf("cause after stop = %v", context.Cause(lockCtx))
+	}
+}
@@ -3,6 +3,8 @@ package database
 import (
 	"common/config"
 	"common/logs"
+	"common/metrics"
+	"common/tracing"
 	"context"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
@@ -13,6 +15,8 @@ import (
 type MongoManager struct {
 	Cli *mongo.Client
 	Db *mongo.Database
+
+	disableTransaction bool // 未部署副本集，不支持事务
 }
 
 func NewMongo() *MongoManager {
@@ -21,13 +25,14 @@ func NewMongo() *MongoManager {
 	defer cancel();
 
 	// 设置连接参数
-	clientOptions := options.Client().ApplyURI(config.Conf.Database.MongoConf.Url)
+	clientOptions := options.Client().ApplyURI(config.Current().Database.MongoConf.Url)
 	clientOptions.SetAuth(options.Credential{
-		Username: config.Conf.Database.MongoConf.UserName,
-		Password: config.Conf.Database.MongoConf.Password,
+		Username: config.Current().Database.MongoConf.UserName,
+		Password: config.Current().Database.MongoConf.Password,
 	})
-	clientOptions.SetMinPoolSize(uint64(config.Conf.Database.MongoConf.MinPoolSize))
-	clientOptions.SetMaxPoolSize(uint64(config.Conf.Database.MongoConf.MaxPoolSize))
+	clientOptions.SetMinPoolSize(uint64(config.Current().Database.MongoConf.MinPoolSize))
+	clientOptions.SetMaxPoolSize(uint64(config.Current().Database.MongoConf.MaxPoolSize))
+	clientOptions.SetMonitor(tracing.MongoMonitor(metrics.MongoMonitor()))
 
 	// 进行连接
 	client, err := mongo.Connect(ctx, clientOptions)
@@ -42,12 +47,18 @@ func NewMongo() *MongoManager {
 	}
 
 	m := &MongoManager{
-		Cli: client,
+		Cli:                client,
+		disableTransaction: config.Current().Database.MongoConf.DisableTransaction,
 	}
-	m.Db = m.Cli.Database(config.Conf.Database.MongoConf.Db)
+	m.Db = m.Cli.Database(config.Current().Database.MongoConf.Db)
 	return m
 }
 
+// Ping 检查mongo是否可用，用于就绪检查
+func (m *MongoManager) Ping(ctx context.Context) error {
+	return m.Cli.Ping(ctx, readpref.Primary())
+}
+
 func (m *MongoManager) Close()  {
 	if err := m.Cli.Disconnect(context.TODO()); err != nil {
 		logs.Error("mongo close err:%v", err)
@@ -3,77 +3,218 @@ package database
 import (
 	"common/config"
 	"common/logs"
+	"common/metrics"
+	"common/tracing"
 	"context"
+	"errors"
+	"fmt"
 	"github.com/redis/go-redis/v9"
 	"time"
 )
 
+// RedisManager redis连接管理，单节点、哨兵、集群统一使用UniversalClient
 type RedisManager struct {
-	ClusterCi *redis.ClusterClient
-	Cli       *redis.Client
+	Cli redis.UniversalClient
 }
 
 func NewRedis() *RedisManager {
-	var clusterCli *redis.ClusterClient
-	var cli *redis.Client
-	clusterAddrs := config.Conf.Database.RedisConf.ClusterAddrs
-	if len(clusterAddrs) < 0 {
-		// 单节点redis
-		cli = redis.NewClient(&redis.Options{
-			Password:     config.Conf.Database.RedisConf.Password,
-			Addr:         config.Conf.Database.RedisConf.Addr,
-			PoolSize:     config.Conf.Database.RedisConf.PoolSize,
-			MinIdleConns: config.Conf.Database.RedisConf.MinIdleConns,
-		})
-	} else {
-		//集群
-		clusterCli = redis.NewClusterClient(&redis.ClusterOptions{
-			Addrs:        config.Conf.Database.RedisConf.ClusterAddrs,
-			PoolSize:     config.Conf.Database.RedisConf.PoolSize,
-			MinIdleConns: config.Conf.Database.RedisConf.MinIdleConns,
-			Password:     config.Conf.Database.RedisConf.Password,
-		})
-	}
+	cli := newRedisClient(config.Current().Database.RedisConf)
+
+	// 命令耗时监控和链路追踪
+	cli.AddHook(metrics.RedisHook{})
+	cli.AddHook(tracing.RedisHook{})
 
 	// ping
-	if clusterCli != nil {
-		if err := clusterCli.Ping(context.TODO()).Err(); err != nil {
-			logs.Fatal("redis cluster ping err: %v", err)
-		}
-	} else {
-		if err := cli.Ping(context.TODO()).Err(); err != nil {
-			logs.Fatal("redis ping err: %v", err)
-		}
+	if err := cli.Ping(context.TODO()).Err(); err != nil {
+		logs.Fatal("redis ping err: %v", err)
 	}
 
 	return &RedisManager{
-		ClusterCi: clusterCli,
-		Cli:       cli,
+		Cli: cli,
 	}
 }
 
-// 关闭redis连接
-func (r *RedisManager)Close()  {
-	if r.ClusterCi != nil {
-		if err := r.ClusterCi.Close(); err != nil {
-			logs.Error("redis cluster close err: %v", err)
-		}
+// newRedisClient 根据配置选择redis的部署模式：配置了masterName为哨兵，配置了clusterAddrs为集群，否则为单节点
+func newRedisClient(conf config.RedisConf) redis.UniversalClient {
+	if conf.MasterName != "" {
+		// 哨兵
+		return redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:       conf.MasterName,
+			SentinelAddrs:    conf.SentinelAddrs,
+			SentinelPassword: conf.SentinelPassword,
+			Password:         conf.Password,
+			DB:               conf.Db,
+			PoolSize:         conf.PoolSize,
+			MinIdleConns:     conf.MinIdleConns,
+		})
+	}
+	if len(conf.ClusterAddrs) > 0 {
+		//集群
+		return redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:        conf.ClusterAddrs,
+			PoolSize:     conf.PoolSize,
+			MinIdleConns: conf.MinIdleConns,
+			Password:     conf.Password,
+		})
 	}
-	if r.Cli != nil {
-		if err := r.Cli.Close(); err != nil {
-			logs.Error("redis close err: %v", err)
-		}
+	// 单节点redis，没有配置addr时使用host和port
+	addr := conf.Addr
+	if addr == "" {
+		addr = fmt.Sprintf("%s:%d", conf.Host, conf.Port)
 	}
+	return redis.NewClient(&redis.Options{
+		Password:     conf.Password,
+		Addr:         addr,
+		DB:           conf.Db,
+		PoolSize:     conf.PoolSize,
+		MinIdleConns: conf.MinIdleConns,
+	})
+}
+
+// Ping 检查redis是否可用，用于就绪检查
+func (r *RedisManager) Ping(ctx context.Context) error {
+	return r.Cli.Ping(ctx).Err()
+}
+
+// 关闭redis连接
+func (r *RedisManager) Close() {
+	if err := r.Cli.Close(); err != nil {
+		logs.Error("redis close err: %v", err)
+	}
+}
+
+// IsNil 判断是否是key不存在的错误
+func IsNil(err error) bool {
+	return errors.Is(err, redis.Nil)
 }
 
 // 封装Set
 func (r *RedisManager) Set(ctx context.Context, key, value string, expire time.Duration) error {
-	if r.ClusterCi != nil {
-		return r.ClusterCi.Set(ctx, key, value, expire).Err()
-	}
+	return r.Cli.Set(ctx, key, value, expire).Err()
+}
 
-	if r.Cli != nil {
-		return r.Cli.Set(ctx, key, value, expire).Err()
-	}
-	return nil
+// SetNX key不存在时才设置，返回是否设置成功
+func (r *RedisManager) SetNX(ctx context.Context, key, value string, expire time.Duration) (bool, error) {
+	return r.Cli.SetNX(ctx, key, value, expire).Result()
+}
+
+// Get key不存在时返回redis.Nil，可以用IsNil判断
+func (r *RedisManager) Get(ctx context.Context, key string) (string, error) {
+	return r.Cli.Get(ctx, key).Result()
+}
+
+// GetDel 获取并删除key，key不存在时返回redis.Nil，用于只能使用一次的值
+func (r *RedisManager) GetDel(ctx context.Context, key string) (string, error) {
+	return r.Cli.GetDel(ctx, key).Result()
+}
+
+func (r *RedisManager) Del(ctx context.Context, keys ...string) (int64, error) {
+	return r.Cli.Del(ctx, keys...).Result()
+}
+
+func (r *RedisManager) Exists(ctx context.Context, key string) (bool, error) {
+	n, err := r.Cli.Exists(ctx, key).Result()
+	return n > 0, err
+}
+
+func (r *RedisManager) Expire(ctx context.Context, key string, expire time.Duration) (bool, error) {
+	return r.Cli.Expire(ctx, key, expire).Result()
+}
+
+func (r *RedisManager) TTL(ctx context.Context, key string) (time.Duration, error) {
+	return r.Cli.TTL(ctx, key).Result()
+}
+
+func (r *RedisManager) Incr(ctx context.Context, key string) (int64, error) {
+	return r.Cli.Incr(ctx, key).Result()
+}
+
+func (r *RedisManager) IncrBy(ctx context.Context, key string, value int64) (int64, error) {
+	return r.Cli.IncrBy(ctx, key, value).Result()
+}
+
+func (r *RedisManager) Decr(ctx context.Context, key string) (int64, error) {
+	return r.Cli.Decr(ctx, key).Result()
+}
+
+// ---------------- hash ----------------
+
+func (r *RedisManager) HGet(ctx context.Context, key, field string) (string, error) {
+	return r.Cli.HGet(ctx, key, field).Result()
+}
+
+func (r *RedisManager) HGetAll(ctx context.Context, key string) (map[string]string, error) {
+	return r.Cli.HGetAll(ctx, key).Result()
+}
+
+// HSet values支持 "k1", "v1", "k2", "v2" 或 map[string]any
+func (r *RedisManager) HSet(ctx context.Context, key string, values ...any) error {
+	return r.Cli.HSet(ctx, key, values...).Err()
+}
+
+func (r *RedisManager) HDel(ctx context.Context, key string, fields ...string) (int64, error) {
+	return r.Cli.HDel(ctx, key, fields...).Result()
+}
+
+func (r *RedisManager) HIncrBy(ctx context.Context, key, field string, incr int64) (int64, error) {
+	return r.Cli.HIncrBy(ctx, key, field, incr).Result()
+}
+
+// ---------------- sorted set ----------------
+
+func (r *RedisManager) ZAdd(ctx context.Context, key string, members ...redis.Z) error {
+	return r.Cli.ZAdd(ctx, key, members...).Err()
+}
+
+func (r *RedisManager) ZIncrBy(ctx context.Context, key string, incr float64, member string) (float64, error) {
+	return r.Cli.ZIncrBy(ctx, key, incr, member).Result()
+}
+
+func (r *RedisManager) ZRem(ctx context.Context, key string, members ...any) (int64, error) {
+	return r.Cli.ZRem(ctx, key, members...).Result()
+}
+
+func (r *RedisManager) ZScore(ctx context.Context, key, member string) (float64, error) {
+	return r.Cli.ZScore(ctx, key, member).Result()
+}
+
+func (r *RedisManager) ZCard(ctx context.Context, key string) (int64, error) {
+	return r.Cli.ZCard(ctx, key).Result()
+}
+
+// ZRevRank 按分数从高到低的排名，从0开始
+func (r *RedisManager) ZRevRank(ctx context.Context, key, member string) (int64, error) {
+	return r.Cli.ZRevRank(ctx, key, member).Result()
+}
+
+// ZRangeWithScores 按分数从低到高取[start, stop]区间的成员
+func (r *RedisManager) ZRangeWithScores(ctx context.Context, key string, start, stop int64) ([]redis.Z, error) {
+	return r.Cli.ZRangeWithScores(ctx, key, start, stop).Result()
+}
+
+// ZRevRangeWithScores 按分数从高到低取[start, stop]区间的成员，用于排行榜
+func (r *RedisManager) ZRevRangeWithScores(ctx context.Context, key string, start, stop int64) ([]redis.Z, error) {
+	return r.Cli.ZRevRangeWithScores(ctx, key, start, stop).Result()
+}
+
+// ---------------- pipeline & lua ----------------
+
+// Pipelined 批量执行命令，减少网络往返
+func (r *RedisManager) Pipelined(ctx context.Context, fn func(pipe redis.Pipeliner) error) ([]redis.Cmder, error) {
+	return r.Cli.Pipelined(ctx, fn)
+}
+
+// TxPipelined 以MULTI/EXEC事务的方式批量执行命令
+func (r *RedisManager) TxPipelined(ctx context.Context, fn func(pipe redis.Pipeliner) error) ([]redis.Cmder, error) {
+	return r.Cli.TxPipelined(ctx, fn)
+}
+
+// NewScript 创建lua脚本，建议定义成包级变量复用
+func NewScript(src string) *redis.Script {
+	return redis.NewScript(src)
+}
+
+// Eval 执行lua脚本，优先使用EVALSHA，脚本未加载时自动回退到EVAL
+func (r *RedisManager) Eval(ctx context.Context, script *redis.Script, keys []string, args ...any) (any, error) {
+	return script.Run(ctx, r.Cli, keys, args...).Result()
 }
@@ -0,0 +1,176 @@
+package database
+
+import (
+	"common/logs"
+	"context"
+	"errors"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+)
+
+// 事务遇到临时错误时的最大重试次数
+const maxTransactionRetries = 3
+
+// mongo返回的错误标签
+const (
+	transientTransactionError      = "TransientTransactionError"
+	unknownTransactionCommitResult = "UnknownTransactionCommitResult"
+)
+
+// Transactor 事务执行器，fn中使用传入的ctx调用Repository，即可加入同一个事务
+type Transactor interface {
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// WithTransaction 在mongo事务中执行fn，多文档更新要么全部成功要么全部失败
+// 遇到TransientTransactionError时整个事务重试，遇到UnknownTransactionCommitResult时重试提交
+// 未部署副本集的环境（配置disableTransaction）退化为补偿写：fn失败时逆序执行Repository登记的补偿操作
+func (m *MongoManager) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if m.disableTransaction {
+		return Compensating{}.WithTransaction(ctx, fn)
+	}
+	ctx, hooks := withHooks(ctx)
+	defer hooks.run()
+	session, err := m.Cli.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(context.Background())
+
+	opts := options.Transaction().
+		SetReadConcern(readconcern.Snapshot()).
+		SetWriteConcern(writeconcern.Majority())
+	for i := 0; ; i++ {
+		err = mongo.WithSession(ctx, session, func(sc mongo.SessionContext) error {
+			if err := sc.StartTransaction(opts); err != nil {
+				return err
+			}
+			if err := fn(sc); err != nil {
+				_ = sc.AbortTransaction(context.Background())
+				return err
+			}
+			return commit(sc)
+		})
+		if err == nil || i >= maxTransactionRetries || !hasErrorLabel(err, transientTransactionError) {
+			return err
+		}
+		logs.Warn("mongo transaction transient error, retry %d: %v", i+1, err)
+	}
+}
+
+// commit 提交事务，提交结果未知时重试提交
+func commit(sc mongo.SessionContext) error {
+	var err error
+	for i := 0; i <= maxTransactionRetries; i++ {
+		err = sc.CommitTransaction(sc)
+		if err == nil || !hasErrorLabel(err, unknownTransactionCommitResult) {
+			return err
+		}
+	}
+	return err
+}
+
+func hasErrorLabel(err error, label string) bool {
+	var le mongo.LabeledError
+	return errors.As(err, &le) && le.HasErrorLabel(label)
+}
+
+// Compensating 补偿写事务，用于不支持事务的mongo部署和单元测试
+// 不保证隔离性，只保证fn失败时尽力撤销已经完成的写操作
+type Compensating struct{}
+
+func (Compensating) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	ctx, hooks := withHooks(ctx)
+	// 补偿完成之后再执行
+	defer hooks.run()
+	c := &compensations{}
+	err := fn(context.WithValue(ctx, compensationKey{}, c))
+	if err != nil {
+		c.rollback()
+	}
+	return err
+}
+
+type compensationKey struct{}
+
+// compensations 已完成写操作的补偿操作
+type compensations struct {
+	lock sync.Mutex
+	fns  []func(ctx context.Context) error
+}
+
+// rollback 逆序执行补偿操作，补偿失败只记录日志，需要人工介入
+func (c *compensations) rollback() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for i := len(c.fns) - 1; i >= 0; i-- {
+		if err := c.fns[i](context.Background()); err != nil {
+			logs.Error("compensating rollback err: %v", err)
+		}
+	}
+	c.fns = nil
+}
+
+// Compensate Repository完成写操作后登记对应的补偿操作，不在补偿写事务中时不做处理
+func Compensate(ctx context.Context, fn func(ctx context.Context) error) {
+	c, ok := ctx.Value(compensationKey{}).(*compensations)
+	if !ok {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.fns = append(c.fns, fn)
+}
+
+// InCompensation 是否在补偿写事务中，用于需要额外读取旧数据才能补偿的写操作
+func InCompensation(ctx context.Context) bool {
+	_, ok := ctx.Value(compensationKey{}).(*compensations)
+	return ok
+}
+
+type hooksKey struct{}
+
+// txHooks 事务结束（提交或回滚）后执行的操作
+type txHooks struct {
+	lock sync.Mutex
+	fns  []func(ctx context.Context)
+}
+
+// withHooks 嵌套在其他事务中时使用外层的hooks，由外层事务结束后执行
+func withHooks(ctx context.Context) (context.Context, *txHooks) {
+	if _, ok := ctx.Value(hooksKey{}).(*txHooks); ok {
+		return ctx, nil
+	}
+	h := &txHooks{}
+	return context.WithValue(ctx, hooksKey{}, h), h
+}
+
+func (h *txHooks) run() {
+	if h == nil {
+		return
+	}
+	h.lock.Lock()
+	fns := h.fns
+	h.fns = nil
+	h.lock.Unlock()
+	for _, fn := range fns {
+		fn(context.Background())
+	}
+}
+
+// AfterTransaction 事务提交或回滚之后执行fn，不在事务中时立即执行
+// 用于删除缓存：提交前删除的话，其他请求可能读到提交前的旧数据或者最终被回滚的数据重新写入缓存
+func AfterTransaction(ctx context.Context, fn func(ctx context.Context)) {
+	h, ok := ctx.Value(hooksKey{}).(*txHooks)
+	if !ok {
+		fn(ctx)
+		return
+	}
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.fns = append(h.fns, fn)
+}
@@ -0,0 +1,58 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestAfterTransaction(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want []string
+	}{
+		{"commit", nil, []string{"write", "after", "nested"}},
+		{"rollback", errors.New("failed"), []string{"write", "compensate", "after", "nested"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var steps []string
+			err := Compensating{}.WithTransaction(context.Background(), func(ctx context.Context) error {
+				steps = append(steps, "write")
+				Compensate(ctx, func(context.Context) error {
+					steps = append(steps, "compensate")
+					return nil
+				})
+				AfterTransaction(ctx, func(context.Context) {
+					steps = append(steps, "after")
+				})
+				// 嵌套的事务结束时不执行，等外层事务结束
+				_ = Compensating{}.WithTransaction(ctx, func(ctx context.Context) error {
+					AfterTransaction(ctx, func(context.Context) {
+						steps = append(steps, "nested")
+					})
+					return nil
+				})
+				if len(steps) != 1 {
+					t.Fatalf("steps before end = %v", steps)
+				}
+				return tt.err
+			})
+			if err != tt.err {
+				t.Fatalf("err = %v", err)
+			}
+			if !reflect.DeepEqual(steps, tt.want) {
+				t.Fatalf("steps = %v, want %v", steps, tt.want)
+			}
+		})
+	}
+
+	// 不在事务中时立即执行
+	called := false
+	AfterTransaction(context.Background(), func(context.Context) { called = true })
+	if !called {
+		t.Fatal("hook not called outside transaction")
+	}
+}
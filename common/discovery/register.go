@@ -5,7 +5,9 @@ import (
 	"common/logs"
 	"context"
 	"encoding/json"
+	"errors"
 	clientv3 "go.etcd.io/etcd/client/v3"
+	"sync/atomic"
 	"time"
 )
 
@@ -21,6 +23,8 @@ type Register struct {
 	keepAliveCh <-chan *clientv3.LeaseKeepAliveResponse // 租约心跳
 	info        Server                                  // 注册的Server信息
 	closeCh     chan struct{}                           // close标识
+	doneCh      chan struct{}                           // 注销完成标识
+	registered  atomic.Bool                             // 当前是否已注册到etcd
 }
 
 // 向外部暴露的创建etcd客户端的方法
@@ -57,6 +61,7 @@ func (r *Register) Register(conf config.EtcdConf) error {
 	}
 	// 给etcd注销的通道赋一个初始容量
 	r.closeCh = make(chan struct{})
+	r.doneCh = make(chan struct{})
 
 	// 放入协程中，根据心跳结果，做相应操作
 	go r.watcher()
@@ -87,7 +92,11 @@ func (r *Register) register() error {
 	if err != nil {
 		return err
 	}
-	return r.bindLease(ctx, r.info.BuildRegisterKey(), string(data))
+	if err = r.bindLease(ctx, r.info.BuildRegisterKey(), string(data)); err != nil {
+		return err
+	}
+	r.registered.Store(true)
+	return nil
 }
 
 // 创建租约
@@ -127,10 +136,12 @@ func (r *Register) watcher() {
 
 	// 租约到期检测：创建了一个定时器 ticker，每隔 r.info.Ttl 秒触发一次，检查是否自动注册
 	ticker := time.NewTicker(time.Duration(r.info.Ttl) * time.Second)
+	defer ticker.Stop()
 	for {
 		select {
 		case <-r.closeCh:
 			// 监测到注销信号，执行注销操作
+			r.registered.Store(false)
 			// step1: 删除key-value
 			if err := r.unRegister(); err != nil {
 				logs.Error("==> close and unregister failed, err: %v", err)
@@ -139,12 +150,19 @@ func (r *Register) watcher() {
 			if _, err := r.etcdCli.Revoke(context.Background(), r.leaseId); err != nil {
 				logs.Error("==> close and revoke lease failed, err: %v", err)
 			}
+			// step3: 关闭etcd连接
+			if err := r.etcdCli.Close(); err != nil {
+				logs.Error("==> close etcd client failed, err: %v", err)
+			}
 			logs.Info("==> Unregister etcd...")
+			close(r.doneCh)
+			return
 
 		case res := <-r.keepAliveCh:
 			// 监测到心跳
 			if res != nil {
 				if err := r.register(); err != nil {
+					r.registered.Store(false)
 					logs.Error("==> keepAliveCh register failed, err: %v", err)
 				}
 			}
@@ -152,6 +170,7 @@ func (r *Register) watcher() {
 			// 没有监测到心跳，注册租约
 			if r.keepAliveCh == nil {
 				if err := r.register(); err != nil {
+					r.registered.Store(false)
 					logs.Error("==> ticker register failed err: %v", err)
 				}
 			}
@@ -166,7 +185,19 @@ func (r *Register) unRegister() error {
 	return err
 }
 
-// Close 关闭etcd连接
+// Check 检查是否已注册到etcd，用于就绪检查
+func (r *Register) Check(ctx context.Context) error {
+	if !r.registered.Load() {
+		return errors.New("not registered to etcd")
+	}
+	return nil
+}
+
+// Close 从etcd注销并关闭etcd连接，注销完成后才返回
 func (r *Register) Close() {
+	if r.closeCh == nil {
+		return
+	}
 	r.closeCh <- struct{}{}
+	<-r.doneCh
 }
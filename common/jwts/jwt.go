@@ -0,0 +1,84 @@
+package jwts
+
+import (
+	"common/config"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// 未配置jwt.accessExp时access token的默认有效期
+const defaultAccessExpire = 30 * time.Minute
+
+var (
+	ErrTokenInvalid = errors.New("token is invalid")
+	ErrTokenRevoked = errors.New("token is revoked")
+	ErrTokenReused  = errors.New("refresh token is reused")
+)
+
+// CustomClaims access token中的信息，Sid为登录会话id，会话被吊销后token随之失效
+type CustomClaims struct {
+	Uid string `json:"uid"`
+	Sid string `json:"sid"`
+	jwt.RegisteredClaims
+}
+
+// accessExpire access token的有效期，配置中的jwt.accessExp单位为分钟
+func accessExpire() time.Duration {
+	exp := config.Current().Jwt.AccessExp
+	if exp <= 0 {
+		return defaultAccessExpire
+	}
+	return time.Duration(exp) * time.Minute
+}
+
+// refreshExpire refresh token的有效期，配置中的jwt.exp单位为天
+func refreshExpire() time.Duration {
+	return time.Duration(config.Current().Jwt.Exp) * 24 * time.Hour
+}
+
+// genAccessToken 使用当前密钥签名，header中带上kid，验证时按kid选择密钥
+func genAccessToken(uid, sid string) (string, error) {
+	now := time.Now()
+	claims := &CustomClaims{
+		Uid: uid,
+		Sid: sid,
+		RegisteredClaims: jwt.RegisteredClaims{
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(accessExpire())),
+		},
+	}
+	t := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	conf := config.Current().Jwt
+	t.Header["kid"] = conf.Kid
+	return t.SignedString([]byte(conf.Secret))
+}
+
+// parseAccessToken 解析并校验access token的签名和有效期
+func parseAccessToken(token string) (*CustomClaims, error) {
+	claims := new(CustomClaims)
+	t, err := jwt.ParseWithClaims(token, claims, keyFunc, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrTokenInvalid, err)
+	}
+	if !t.Valid || claims.Uid == "" || claims.Sid == "" {
+		return nil, ErrTokenInvalid
+	}
+	return claims, nil
+}
+
+// keyFunc 按kid选择密钥：当前密钥或轮换下来的旧密钥
+// 更换密钥时把旧密钥移到jwt.keys中，已签发的access token过期前仍然有效，不会把所有人踢下线
+func keyFunc(t *jwt.Token) (any, error) {
+	kid, _ := t.Header["kid"].(string)
+	conf := config.Current().Jwt
+	if kid == conf.Kid {
+		return []byte(conf.Secret), nil
+	}
+	if secret, ok := conf.Keys[kid]; ok {
+		return []byte(secret), nil
+	}
+	return nil, fmt.Errorf("unknown kid %q", kid)
+}
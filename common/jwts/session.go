@@ -0,0 +1,155 @@
+package jwts
+
+import (
+	"common/database"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 登录会话在redis中的存储：
+// token:session:<sid>  hash{uid, device, token}，token为当前refresh token的sha256，刷新时轮换
+// token:sessions:<uid> set，用户所有的sid，退出登录、冻结时据此吊销全部会话
+const (
+	sessionKeyPrefix      = "token:session:"
+	userSessionsKeyPrefix = "token:sessions:"
+)
+
+// 刷新时校验设备和refresh token并轮换，返回 {状态, uid}
+// 1成功 0会话不存在 -1设备不一致 -2refresh token已被使用过（会话被盗用，删除整个会话）
+var refreshScript = database.NewScript(`
+local s = redis.call("HMGET", KEYS[1], "uid", "device", "token")
+if not s[1] then
+	return {0, ""}
+end
+if s[2] ~= ARGV[1] then
+	return {-1, s[1]}
+end
+if s[3] ~= ARGV[2] then
+	redis.call("DEL", KEYS[1])
+	return {-2, s[1]}
+end
+redis.call("HSET", KEYS[1], "token", ARGV[3])
+redis.call("PEXPIRE", KEYS[1], ARGV[4])
+return {1, s[1]}`)
+
+// Token 登录、刷新后返回给客户端的token
+type Token struct {
+	AccessToken  string `json:"token"`
+	RefreshToken string `json:"refreshToken"`
+	ExpiresIn    int64  `json:"expiresIn"` // access token有效期，单位秒
+}
+
+// Issue 登录成功后创建会话，refresh token绑定设备，只能在该设备上刷新
+func Issue(ctx context.Context, r *database.RedisManager, uid string, device string) (*Token, error) {
+	sid := randomHex(16)
+	secret := randomHex(32)
+	ttl := refreshExpire()
+	_, err := r.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, sessionKeyPrefix+sid, "uid", uid, "device", device, "token", hash(secret))
+		pipe.PExpire(ctx, sessionKeyPrefix+sid, ttl)
+		pipe.SAdd(ctx, userSessionsKeyPrefix+uid, sid)
+		pipe.PExpire(ctx, userSessionsKeyPrefix+uid, ttl)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newToken(uid, sid, secret)
+}
+
+// Refresh 用refresh token换取新的access token和refresh token，旧的refresh token随即失效
+// 已经失效的refresh token再次使用时视为被盗用，返回ErrTokenReused并吊销该会话
+func Refresh(ctx context.Context, r *database.RedisManager, refreshToken string, device string) (*Token, error) {
+	sid, secret, ok := strings.Cut(refreshToken, ".")
+	if !ok || sid == "" || secret == "" {
+		return nil, ErrTokenInvalid
+	}
+	newSecret := randomHex(32)
+	ttl := refreshExpire()
+	res, err := r.Eval(ctx, refreshScript, []string{sessionKeyPrefix + sid},
+		device, hash(secret), hash(newSecret), ttl.Milliseconds())
+	if err != nil {
+		return nil, err
+	}
+	values, _ := res.([]any)
+	if len(values) != 2 {
+		return nil, ErrTokenInvalid
+	}
+	status, _ := values[0].(int64)
+	uid, _ := values[1].(string)
+	switch status {
+	case 1:
+	case -2:
+		return nil, ErrTokenReused
+	default:
+		return nil, ErrTokenInvalid
+	}
+	if _, err = r.Expire(ctx, userSessionsKeyPrefix+uid, ttl); err != nil {
+		return nil, err
+	}
+	return newToken(uid, sid, newSecret)
+}
+
+// Verify 解析access token并检查会话是否已被吊销，返回其中的uid
+// token无效返回ErrTokenInvalid，会话已吊销返回ErrTokenRevoked，其他错误为redis错误
+func Verify(ctx context.Context, r *database.RedisManager, token string) (string, error) {
+	claims, err := parseAccessToken(token)
+	if err != nil {
+		return "", err
+	}
+	exists, err := r.Exists(ctx, sessionKeyPrefix+claims.Sid)
+	if err != nil {
+		return "", err
+	}
+	if !exists {
+		return "", ErrTokenRevoked
+	}
+	return claims.Uid, nil
+}
+
+// Revoke 吊销uid的所有会话，已签发的access token和refresh token全部失效，用于退出登录、冻结账号
+func Revoke(ctx context.Context, r *database.RedisManager, uid string) error {
+	key := userSessionsKeyPrefix + uid
+	sids, err := r.Cli.SMembers(ctx, key).Result()
+	if err != nil {
+		return err
+	}
+	// 集群模式下多个key可能不在同一个slot，逐个删除
+	_, err = r.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, sid := range sids {
+			pipe.Del(ctx, sessionKeyPrefix+sid)
+		}
+		pipe.Del(ctx, key)
+		return nil
+	})
+	return err
+}
+
+func newToken(uid, sid, secret string) (*Token, error) {
+	accessToken, err := genAccessToken(uid, sid)
+	if err != nil {
+		return nil, err
+	}
+	return &Token{
+		AccessToken:  accessToken,
+		RefreshToken: sid + "." + secret,
+		ExpiresIn:    int64(accessExpire().Seconds()),
+	}, nil
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// hash redis中只保存refresh token的摘要，redis数据泄露也无法直接使用
+func hash(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
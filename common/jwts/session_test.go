@@ -0,0 +1,101 @@
+package jwts
+
+import (
+	"common/config"
+	"common/database"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func newTestRedis(t *testing.T) *database.RedisManager {
+	config.Set(&config.Config{Jwt: config.JwtConf{Kid: "k1", Secret: "secret", Exp: 7, AccessExp: 30}})
+	server := miniredis.RunT(t)
+	return &database.RedisManager{Cli: redis.NewClient(&redis.Options{Addr: server.Addr()})}
+}
+
+func TestRefresh(t *testing.T) {
+	ctx := context.Background()
+	r := newTestRedis(t)
+	token, err := Issue(ctx, r, "10000", "device")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = Refresh(ctx, r, token.RefreshToken, "other"); !errors.Is(err, ErrTokenInvalid) {
+		t.Fatalf("refresh on other device err = %v, want ErrTokenInvalid", err)
+	}
+
+	// 刷新后旧的refresh token失效，access token仍然有效
+	rotated, err := Refresh(ctx, r, token.RefreshToken, "device")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rotated.RefreshToken == token.RefreshToken {
+		t.Fatal("refresh token not rotated")
+	}
+	if uid, err := Verify(ctx, r, rotated.AccessToken); err != nil || uid != "10000" {
+		t.Fatalf("verify = %s, %v", uid, err)
+	}
+
+	// 旧的refresh token被再次使用，整个会话被吊销
+	if _, err = Refresh(ctx, r, token.RefreshToken, "device"); !errors.Is(err, ErrTokenReused) {
+		t.Fatalf("reuse err = %v, want ErrTokenReused", err)
+	}
+	if _, err = Refresh(ctx, r, rotated.RefreshToken, "device"); !errors.Is(err, ErrTokenInvalid) {
+		t.Fatalf("refresh after reuse err = %v, want ErrTokenInvalid", err)
+	}
+	if _, err = Verify(ctx, r, rotated.AccessToken); !errors.Is(err, ErrTokenRevoked) {
+		t.Fatalf("verify after reuse err = %v, want ErrTokenRevoked", err)
+	}
+}
+
+func TestRevoke(t *testing.T) {
+	ctx := context.Background()
+	r := newTestRedis(t)
+	phone, err := Issue(ctx, r, "10000", "phone")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pad, err := Issue(ctx, r, "10000", "pad")
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := Issue(ctx, r, "10001", "phone")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = Revoke(ctx, r, "10000"); err != nil {
+		t.Fatal(err)
+	}
+	for _, token := range []*Token{phone, pad} {
+		if _, err = Verify(ctx, r, token.AccessToken); !errors.Is(err, ErrTokenRevoked) {
+			t.Fatalf("verify err = %v, want ErrTokenRevoked", err)
+		}
+	}
+	if _, err = Verify(ctx, r, other.AccessToken); err != nil {
+		t.Fatalf("other user verify err = %v", err)
+	}
+}
+
+func TestKeyRotation(t *testing.T) {
+	ctx := context.Background()
+	r := newTestRedis(t)
+	token, err := Issue(ctx, r, "10000", "device")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 更换密钥，旧密钥移到keys中，已签发的token仍然有效
+	config.Current().Jwt = config.JwtConf{Kid: "k2", Secret: "new", Exp: 7, Keys: map[string]string{"k1": "secret"}}
+	if _, err = Verify(ctx, r, token.AccessToken); err != nil {
+		t.Fatalf("verify with rotated key err = %v", err)
+	}
+	// 旧密钥删除后失效
+	config.Current().Jwt.Keys = nil
+	if _, err = Verify(ctx, r, token.AccessToken); !errors.Is(err, ErrTokenInvalid) {
+		t.Fatalf("verify with removed key err = %v, want ErrTokenInvalid", err)
+	}
+}
@@ -0,0 +1,73 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+
+	"google.golang.org/grpc"
+)
+
+// HttpServer http服务的启停钩子，停止时不再接收新连接，等待处理中的请求完成
+// server.TLSConfig不为空时以https方式提供服务，证书由TLSConfig提供
+func (l *Lifecycle) HttpServer(name string, server *http.Server) Hook {
+	return Hook{
+		Name: name,
+		OnStart: func(ctx context.Context) error {
+			// 同步监听端口，端口被占用等错误在启动阶段就能返回
+			listen, err := net.Listen("tcp", server.Addr)
+			if err != nil {
+				return err
+			}
+			go func() {
+				var err error
+				if server.TLSConfig != nil {
+					err = server.ServeTLS(listen, "", "")
+				} else {
+					err = server.Serve(listen)
+				}
+				if err != nil && !errors.Is(err, http.ErrServerClosed) {
+					l.Error(err)
+				}
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			return server.Shutdown(ctx)
+		},
+	}
+}
+
+// GrpcServer grpc服务的启停钩子，停止时等待处理中的请求完成，超时则强制停止
+func (l *Lifecycle) GrpcServer(name string, server *grpc.Server, addr string) Hook {
+	return Hook{
+		Name: name,
+		OnStart: func(ctx context.Context) error {
+			listen, err := net.Listen("tcp", addr)
+			if err != nil {
+				return err
+			}
+			go func() {
+				if err := server.Serve(listen); err != nil {
+					l.Error(err)
+				}
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			done := make(chan struct{})
+			go func() {
+				server.GracefulStop()
+				close(done)
+			}()
+			select {
+			case <-done:
+				return nil
+			case <-ctx.Done():
+				server.Stop()
+				return ctx.Err()
+			}
+		},
+	}
+}
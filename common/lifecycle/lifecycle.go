@@ -0,0 +1,169 @@
+package lifecycle
+
+import (
+	"common/config"
+	"common/logs"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// 默认的启动、停止超时时间
+const (
+	DefaultStartTimeout = 15 * time.Second
+	DefaultStopTimeout  = 30 * time.Second
+)
+
+// Hook 组件的启停钩子，如：http、grpc服务，etcd注册，数据库连接，监控服务
+// OnStart不能阻塞，需要长期运行的服务应在OnStart中启动协程，运行出错时通过Lifecycle.Error上报
+type Hook struct {
+	Name    string
+	OnStart func(ctx context.Context) error
+	OnStop  func(ctx context.Context) error
+}
+
+// Lifecycle 按添加顺序启动组件，按相反的顺序停止组件
+// 例如依次添加 数据库->grpc服务->etcd注册，停止时会先从etcd注销，再等grpc处理完存量请求，最后关闭数据库
+type Lifecycle struct {
+	name         string
+	hooks        []Hook
+	errCh        chan error
+	startTimeout time.Duration
+	stopTimeout  time.Duration
+}
+
+type Option func(l *Lifecycle)
+
+// WithStartTimeout 所有组件启动的总超时时间
+func WithStartTimeout(d time.Duration) Option {
+	return func(l *Lifecycle) {
+		if d > 0 {
+			l.startTimeout = d
+		}
+	}
+}
+
+// WithStopTimeout 所有组件停止的总超时时间，超时后剩余组件的OnStop拿到的是已取消的ctx
+func WithStopTimeout(d time.Duration) Option {
+	return func(l *Lifecycle) {
+		if d > 0 {
+			l.stopTimeout = d
+		}
+	}
+}
+
+func New(name string, opts ...Option) *Lifecycle {
+	l := &Lifecycle{
+		name:         name,
+		errCh:        make(chan error, 1),
+		startTimeout: DefaultStartTimeout,
+		stopTimeout:  DefaultStopTimeout,
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// Append 添加组件，添加顺序即启动顺序
+func (l *Lifecycle) Append(hook Hook) {
+	l.hooks = append(l.hooks, hook)
+}
+
+// OnReload 添加重新加载配置后执行的函数，收到SIGHUP信号和配置文件被修改时都会执行
+func (l *Lifecycle) OnReload(fn func()) {
+	config.OnReload(fn)
+}
+
+// Error 组件运行过程中出现无法恢复的错误时调用，会触发整个程序停止
+func (l *Lifecycle) Error(err error) {
+	select {
+	case l.errCh <- err:
+	default:
+	}
+}
+
+// Run 启动所有组件，阻塞直到ctx结束、收到退出信号或者组件上报错误，然后停止所有组件
+func (l *Lifecycle) Run(ctx context.Context) error {
+	started, err := l.start(ctx)
+	if err != nil {
+		// 启动失败，把已经启动的组件停掉
+		l.stop(l.hooks[:started])
+		return err
+	}
+	logs.Info("%s started", l.name)
+
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, syscall.SIGTERM, syscall.SIGQUIT, syscall.SIGINT, syscall.SIGHUP)
+	defer signal.Stop(c)
+	for {
+		select {
+		// 上下文事件完成
+		case <-ctx.Done():
+			return l.stop(l.hooks)
+		// 组件运行出错
+		case err := <-l.errCh:
+			logs.Error("%s component failed: %v", l.name, err)
+			return errors.Join(err, l.stop(l.hooks))
+		// 收到信号
+		case s := <-c:
+			logs.Warn("get a signal %s", s.String())
+			if s == syscall.SIGHUP {
+				l.reload()
+				continue
+			}
+			err := l.stop(l.hooks)
+			logs.Warn("%s exit", l.name)
+			return err
+		}
+	}
+}
+
+// start 依次启动组件，返回启动成功的组件数量
+func (l *Lifecycle) start(ctx context.Context) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, l.startTimeout)
+	defer cancel()
+	for i, hook := range l.hooks {
+		if hook.OnStart == nil {
+			continue
+		}
+		if err := hook.OnStart(ctx); err != nil {
+			return i, fmt.Errorf("start %s: %w", hook.Name, err)
+		}
+		logs.Info("%s start %s finish", l.name, hook.Name)
+	}
+	return len(l.hooks), nil
+}
+
+// stop 逆序停止组件，某个组件停止失败不影响其他组件
+func (l *Lifecycle) stop(hooks []Hook) error {
+	ctx, cancel := context.WithTimeout(context.Background(), l.stopTimeout)
+	defer cancel()
+	var errs []error
+	for i := len(hooks) - 1; i >= 0; i-- {
+		hook := hooks[i]
+		if hook.OnStop == nil {
+			continue
+		}
+		if err := hook.OnStop(ctx); err != nil {
+			logs.Error("%s stop %s error: %v", l.name, hook.Name, err)
+			errs = append(errs, fmt.Errorf("stop %s: %w", hook.Name, err))
+			continue
+		}
+		logs.Info("%s stop %s finish", l.name, hook.Name)
+	}
+	logs.Info("stop app finish")
+	return errors.Join(errs...)
+}
+
+// reload 重新加载配置，成功后由config执行各组件注册的重新加载函数
+func (l *Lifecycle) reload() {
+	logs.Warn("%s reload config", l.name)
+	if err := config.Reload(); err != nil {
+		logs.Error("%s reload config error: %v", l.name, err)
+	}
+}
@@ -2,25 +2,34 @@ package logs
 
 import (
 	"common/config"
+	"context"
+	"fmt"
 	"os"
 	"time"
 
 	"github.com/charmbracelet/log"
+	"go.opentelemetry.io/otel/trace"
 )
 
-var logger *log.Logger
+// 未调用InitLog时（如单元测试）使用默认配置输出到标准错误
+var logger = log.New(os.Stderr)
 
 // InitLog 日志初始化
 func InitLog(appName string) {
 	logger = log.New(os.Stderr)
-	if config.Conf.Log.Level == "DEBUG" {
+	SetLevel(config.Current().Log.Level)
+	logger.SetPrefix(appName)
+	logger.SetReportTimestamp(true)
+	logger.SetTimeFormat(time.DateTime)
+}
+
+// SetLevel 修改日志级别，重新加载配置时调用，不替换logger，可以和写日志并发执行
+func SetLevel(level string) {
+	if level == "DEBUG" {
 		logger.SetLevel(log.DebugLevel)
 	} else {
 		logger.SetLevel(log.InfoLevel)
 	}
-	logger.SetPrefix(appName)
-	logger.SetReportTimestamp(true)
-	logger.SetTimeFormat(time.DateTime)
 }
 
 func Warn(format string, values ...any) {
@@ -53,3 +62,22 @@ func Info(format string, values ...any) {
 	}
 
 }
+
+// withTrace 上下文中有trace时，日志带上trace_id字段，方便跨服务串联日志
+func withTrace(ctx context.Context) *log.Logger {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.HasTraceID() {
+		return logger
+	}
+	return logger.With("trace_id", sc.TraceID().String())
+}
+
+func WarnCtx(ctx context.Context, format string, values ...any) {
+	withTrace(ctx).Warn(fmt.Sprintf(format, values...))
+}
+func ErrorCtx(ctx context.Context, format string, values ...any) {
+	withTrace(ctx).Error(fmt.Sprintf(format, values...))
+}
+func InfoCtx(ctx context.Context, format string, values ...any) {
+	withTrace(ctx).Info(fmt.Sprintf(format, values...))
+}
@@ -0,0 +1,94 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// 单次就绪检查的超时时间
+const checkTimeout = 3 * time.Second
+
+// Checker 就绪检查项，如：mongo、redis、etcd注册状态、依赖的grpc服务
+type Checker interface {
+	Name() string
+	Check(ctx context.Context) error
+}
+
+type checkerFunc struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+func (c checkerFunc) Name() string                    { return c.name }
+func (c checkerFunc) Check(ctx context.Context) error { return c.fn(ctx) }
+
+// NewChecker 通过函数构造一个检查项
+func NewChecker(name string, fn func(ctx context.Context) error) Checker {
+	return checkerFunc{name: name, fn: fn}
+}
+
+var (
+	checkerLock sync.RWMutex
+	checkers    []Checker
+)
+
+// RegisterChecker 注册就绪检查项，各服务在初始化完对应组件后注册
+func RegisterChecker(c ...Checker) {
+	checkerLock.Lock()
+	defer checkerLock.Unlock()
+	checkers = append(checkers, c...)
+}
+
+// healthz 存活检查，进程能响应即为存活
+func healthz(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("ok"))
+}
+
+// readyz 就绪检查，并发执行所有检查项，任意一项失败则返回503
+func readyz(w http.ResponseWriter, r *http.Request) {
+	checkerLock.RLock()
+	list := make([]Checker, len(checkers))
+	copy(list, checkers)
+	checkerLock.RUnlock()
+
+	ctx, cancel := context.WithTimeout(r.Context(), checkTimeout)
+	defer cancel()
+
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+	result := make(map[string]string, len(list))
+	ready := true
+	for _, c := range list {
+		wg.Add(1)
+		go func(c Checker) {
+			defer wg.Done()
+			msg := "ok"
+			err := c.Check(ctx)
+			if err != nil {
+				msg = err.Error()
+			}
+			lock.Lock()
+			defer lock.Unlock()
+			if err != nil {
+				ready = false
+			}
+			result[c.Name()] = msg
+		}(c)
+	}
+	wg.Wait()
+
+	code := http.StatusOK
+	if !ready {
+		code = http.StatusServiceUnavailable
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"ready":  ready,
+		"checks": result,
+	})
+}
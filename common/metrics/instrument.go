@@ -0,0 +1,129 @@
+package metrics
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/event"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// 存储操作的耗时分桶，单位秒，从0.5ms到1s
+var storageBuckets = []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1}
+
+// 各服务通用的标准指标
+var (
+	httpRequests      = Counter("http_requests_total", "http请求总数", "method", "path", "code")
+	httpDuration      = Histogram("http_request_duration_seconds", "http请求耗时", nil, "method", "path")
+	grpcServerHandled = Counter("grpc_server_handled_total", "grpc服务端处理的请求总数", "method", "code")
+	grpcServerLatency = Histogram("grpc_server_handling_seconds", "grpc服务端处理耗时", nil, "method")
+	grpcClientHandled = Counter("grpc_client_handled_total", "grpc客户端发起的请求总数", "method", "code")
+	grpcClientLatency = Histogram("grpc_client_handling_seconds", "grpc客户端请求耗时", nil, "method")
+	redisLatency      = Histogram("redis_command_duration_seconds", "redis命令耗时", storageBuckets, "command", "result")
+	mongoLatency      = Histogram("mongo_command_duration_seconds", "mongo命令耗时", storageBuckets, "command", "result")
+
+	// OnlineSessions connector上在线的长连接数量，由connector在连接建立、断开时增减
+	OnlineSessions = Gauge("connector_online_sessions", "connector在线会话数", "server")
+	// ActiveRooms 当前活跃的房间数量，由game在房间创建、解散时增减
+	ActiveRooms = Gauge("game_active_rooms", "game活跃房间数", "game")
+)
+
+// GinMiddleware 统计gin的请求数和耗时，path使用路由模板，避免参数导致标签爆炸
+func GinMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+		ctx.Next()
+		path := ctx.FullPath()
+		if path == "" {
+			path = "unknown"
+		}
+		httpRequests.WithLabelValues(ctx.Request.Method, path, strconv.Itoa(ctx.Writer.Status())).Inc()
+		httpDuration.WithLabelValues(ctx.Request.Method, path).Observe(time.Since(start).Seconds())
+	}
+}
+
+// UnaryServerInterceptor 统计grpc服务端每个方法的请求数、返回码和耗时
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		grpcServerHandled.WithLabelValues(info.FullMethod, status.Code(err).String()).Inc()
+		grpcServerLatency.WithLabelValues(info.FullMethod).Observe(time.Since(start).Seconds())
+		return resp, err
+	}
+}
+
+// UnaryClientInterceptor 统计grpc客户端每个方法的请求数、返回码和耗时
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		grpcClientHandled.WithLabelValues(method, status.Code(err).String()).Inc()
+		grpcClientLatency.WithLabelValues(method).Observe(time.Since(start).Seconds())
+		return err
+	}
+}
+
+// RedisHook go-redis的钩子，统计每条命令的耗时
+type RedisHook struct{}
+
+func (RedisHook) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return next(ctx, network, addr)
+	}
+}
+
+func (RedisHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmd)
+		redisLatency.WithLabelValues(cmd.Name(), result(err)).Observe(time.Since(start).Seconds())
+		return err
+	}
+}
+
+func (RedisHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmds)
+		redisLatency.WithLabelValues("pipeline", result(err)).Observe(time.Since(start).Seconds())
+		return err
+	}
+}
+
+// MongoMonitor mongo命令监听器，统计每条命令的耗时
+func MongoMonitor() *event.CommandMonitor {
+	// 成功和失败事件里只有requestId，需要记录下开始时的命令名
+	var commands sync.Map
+	finish := func(requestId int64, duration time.Duration, res string) {
+		name, ok := commands.LoadAndDelete(requestId)
+		if !ok {
+			return
+		}
+		mongoLatency.WithLabelValues(name.(string), res).Observe(duration.Seconds())
+	}
+	return &event.CommandMonitor{
+		Started: func(_ context.Context, e *event.CommandStartedEvent) {
+			commands.Store(e.RequestID, e.CommandName)
+		},
+		Succeeded: func(_ context.Context, e *event.CommandSucceededEvent) {
+			finish(e.RequestID, e.Duration, "ok")
+		},
+		Failed: func(_ context.Context, e *event.CommandFailedEvent) {
+			finish(e.RequestID, e.Duration, "error")
+		},
+	}
+}
+
+func result(err error) string {
+	if err != nil && err != redis.Nil {
+		return "error"
+	}
+	return "ok"
+}
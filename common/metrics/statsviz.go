@@ -1,19 +1,41 @@
 package metrics
 
 import (
+	"common/config"
 	"github.com/arl/statsviz"
 	"net/http"
+	"net/http/pprof"
 )
 
-// Serve 可视化实时监控  /debug/statsviz
+// Serve 可视化实时监控  /debug/statsviz，prometheus指标  /metrics，
+// 存活检查  /healthz，就绪检查  /readyz，开启pprof配置后注册  /debug/pprof
 func Serve(addr string) error {
-	mux := http.NewServeMux()
-	err := statsviz.Register(mux)
+	server, err := NewServer(addr)
 	if err != nil {
 		return err
 	}
-	if err := http.ListenAndServe(addr, mux); err != nil {
+	if err := server.ListenAndServe(); err != nil {
 		return err
 	}
 	return nil
 }
+
+// NewServer 创建监控http服务，由调用方负责启动和关闭
+func NewServer(addr string) (*http.Server, error) {
+	mux := http.NewServeMux()
+	err := statsviz.Register(mux)
+	if err != nil {
+		return nil, err
+	}
+	mux.Handle("/metrics", Handler())
+	mux.HandleFunc("/healthz", healthz)
+	mux.HandleFunc("/readyz", readyz)
+	if config.Current().Pprof {
+		mux.HandleFunc("/debug/pprof/", pprof.Index)
+		mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+		mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+		mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+		mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	}
+	return &http.Server{Addr: addr, Handler: mux}, nil
+}
@@ -2,19 +2,32 @@ package common
 
 import (
 	"common/biz"
+	"common/msError"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 )
 
+// gin上下文中保存返回码的key，中间件在处理完成后据此判断业务结果
+const CodeKey = "resultCode"
+
 type Result struct {
 	Code int `json:"code"`
 	Msg  any `json:"msg"`
 }
 
 func Success(ctx *gin.Context, data any) {
+	ctx.Set(CodeKey, biz.OK)
 	ctx.JSON(http.StatusOK, Result{
 		Code: biz.OK,
 		Msg:  data,
 	})
 }
+
+func Fail(ctx *gin.Context, err *msError.Error) {
+	ctx.Set(CodeKey, err.Code)
+	ctx.JSON(http.StatusOK, Result{
+		Code: err.Code,
+		Msg:  err.Err.Error(),
+	})
+}
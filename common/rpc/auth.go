@@ -0,0 +1,30 @@
+package rpc
+
+import (
+	"common/biz"
+	"common/config"
+	"common/logs"
+	"common/msError"
+	"context"
+	"crypto/subtle"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// AdminTokenKey 调用后台管理服务时携带令牌的metadata key
+const AdminTokenKey = "x-admin-token"
+
+// AdminAuthInterceptor 校验后台管理服务的令牌，每次请求读取当前配置，重新加载后立即生效
+func AdminAuthInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		token := config.Current().Grpc.AdminToken
+		md, _ := metadata.FromIncomingContext(ctx)
+		values := md.Get(AdminTokenKey)
+		if token == "" || len(values) == 0 || subtle.ConstantTimeCompare([]byte(values[0]), []byte(token)) != 1 {
+			logs.WarnCtx(ctx, "admin rpc %s unauthorized", info.FullMethod)
+			return nil, msError.GrpcError(biz.PermissionNotEnough)
+		}
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,50 @@
+package rpc
+
+import (
+	"common/biz"
+	"common/config"
+	"common/msError"
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestAdminAuthInterceptor(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		token  []string
+		ok     bool
+	}{
+		{"valid", "secret", []string{"secret"}, true},
+		{"wrong token", "secret", []string{"other"}, false},
+		{"missing token", "secret", nil, false},
+		{"not configured", "", []string{""}, false},
+	}
+	interceptor := AdminAuthInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/pb.AdminService/SetMaintenance"}
+	handler := func(ctx context.Context, req any) (any, error) {
+		return "ok", nil
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.Set(&config.Config{Grpc: config.GrpcConf{AdminToken: tt.config}})
+			ctx := context.Background()
+			for _, v := range tt.token {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(AdminTokenKey, v))
+			}
+			resp, err := interceptor(ctx, nil, info, handler)
+			if tt.ok {
+				if err != nil || resp != "ok" {
+					t.Fatalf("resp = %v, err = %v", resp, err)
+				}
+				return
+			}
+			if code := msError.ToError(err).Code; code != biz.PermissionNotEnough.Code {
+				t.Fatalf("code = %d, want %d", code, biz.PermissionNotEnough.Code)
+			}
+		})
+	}
+}
@@ -4,26 +4,49 @@ import (
 	"common/config"
 	"common/discovery"
 	"common/logs"
+	"common/metrics"
+	"common/tracing"
 	"context"
 	"fmt"
+	gamepb "game/pb"
+	hallpb "hall/pb"
+	"sync"
 	"user/pb"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/resolver"
 )
 
 var (
-	UserClient pb.UserServiceClient
+	UserClient  pb.UserServiceClient
+	HallClient  hallpb.HallServiceClient
+	UnionClient hallpb.UnionServiceClient
+	// GameClient 按负载均衡选择game节点，只用于创建房间，房间内的请求通过GameNode发到房间所在的节点
+	GameClient gamepb.GameServiceClient
 )
 
+// 已建立的grpc连接，key为服务名，用于依赖服务的就绪检查
+var conns = make(map[string]*grpc.ClientConn)
+
 func Init() {
 
 	// etcd解析器，就可以在grpc连接的时候，进行触发，通过提供的addr地址，去etcd中进行查找
-	r := discovery.NewResolver(config.Conf.Etcd)
+	r := discovery.NewResolver(config.Current().Etcd)
 	resolver.Register(r)
-	userDomain := config.Conf.Domain["user"]
-	initClient(userDomain.Name, userDomain.LoadBalance, &UserClient)
+	// 只连接配置了的服务，如connector只依赖hall
+	if userDomain, ok := config.Current().Domain["user"]; ok {
+		initClient(userDomain.Name, userDomain.LoadBalance, &UserClient)
+	}
+	if hallDomain, ok := config.Current().Domain["hall"]; ok {
+		initClient(hallDomain.Name, hallDomain.LoadBalance, &HallClient)
+		// 联盟服务和大厅服务在同一个进程中，共用连接
+		UnionClient = hallpb.NewUnionServiceClient(conns[hallDomain.Name])
+	}
+	if gameDomain, ok := config.Current().Domain["game"]; ok {
+		initClient(gameDomain.Name, gameDomain.LoadBalance, &GameClient)
+	}
 
 }
 
@@ -32,21 +55,82 @@ func initClient(name string, loadBalance bool, client interface{}) {
 	addr := fmt.Sprintf("etcd:///%s", name)
 	// 配置从服务列表中选择服务时的负载均衡策略：轮询
 	opts := []grpc.DialOption{
-		grpc.WithTransportCredentials(insecure.NewCredentials())}
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(tracing.UnaryClientInterceptor(), metrics.UnaryClientInterceptor()),
+	}
 	if loadBalance {
 		opts = append(opts, grpc.WithDefaultServiceConfig(fmt.Sprintf(`{"LoadBalancingPolicy": "%s"}`, "round_robin")))
 	}
-	conn, err := grpc.DialContext(context.TODO(), addr)
+	conn, err := grpc.DialContext(context.TODO(), addr, opts...)
 	if err != nil {
 		logs.Fatal("rpc connect etcd error: %v", err)
 	}
+	conns[name] = conn
 
 	// 判断传入的client是哪一个client
 	switch c := client.(type) {
 	case *pb.UserServiceClient:
 		*c = pb.NewUserServiceClient(conn)
+	case *hallpb.HallServiceClient:
+		*c = hallpb.NewHallServiceClient(conn)
+	case *gamepb.GameServiceClient:
+		*c = gamepb.NewGameServiceClient(conn)
 	default:
 		logs.Fatal("unsupported client type")
 	}
 
 }
+
+// 直连game节点的连接，key为节点地址
+var (
+	nodeLock  sync.Mutex
+	nodeConns = make(map[string]*grpc.ClientConn)
+)
+
+// GameNode 直连指定地址的game节点，连接建立后复用
+func GameNode(addr string) (gamepb.GameServiceClient, error) {
+	nodeLock.Lock()
+	defer nodeLock.Unlock()
+	if conn, ok := nodeConns[addr]; ok {
+		return gamepb.NewGameServiceClient(conn), nil
+	}
+	conn, err := grpc.DialContext(context.TODO(), addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(tracing.UnaryClientInterceptor(), metrics.UnaryClientInterceptor()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	nodeConns[addr] = conn
+	return gamepb.NewGameServiceClient(conn), nil
+}
+
+// Check 通过grpc标准健康检查服务，检查所有依赖的grpc服务是否可用
+func Check(ctx context.Context) error {
+	for name, conn := range conns {
+		res, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+		if err != nil {
+			return fmt.Errorf("%s: %v", name, err)
+		}
+		if res.Status != healthpb.HealthCheckResponse_SERVING {
+			return fmt.Errorf("%s: %s", name, res.Status.String())
+		}
+	}
+	return nil
+}
+
+// Close 关闭所有grpc连接
+func Close() {
+	for name, conn := range conns {
+		if err := conn.Close(); err != nil {
+			logs.Error("rpc close %s error: %v", name, err)
+		}
+	}
+	nodeLock.Lock()
+	defer nodeLock.Unlock()
+	for addr, conn := range nodeConns {
+		if err := conn.Close(); err != nil {
+			logs.Error("rpc close game node %s error: %v", addr, err)
+		}
+	}
+}
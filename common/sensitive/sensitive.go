@@ -0,0 +1,101 @@
+package sensitive
+
+import (
+	"strings"
+	"sync/atomic"
+	"unicode"
+)
+
+// 敏感词过滤，基于前缀树，匹配时忽略大小写以及夹在敏感词中间的空格、符号（如"傻 逼"、"傻*逼"）
+
+type node struct {
+	children map[rune]*node
+	end      bool
+}
+
+// 当前使用的敏感词树，重新加载时整体替换
+var root atomic.Pointer[node]
+
+// Load 加载敏感词，会替换之前加载的所有敏感词
+func Load(words []string) {
+	n := &node{children: make(map[rune]*node)}
+	for _, word := range words {
+		cur := n
+		count := 0
+		for _, r := range strings.ToLower(word) {
+			if skip(r) {
+				continue
+			}
+			next, ok := cur.children[r]
+			if !ok {
+				next = &node{children: make(map[rune]*node)}
+				cur.children[r] = next
+			}
+			cur = next
+			count++
+		}
+		if count > 0 {
+			cur.end = true
+		}
+	}
+	root.Store(n)
+}
+
+// Contains 是否包含敏感词
+func Contains(text string) bool {
+	runes := []rune(text)
+	for i := range runes {
+		if match(runes, i) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Replace 把敏感词替换成*
+func Replace(text string) string {
+	runes := []rune(text)
+	replaced := false
+	for i := 0; i < len(runes); i++ {
+		if n := match(runes, i); n > 0 {
+			for j := i; j < i+n; j++ {
+				runes[j] = '*'
+			}
+			i += n - 1
+			replaced = true
+		}
+	}
+	if !replaced {
+		return text
+	}
+	return string(runes)
+}
+
+// match 从start开始匹配最长的敏感词，返回匹配的长度，未匹配返回0
+func match(runes []rune, start int) int {
+	cur := root.Load()
+	if cur == nil || skip(runes[start]) {
+		return 0
+	}
+	length := 0
+	for i := start; i < len(runes); i++ {
+		r := unicode.ToLower(runes[i])
+		if skip(r) {
+			continue
+		}
+		next, ok := cur.children[r]
+		if !ok {
+			break
+		}
+		cur = next
+		if cur.end {
+			length = i - start + 1
+		}
+	}
+	return length
+}
+
+// skip 匹配时忽略的字符
+func skip(r rune) bool {
+	return unicode.IsSpace(r) || unicode.IsPunct(r) || unicode.IsSymbol(r)
+}
@@ -0,0 +1,211 @@
+package tracing
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/event"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// GinMiddleware 从http头中提取上游的trace上下文，为每个请求开启一个server span，
+// 并把trace id通过响应头 X-Trace-Id 返回给客户端，方便排查问题
+func GinMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		parent := otel.GetTextMapPropagator().Extract(ctx.Request.Context(), propagation.HeaderCarrier(ctx.Request.Header))
+		name := ctx.FullPath()
+		if name == "" {
+			name = ctx.Request.URL.Path
+		}
+		spanCtx, span := Start(parent, ctx.Request.Method+" "+name, trace.WithSpanKind(trace.SpanKindServer))
+		defer span.End()
+
+		ctx.Request = ctx.Request.WithContext(spanCtx)
+		ctx.Header("X-Trace-Id", TraceId(spanCtx))
+		ctx.Next()
+
+		code := ctx.Writer.Status()
+		span.SetAttributes(
+			attribute.String("http.method", ctx.Request.Method),
+			attribute.String("http.route", name),
+			attribute.Int("http.status_code", code),
+			attribute.String("http.client_ip", ctx.ClientIP()),
+		)
+		if code >= 500 {
+			span.SetStatus(codes.Error, "")
+		}
+	}
+}
+
+// metadataCarrier 让grpc的metadata满足TextMapCarrier接口
+type metadataCarrier metadata.MD
+
+func (m metadataCarrier) Get(key string) string {
+	v := metadata.MD(m).Get(key)
+	if len(v) == 0 {
+		return ""
+	}
+	return v[0]
+}
+
+func (m metadataCarrier) Set(key, value string) {
+	metadata.MD(m).Set(key, value)
+}
+
+func (m metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// UnaryServerInterceptor 从grpc metadata中提取trace上下文并开启server span
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md.Copy()))
+		ctx, span := Start(ctx, info.FullMethod, trace.WithSpanKind(trace.SpanKindServer))
+		defer span.End()
+
+		resp, err := handler(ctx, req)
+		end(span, err)
+		return resp, err
+	}
+}
+
+// UnaryClientInterceptor 开启client span，并把trace上下文注入到grpc metadata传给下游
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx, span := Start(ctx, method, trace.WithSpanKind(trace.SpanKindClient))
+		defer span.End()
+
+		md, ok := metadata.FromOutgoingContext(ctx)
+		if ok {
+			md = md.Copy()
+		} else {
+			md = metadata.MD{}
+		}
+		otel.GetTextMapPropagator().Inject(ctx, metadataCarrier(md))
+		ctx = metadata.NewOutgoingContext(ctx, md)
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		end(span, err)
+		return err
+	}
+}
+
+func end(span trace.Span, err error) {
+	s := status.Convert(err)
+	span.SetAttributes(attribute.String("rpc.grpc.status_code", s.Code().String()))
+	if err != nil {
+		span.SetStatus(codes.Error, s.Message())
+	}
+}
+
+// RedisHook go-redis的钩子，为每条命令开启一个client span
+type RedisHook struct{}
+
+func (RedisHook) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return next(ctx, network, addr)
+	}
+}
+
+func (RedisHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		ctx, span := Start(ctx, "redis "+cmd.Name(), trace.WithSpanKind(trace.SpanKindClient))
+		defer span.End()
+		span.SetAttributes(attribute.String("db.system", "redis"))
+		err := next(ctx, cmd)
+		if err != nil && err != redis.Nil {
+			span.SetStatus(codes.Error, err.Error())
+		}
+		return err
+	}
+}
+
+func (RedisHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		names := make([]string, 0, len(cmds))
+		for _, cmd := range cmds {
+			names = append(names, cmd.Name())
+		}
+		ctx, span := Start(ctx, "redis pipeline", trace.WithSpanKind(trace.SpanKindClient))
+		defer span.End()
+		span.SetAttributes(
+			attribute.String("db.system", "redis"),
+			attribute.String("db.operation", strings.Join(names, " ")),
+		)
+		err := next(ctx, cmds)
+		if err != nil && err != redis.Nil {
+			span.SetStatus(codes.Error, err.Error())
+		}
+		return err
+	}
+}
+
+// MongoMonitor mongo命令监听器，为每条命令开启一个client span；
+// next不为空时同时转发事件，用于和其他监听器（如metrics）组合
+func MongoMonitor(next *event.CommandMonitor) *event.CommandMonitor {
+	var spans sync.Map
+	finish := func(requestId int64, err string) {
+		v, ok := spans.LoadAndDelete(requestId)
+		if !ok {
+			return
+		}
+		span := v.(trace.Span)
+		if err != "" {
+			span.SetStatus(codes.Error, err)
+		}
+		span.End()
+	}
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, e *event.CommandStartedEvent) {
+			_, span := Start(ctx, "mongo "+e.CommandName, trace.WithSpanKind(trace.SpanKindClient))
+			span.SetAttributes(
+				attribute.String("db.system", "mongodb"),
+				attribute.String("db.name", e.DatabaseName),
+			)
+			spans.Store(e.RequestID, span)
+			if next != nil && next.Started != nil {
+				next.Started(ctx, e)
+			}
+		},
+		Succeeded: func(ctx context.Context, e *event.CommandSucceededEvent) {
+			finish(e.RequestID, "")
+			if next != nil && next.Succeeded != nil {
+				next.Succeeded(ctx, e)
+			}
+		},
+		Failed: func(ctx context.Context, e *event.CommandFailedEvent) {
+			finish(e.RequestID, e.Failure)
+			if next != nil && next.Failed != nil {
+				next.Failed(ctx, e)
+			}
+		},
+	}
+}
+
+// StartMessage connector路由客户端消息时调用，从消息头中提取trace上下文并开启一个server span，
+// headers为空时开启新的trace
+func StartMessage(ctx context.Context, route string, headers map[string]string) (context.Context, trace.Span) {
+	if len(headers) > 0 {
+		ctx = otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(headers))
+	}
+	return Start(ctx, "route "+route,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(attribute.String("connector.route", route)),
+	)
+}
@@ -0,0 +1,97 @@
+package tracing
+
+import (
+	"common/config"
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// 支持的导出方式
+const (
+	ExporterNone   = "none"   // 不导出，只在进程内传递trace id
+	ExporterStdout = "stdout" // 输出到标准输出，离线调试用
+	ExporterMemory = "memory" // 保存在内存中，测试用
+	ExporterOtlp   = "otlp"   // 通过otlp grpc协议导出到collector
+)
+
+// 本项目统一使用的tracer名称
+const tracerName = "msqp"
+
+// Memory 内存导出器，exporter配置为memory时可以通过它拿到所有已结束的span
+var Memory = tracetest.NewInMemoryExporter()
+
+// Init 初始化全局的TracerProvider和上下文传播器，返回的函数用于停止时刷新并关闭导出器
+func Init(appName string, conf config.TraceConf) (func(ctx context.Context) error, error) {
+	// 不论是否导出，都需要在服务间传播trace上下文
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch conf.Exporter {
+	case "", ExporterNone:
+	case ExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case ExporterMemory:
+		exporter = Memory
+	case ExporterOtlp:
+		exporter, err = otlptracegrpc.New(context.Background(),
+			otlptracegrpc.WithEndpoint(conf.Endpoint),
+			otlptracegrpc.WithInsecure(),
+		)
+	default:
+		return nil, fmt.Errorf("unsupported trace exporter: %s", conf.Exporter)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// 采样率，不配置或为0时不采样，未采样的请求仍然有trace id，可以用来关联日志
+	opts := []sdktrace.TracerProviderOption{
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(conf.SampleRatio))),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", appName))),
+	}
+	if exporter != nil {
+		if exporter == Memory {
+			// 内存导出器同步导出，方便测试中立刻拿到span
+			opts = append(opts, sdktrace.WithSyncer(exporter))
+		} else {
+			opts = append(opts, sdktrace.WithBatcher(exporter))
+		}
+	}
+	tp := sdktrace.NewTracerProvider(opts...)
+	otel.SetTracerProvider(tp)
+	return tp.Shutdown, nil
+}
+
+// Tracer 获取全局tracer
+func Tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// Start 开启一个span，调用方负责End
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, opts...)
+}
+
+// TraceId 获取上下文中的trace id，没有则返回空字符串
+func TraceId(ctx context.Context) string {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.HasTraceID() {
+		return ""
+	}
+	return sc.TraceID().String()
+}
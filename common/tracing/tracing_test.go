@@ -0,0 +1,36 @@
+package tracing
+
+import (
+	"common/config"
+	"context"
+	"testing"
+)
+
+func TestInit_SampleRatio(t *testing.T) {
+	tests := []struct {
+		name  string
+		ratio float64
+		want  int
+	}{
+		{"not configured", 0, 0},
+		{"all", 1, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Memory.Reset()
+			shutdown, err := Init("test", config.TraceConf{Exporter: ExporterMemory, SampleRatio: tt.ratio})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer shutdown(context.Background())
+			ctx, span := Start(context.Background(), "test")
+			span.End()
+			if TraceId(ctx) == "" {
+				t.Fatal("trace id is empty")
+			}
+			if n := len(Memory.GetSpans()); n != tt.want {
+				t.Fatalf("spans = %d, want %d", n, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package app
+
+import (
+	"common/config"
+	"common/database"
+	"common/lifecycle"
+	"common/logs"
+	"common/metrics"
+	"common/rpc"
+	"common/tracing"
+	"connector/internal/route"
+	"connector/internal/ws"
+	"context"
+	"core/session"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Run 启动程序: 日志库、redis、grpc客户端、websocket服务
+func Run(ctx context.Context) error {
+
+	// 1.初始化日志库
+	logs.InitLog(config.Current().AppName)
+
+	// 2.初始化链路追踪
+	shutdownTrace, err := tracing.Init(config.Current().AppName, config.Current().Trace)
+	if err != nil {
+		return err
+	}
+
+	// 3.初始化redis（校验token、踢下线通知、推送消息、上报在线人数、查询房间所在节点）和grpc客户端
+	redis := database.NewRedis()
+	rpc.Init()
+
+	// 4.创建websocket服务，注册客户端路由
+	node := config.Current().Services["connector"].Id
+	manager := ws.NewManager(node)
+	router := ws.NewRouter()
+	route.Register(router, redis)
+	mux := http.NewServeMux()
+	mux.Handle("/ws", ws.NewServer(redis, manager, router))
+	server := &http.Server{
+		Addr:              fmt.Sprintf("0.0.0.0:%d", config.Current().WsPort),
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	// 就绪检查：依赖的grpc服务和redis
+	metrics.RegisterChecker(
+		metrics.NewChecker("grpc", rpc.Check),
+		metrics.NewChecker("redis", redis.Ping),
+	)
+	metricServer, err := metrics.NewServer(fmt.Sprintf("0.0.0.0:%d", config.Current().MetricPort))
+	if err != nil {
+		return err
+	}
+
+	// 5.按顺序启动各组件，停止时逆序：先停止握手，再断开所有会话，最后关闭grpc、redis连接
+	lc := lifecycle.New(config.Current().AppName)
+	lc.Append(lc.HttpServer("metrics", metricServer))
+	lc.Append(lifecycle.Hook{
+		Name:   "trace",
+		OnStop: shutdownTrace,
+	})
+	lc.Append(lifecycle.Hook{
+		Name: "redis",
+		OnStop: func(ctx context.Context) error {
+			redis.Close()
+			return nil
+		},
+	})
+	lc.Append(lifecycle.Hook{
+		Name: "rpc",
+		OnStop: func(ctx context.Context) error {
+			rpc.Close()
+			return nil
+		},
+	})
+	background, cancel := context.WithCancel(context.Background())
+	lc.Append(lifecycle.Hook{
+		Name: "sessions",
+		OnStart: func(ctx context.Context) error {
+			// 冻结账号等操作通过redis广播踢下线
+			go session.SubscribeKick(background, redis, func(msg *session.KickMessage) {
+				manager.Kick(msg.Uid, msg.Reason)
+			})
+			// 后台设置、取消维护时推送给所有连接
+			go session.SubscribeMaintenance(background, redis, func(m *session.Maintenance) {
+				manager.Broadcast(ws.PushMaintenance, ws.MaintenanceNotice(m))
+			})
+			// game等服务通过redis广播推送消息，只推送给连接在本节点的用户
+			go session.SubscribePush(background, redis, func(msg *session.PushMessage) {
+				for _, uid := range msg.Uids {
+					if s := manager.Get(uid); s != nil {
+						s.Push(msg.Route, msg.Data)
+					}
+				}
+			})
+			go reportOnline(background, redis, node, manager)
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			cancel()
+			manager.CloseAll()
+			return session.RemoveOnline(ctx, redis, node)
+		},
+	})
+	lc.Append(lc.HttpServer("websocket", server))
+	lc.OnReload(func() {
+		logs.SetLevel(config.Current().Log.Level)
+	})
+	return lc.Run(ctx)
+}
+
+// reportOnline 定时上报在线人数，大厅汇总所有connector的在线人数
+func reportOnline(ctx context.Context, redis *database.RedisManager, node string, manager *ws.Manager) {
+	ticker := time.NewTicker(session.OnlineInterval)
+	defer ticker.Stop()
+	for {
+		if err := session.ReportOnline(ctx, redis, node, manager.Count()); err != nil && ctx.Err() == nil {
+			logs.Error("report online err: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package route
+
+import (
+	"common/biz"
+	"common/database"
+	"common/logs"
+	"common/rpc"
+	"connector/internal/ws"
+	"context"
+	"core/session"
+	"encoding/json"
+	"game/pb"
+)
+
+// gameRoute 房间只存在于创建它的game节点上，房间内的请求按房间号找到节点后直连转发
+type gameRoute struct {
+	redis *database.RedisManager
+}
+
+func registerGame(r *ws.Router, redis *database.RedisManager) {
+	g := &gameRoute{redis: redis}
+	r.Handle("game.createRoom", g.createRoom)
+	r.Handle("game.joinRoom", g.joinRoom)
+	r.Handle("game.matchRoom", g.matchRoom)
+	r.Handle("game.leaveRoom", g.leaveRoom)
+	r.Handle("game.ready", g.ready)
+	r.Handle("game.action", g.action)
+}
+
+// 创建房间，由负载均衡选择的节点创建
+func (g *gameRoute) createRoom(ctx context.Context, s *ws.Session, data json.RawMessage) (any, error) {
+	var req pb.CreateRoomParams
+	if err := decode(data, &req); err != nil {
+		return nil, err
+	}
+	req.Uid = s.Uid
+	req.Ip = s.Ip
+	req.Location = location(s)
+	response, err := rpc.GameClient.CreateRoom(ctx, &req)
+	if err != nil {
+		return nil, err
+	}
+	return response.Room, nil
+}
+
+// 加入房间
+func (g *gameRoute) joinRoom(ctx context.Context, s *ws.Session, data json.RawMessage) (any, error) {
+	var req pb.JoinRoomParams
+	if err := decode(data, &req); err != nil {
+		return nil, err
+	}
+	req.Uid = s.Uid
+	req.Ip = s.Ip
+	req.Location = location(s)
+	client, err := g.node(ctx, req.RoomId)
+	if err != nil {
+		return nil, err
+	}
+	response, err := client.JoinRoom(ctx, &req)
+	if err != nil {
+		return nil, err
+	}
+	return response.Room, nil
+}
+
+// 金币场匹配，在负载均衡选择的节点上匹配该节点的房间
+func (g *gameRoute) matchRoom(ctx context.Context, s *ws.Session, data json.RawMessage) (any, error) {
+	var req pb.MatchRoomParams
+	if err := decode(data, &req); err != nil {
+		return nil, err
+	}
+	req.Uid = s.Uid
+	req.Ip = s.Ip
+	req.Location = location(s)
+	response, err := rpc.GameClient.MatchRoom(ctx, &req)
+	if err != nil {
+		return nil, err
+	}
+	return response.Room, nil
+}
+
+// 离开房间
+func (g *gameRoute) leaveRoom(ctx context.Context, s *ws.Session, data json.RawMessage) (any, error) {
+	var req pb.LeaveRoomParams
+	if err := decode(data, &req); err != nil {
+		return nil, err
+	}
+	req.Uid = s.Uid
+	client, err := g.node(ctx, req.RoomId)
+	if err != nil {
+		return nil, err
+	}
+	return client.LeaveRoom(ctx, &req)
+}
+
+// 准备、取消准备
+func (g *gameRoute) ready(ctx context.Context, s *ws.Session, data json.RawMessage) (any, error) {
+	var req pb.RoomReadyParams
+	if err := decode(data, &req); err != nil {
+		return nil, err
+	}
+	req.Uid = s.Uid
+	client, err := g.node(ctx, req.RoomId)
+	if err != nil {
+		return nil, err
+	}
+	return client.RoomReady(ctx, &req)
+}
+
+// 对局中的操作，data由玩法定义，原样转发给房间所在的节点
+func (g *gameRoute) action(ctx context.Context, s *ws.Session, data json.RawMessage) (any, error) {
+	var req struct {
+		RoomId string          `json:"roomId"`
+		Type   string          `json:"type"`
+		Data   json.RawMessage `json:"data"`
+	}
+	if err := decode(data, &req); err != nil {
+		return nil, err
+	}
+	client, err := g.node(ctx, req.RoomId)
+	if err != nil {
+		return nil, err
+	}
+	return client.RoomAction(ctx, &pb.RoomActionParams{
+		Uid:    s.Uid,
+		RoomId: req.RoomId,
+		Type:   req.Type,
+		Data:   string(req.Data),
+	})
+}
+
+// location 会话中最新的定位，进入房间时由connector填充，不使用客户端在请求中传入的定位
+func location(s *ws.Session) *pb.Location {
+	l := s.Location()
+	if l == nil {
+		return nil
+	}
+	return &pb.Location{Latitude: l.Latitude, Longitude: l.Longitude}
+}
+
+// node 房间所在节点的客户端，房间不存在或节点已宕机时返回RoomNotExist
+func (g *gameRoute) node(ctx context.Context, roomId string) (pb.GameServiceClient, error) {
+	if roomId == "" {
+		return nil, biz.RequestDataError
+	}
+	addr, err := session.RoomNode(ctx, g.redis, roomId)
+	if err != nil {
+		logs.ErrorCtx(ctx, "get room %s node err: %v", roomId, err)
+		return nil, biz.Fail
+	}
+	if addr == "" {
+		return nil, biz.RoomNotExist
+	}
+	client, err := rpc.GameNode(addr)
+	if err != nil {
+		logs.ErrorCtx(ctx, "connect game node %s err: %v", addr, err)
+		return nil, biz.Fail
+	}
+	return client, nil
+}
@@ -0,0 +1,44 @@
+package route
+
+import (
+	"common/rpc"
+	"connector/internal/ws"
+	"context"
+	"encoding/json"
+	"hall/pb"
+)
+
+func registerHall(r *ws.Router) {
+	r.Handle("hall.gameList", gameList)
+	r.Handle("hall.announcements", announcements)
+	r.Handle("hall.currentRoom", currentRoom)
+	r.Handle("hall.onlineCount", onlineCount)
+}
+
+// 游戏列表
+func gameList(ctx context.Context, s *ws.Session, data json.RawMessage) (any, error) {
+	response, err := rpc.HallClient.GameList(ctx, &pb.GameListParams{})
+	if err != nil {
+		return nil, err
+	}
+	return response.List, nil
+}
+
+// 公告
+func announcements(ctx context.Context, s *ws.Session, data json.RawMessage) (any, error) {
+	response, err := rpc.HallClient.Announcements(ctx, &pb.AnnouncementsParams{})
+	if err != nil {
+		return nil, err
+	}
+	return response.List, nil
+}
+
+// 当前所在的房间，断线重连后回到房间
+func currentRoom(ctx context.Context, s *ws.Session, data json.RawMessage) (any, error) {
+	return rpc.HallClient.CurrentRoom(ctx, &pb.CurrentRoomParams{Uid: s.Uid})
+}
+
+// 在线人数
+func onlineCount(ctx context.Context, s *ws.Session, data json.RawMessage) (any, error) {
+	return rpc.HallClient.OnlineCount(ctx, &pb.OnlineCountParams{})
+}
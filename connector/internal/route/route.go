@@ -0,0 +1,27 @@
+package route
+
+import (
+	"common/biz"
+	"common/database"
+	"connector/internal/ws"
+	"encoding/json"
+)
+
+// Register 注册所有客户端路由，路由名为 服务.方法，redis用于查询房间所在的game节点
+func Register(r *ws.Router, redis *database.RedisManager) {
+	registerHall(r)
+	registerUnion(r)
+	registerGame(r, redis)
+	registerSession(r)
+}
+
+// decode 解析请求数据，格式错误时返回RequestDataError
+func decode(data json.RawMessage, v any) error {
+	if len(data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return biz.RequestDataError
+	}
+	return nil
+}
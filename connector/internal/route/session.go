@@ -0,0 +1,29 @@
+package route
+
+import (
+	"common/biz"
+	"connector/internal/ws"
+	"context"
+	"encoding/json"
+)
+
+// 会话相关的路由，在connector本地处理
+func registerSession(r *ws.Router) {
+	r.Handle("connector.location", reportLocation)
+}
+
+// 上报定位，进入开启定位检查的房间前需要上报
+func reportLocation(ctx context.Context, s *ws.Session, data json.RawMessage) (any, error) {
+	if len(data) == 0 {
+		return nil, biz.RequestDataError
+	}
+	var l ws.Location
+	if err := decode(data, &l); err != nil {
+		return nil, err
+	}
+	if l.Latitude < -90 || l.Latitude > 90 || l.Longitude < -180 || l.Longitude > 180 {
+		return nil, biz.RequestDataError
+	}
+	s.SetLocation(&l)
+	return nil, nil
+}
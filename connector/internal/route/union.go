@@ -0,0 +1,159 @@
+package route
+
+import (
+	"common/rpc"
+	"connector/internal/ws"
+	"context"
+	"encoding/json"
+	"hall/pb"
+)
+
+func registerUnion(r *ws.Router) {
+	r.Handle("union.mine", myUnions)
+	r.Handle("union.create", createUnion)
+	r.Handle("union.join", joinUnion)
+	r.Handle("union.leave", leaveUnion)
+	r.Handle("union.kick", kickUnionMember)
+	r.Handle("union.setRole", setUnionMemberRole)
+	r.Handle("union.members", unionMembers)
+	r.Handle("union.setSwitch", setUnionSwitch)
+	r.Handle("union.giveScore", giveUnionScore)
+	r.Handle("union.takeScore", takeUnionScore)
+	r.Handle("union.scoreRecords", unionScoreRecords)
+	r.Handle("union.scoreDaily", unionScoreDaily)
+}
+
+// 加入的所有联盟
+func myUnions(ctx context.Context, s *ws.Session, data json.RawMessage) (any, error) {
+	response, err := rpc.UnionClient.MyUnions(ctx, &pb.MyUnionsParams{Uid: s.Uid})
+	if err != nil {
+		return nil, err
+	}
+	return response.List, nil
+}
+
+// 创建联盟
+func createUnion(ctx context.Context, s *ws.Session, data json.RawMessage) (any, error) {
+	var req pb.CreateUnionParams
+	if err := decode(data, &req); err != nil {
+		return nil, err
+	}
+	req.Uid = s.Uid
+	response, err := rpc.UnionClient.CreateUnion(ctx, &req)
+	if err != nil {
+		return nil, err
+	}
+	return response.Union, nil
+}
+
+// 通过邀请码加入联盟
+func joinUnion(ctx context.Context, s *ws.Session, data json.RawMessage) (any, error) {
+	var req pb.JoinUnionParams
+	if err := decode(data, &req); err != nil {
+		return nil, err
+	}
+	req.Uid = s.Uid
+	response, err := rpc.UnionClient.JoinUnion(ctx, &req)
+	if err != nil {
+		return nil, err
+	}
+	return response.Union, nil
+}
+
+// 退出联盟
+func leaveUnion(ctx context.Context, s *ws.Session, data json.RawMessage) (any, error) {
+	var req pb.LeaveUnionParams
+	if err := decode(data, &req); err != nil {
+		return nil, err
+	}
+	req.Uid = s.Uid
+	return rpc.UnionClient.LeaveUnion(ctx, &req)
+}
+
+// 踢出成员
+func kickUnionMember(ctx context.Context, s *ws.Session, data json.RawMessage) (any, error) {
+	var req pb.KickUnionMemberParams
+	if err := decode(data, &req); err != nil {
+		return nil, err
+	}
+	req.Uid = s.Uid
+	return rpc.UnionClient.KickUnionMember(ctx, &req)
+}
+
+// 设置成员角色
+func setUnionMemberRole(ctx context.Context, s *ws.Session, data json.RawMessage) (any, error) {
+	var req pb.SetUnionMemberRoleParams
+	if err := decode(data, &req); err != nil {
+		return nil, err
+	}
+	req.Uid = s.Uid
+	return rpc.UnionClient.SetUnionMemberRole(ctx, &req)
+}
+
+// 成员列表
+func unionMembers(ctx context.Context, s *ws.Session, data json.RawMessage) (any, error) {
+	var req pb.UnionMembersParams
+	if err := decode(data, &req); err != nil {
+		return nil, err
+	}
+	req.Uid = s.Uid
+	return rpc.UnionClient.UnionMembers(ctx, &req)
+}
+
+// 修改联盟开关
+func setUnionSwitch(ctx context.Context, s *ws.Session, data json.RawMessage) (any, error) {
+	var req pb.SetUnionSwitchParams
+	if err := decode(data, &req); err != nil {
+		return nil, err
+	}
+	req.Uid = s.Uid
+	return rpc.UnionClient.SetUnionSwitch(ctx, &req)
+}
+
+// 赠送积分给直属成员
+func giveUnionScore(ctx context.Context, s *ws.Session, data json.RawMessage) (any, error) {
+	var req pb.GiveUnionScoreParams
+	if err := decode(data, &req); err != nil {
+		return nil, err
+	}
+	req.Uid = s.Uid
+	return rpc.UnionClient.GiveUnionScore(ctx, &req)
+}
+
+// 收回直属成员的积分
+func takeUnionScore(ctx context.Context, s *ws.Session, data json.RawMessage) (any, error) {
+	var req pb.TakeUnionScoreParams
+	if err := decode(data, &req); err != nil {
+		return nil, err
+	}
+	req.Uid = s.Uid
+	return rpc.UnionClient.TakeUnionScore(ctx, &req)
+}
+
+// 积分流水
+func unionScoreRecords(ctx context.Context, s *ws.Session, data json.RawMessage) (any, error) {
+	var req pb.UnionScoreRecordsParams
+	if err := decode(data, &req); err != nil {
+		return nil, err
+	}
+	req.Uid = s.Uid
+	response, err := rpc.UnionClient.UnionScoreRecords(ctx, &req)
+	if err != nil {
+		return nil, err
+	}
+	return response.List, nil
+}
+
+// 积分日汇总
+func unionScoreDaily(ctx context.Context, s *ws.Session, data json.RawMessage) (any, error) {
+	var req pb.UnionScoreDailyParams
+	if err := decode(data, &req); err != nil {
+		return nil, err
+	}
+	req.Uid = s.Uid
+	response, err := rpc.UnionClient.UnionScoreDaily(ctx, &req)
+	if err != nil {
+		return nil, err
+	}
+	return response.List, nil
+}
@@ -0,0 +1,97 @@
+package ws
+
+import (
+	"common/logs"
+	"common/metrics"
+	"sync"
+)
+
+// Manager 本节点上的所有会话，同一个uid只保留最新的连接
+type Manager struct {
+	node     string
+	lock     sync.RWMutex
+	sessions map[string]*Session
+}
+
+func NewManager(node string) *Manager {
+	return &Manager{
+		node:     node,
+		sessions: make(map[string]*Session),
+	}
+}
+
+// add 保存会话，uid已有连接时把旧连接踢下线
+func (m *Manager) add(s *Session) {
+	m.lock.Lock()
+	old := m.sessions[s.Uid]
+	m.sessions[s.Uid] = s
+	count := len(m.sessions)
+	m.lock.Unlock()
+	metrics.OnlineSessions.WithLabelValues(m.node).Set(float64(count))
+	if old != nil {
+		kick(old, "账号在其他设备登录")
+	}
+}
+
+// remove 连接断开后删除，已经被新连接替换的不删除
+func (m *Manager) remove(s *Session) {
+	m.lock.Lock()
+	if m.sessions[s.Uid] == s {
+		delete(m.sessions, s.Uid)
+	}
+	count := len(m.sessions)
+	m.lock.Unlock()
+	metrics.OnlineSessions.WithLabelValues(m.node).Set(float64(count))
+}
+
+// Get 获取uid在本节点上的会话，不在本节点返回nil
+func (m *Manager) Get(uid string) *Session {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.sessions[uid]
+}
+
+// Count 本节点的在线会话数
+func (m *Manager) Count() int64 {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return int64(len(m.sessions))
+}
+
+// Kick 踢下线，uid不在本节点时不做处理
+func (m *Manager) Kick(uid string, reason string) {
+	if s := m.Get(uid); s != nil {
+		logs.Info("kick session %s: %s", uid, reason)
+		kick(s, reason)
+	}
+}
+
+// Broadcast 推送给本节点的所有会话
+func (m *Manager) Broadcast(route string, msg any) {
+	for _, s := range m.all() {
+		s.Push(route, msg)
+	}
+}
+
+// CloseAll 关闭所有会话，停止服务时调用
+func (m *Manager) CloseAll() {
+	for _, s := range m.all() {
+		s.Close()
+	}
+}
+
+func (m *Manager) all() []*Session {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	list := make([]*Session, 0, len(m.sessions))
+	for _, s := range m.sessions {
+		list = append(list, s)
+	}
+	return list
+}
+
+// kick 推送踢下线通知后断开
+func kick(s *Session, reason string) {
+	s.Push(PushKick, map[string]any{"reason": reason})
+	s.Close()
+}
@@ -0,0 +1,49 @@
+package ws
+
+import (
+	"core/session"
+	"encoding/json"
+	"time"
+)
+
+// 客户端和connector之间使用websocket文本帧传输json消息
+
+// Request 客户端请求，id由客户端生成，响应中原样返回用于匹配请求
+// headers用于透传链路追踪等信息
+type Request struct {
+	Id      int64             `json:"id"`
+	Route   string            `json:"route"` // 如 hall.gameList
+	Data    json.RawMessage   `json:"data"`
+	Headers map[string]string `json:"headers"`
+}
+
+// Response 请求的响应和服务端主动推送，推送消息的id为0，code、msg和http接口的Result一致
+type Response struct {
+	Id    int64  `json:"id,omitempty"`
+	Route string `json:"route"`
+	Code  int    `json:"code"`
+	Msg   any    `json:"msg"`
+}
+
+// 服务端推送的路由
+const (
+	PushKick        = "onKick"        // 被踢下线
+	PushMaintenance = "onMaintenance" // 维护倒计时、取消维护
+)
+
+// MaintenanceNotice 推送给客户端的维护倒计时，countdown为距离开始的秒数，已经开始时为0，取消维护时m为nil
+func MaintenanceNotice(m *session.Maintenance) map[string]any {
+	if m == nil {
+		return map[string]any{"cancelled": true}
+	}
+	countdown := (m.StartTime - time.Now().UnixMilli() + 999) / 1000
+	if countdown < 0 {
+		countdown = 0
+	}
+	return map[string]any{
+		"startTime": m.StartTime,
+		"endTime":   m.EndTime,
+		"message":   m.Message,
+		"countdown": countdown,
+	}
+}
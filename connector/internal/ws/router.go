@@ -0,0 +1,54 @@
+package ws
+
+import (
+	"common/biz"
+	"common/logs"
+	"common/msError"
+	"common/tracing"
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// 单个请求的处理超时时间
+const handleTimeout = 10 * time.Second
+
+// HandlerFunc 处理客户端请求，返回的数据作为响应的msg，返回的错误转换成biz错误码
+type HandlerFunc func(ctx context.Context, s *Session, data json.RawMessage) (any, error)
+
+// Router 按route把客户端请求分发到对应的handler，同一个会话的请求按顺序处理
+type Router struct {
+	handlers map[string]HandlerFunc
+}
+
+func NewRouter() *Router {
+	return &Router{handlers: make(map[string]HandlerFunc)}
+}
+
+func (r *Router) Handle(route string, handler HandlerFunc) {
+	r.handlers[route] = handler
+}
+
+func (r *Router) dispatch(s *Session, req *Request) {
+	handler, ok := r.handlers[req.Route]
+	if !ok {
+		logs.Warn("session %s unknown route: %s", s.Uid, req.Route)
+		s.write(&Response{Id: req.Id, Route: req.Route, Code: biz.RequestDataError.Code, Msg: biz.RequestDataError.Error()})
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), handleTimeout)
+	defer cancel()
+	ctx, span := tracing.StartMessage(ctx, req.Route, req.Headers)
+	defer span.End()
+
+	msg, err := handler(ctx, s, req.Data)
+	if err != nil {
+		e, ok := err.(*msError.Error)
+		if !ok {
+			e = msError.ToError(err)
+		}
+		s.write(&Response{Id: req.Id, Route: req.Route, Code: e.Code, Msg: e.Error()})
+		return
+	}
+	s.write(&Response{Id: req.Id, Route: req.Route, Code: biz.OK, Msg: msg})
+}
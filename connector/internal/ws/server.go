@@ -0,0 +1,153 @@
+package ws
+
+import (
+	"common"
+	"common/biz"
+	"common/config"
+	"common/database"
+	"common/jwts"
+	"common/logs"
+	"core/session"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/websocket"
+)
+
+// Server websocket服务，握手时校验token，通过后建立会话
+type Server struct {
+	redis    *database.RedisManager
+	manager  *Manager
+	router   *Router
+	upgrader websocket.Upgrader
+	proxies  []*net.IPNet // 可信的反向代理
+}
+
+func NewServer(redis *database.RedisManager, manager *Manager, router *Router) *Server {
+	return &Server{
+		redis:   redis,
+		manager: manager,
+		router:  router,
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  4096,
+			WriteBufferSize: 4096,
+			// 客户端为app和小游戏，不校验Origin
+			CheckOrigin: func(r *http.Request) bool { return true },
+		},
+		proxies: parseProxies(config.Current().Http.TrustedProxies),
+	}
+}
+
+// ServeHTTP 握手：token放在查询参数token或Authorization请求头中
+// 退出登录、被冻结的账号会话已被吊销，无法握手；维护期间只有白名单中的uid、ip可以握手
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		token = strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	}
+	uid, err := jwts.Verify(r.Context(), s.redis, token)
+	if err != nil {
+		e := biz.TokenInfoError
+		if !errors.Is(err, jwts.ErrTokenInvalid) && !errors.Is(err, jwts.ErrTokenRevoked) {
+			logs.Error("handshake verify token err: %v", err)
+			e = biz.Fail
+		}
+		writeResult(w, http.StatusUnauthorized, e.Code, e.Error())
+		return
+	}
+	ip := clientIp(r, s.proxies)
+	m, err := session.GetMaintenance(r.Context(), s.redis)
+	if err != nil {
+		// 读取失败时不影响握手
+		logs.Error("handshake get maintenance err: %v", err)
+	}
+	if m.Active() && !m.Allow(uid, ip) {
+		writeResult(w, http.StatusServiceUnavailable, biz.ServerMaintenance.Code, biz.ServerMaintenance.Error())
+		return
+	}
+	conn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// Upgrade失败时已经写了错误响应
+		logs.Warn("websocket upgrade err: %v", err)
+		return
+	}
+
+	sess := newSession(uid, ip, conn)
+	s.manager.add(sess)
+	defer s.manager.remove(sess)
+	go sess.writeLoop()
+	if m != nil {
+		// 已经有维护计划时，新连接也需要收到倒计时
+		sess.Push(PushMaintenance, MaintenanceNotice(m))
+	}
+	sess.readLoop(func(req *Request) {
+		s.router.dispatch(sess, req)
+	})
+}
+
+func writeResult(w http.ResponseWriter, status int, code int, msg string) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(common.Result{Code: code, Msg: msg})
+}
+
+// clientIp 直连地址是可信代理时，从X-Forwarded-For的最右边开始跳过可信代理，第一个不可信的地址为客户端ip
+// 不是来自可信代理的请求直接使用连接地址，避免客户端伪造X-Forwarded-For绕过维护白名单
+func clientIp(r *http.Request, proxies []*net.IPNet) string {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+	if !trusted(ip, proxies) {
+		return ip
+	}
+	forwarded := strings.Split(r.Header.Get("X-Forwarded-For"), ",")
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		v := strings.TrimSpace(forwarded[i])
+		if net.ParseIP(v) == nil {
+			break
+		}
+		ip = v
+		if !trusted(v, proxies) {
+			break
+		}
+	}
+	return ip
+}
+
+func trusted(ip string, proxies []*net.IPNet) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	for _, n := range proxies {
+		if n.Contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseProxies 支持单个ip和cidr，格式错误的配置忽略
+func parseProxies(list []string) []*net.IPNet {
+	proxies := make([]*net.IPNet, 0, len(list))
+	for _, v := range list {
+		if !strings.Contains(v, "/") {
+			if ip := net.ParseIP(v); ip != nil && ip.To4() != nil {
+				v += "/32"
+			} else {
+				v += "/128"
+			}
+		}
+		_, n, err := net.ParseCIDR(v)
+		if err != nil {
+			logs.Error("invalid trusted proxy %s: %v", v, err)
+			continue
+		}
+		proxies = append(proxies, n)
+	}
+	return proxies
+}
@@ -0,0 +1,210 @@
+package ws
+
+import (
+	"common/biz"
+	"common/config"
+	"common/database"
+	"common/jwts"
+	"context"
+	"core/session"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gorilla/websocket"
+	"github.com/redis/go-redis/v9"
+)
+
+func newTestServer(t *testing.T) (*httptest.Server, *Manager, *database.RedisManager) {
+	config.Set(&config.Config{Jwt: config.JwtConf{Kid: "k1", Secret: "secret", Exp: 7, AccessExp: 30}})
+	r := &database.RedisManager{Cli: redis.NewClient(&redis.Options{Addr: miniredis.RunT(t).Addr()})}
+	manager := NewManager("test")
+	router := NewRouter()
+	router.Handle("test.echo", func(ctx context.Context, s *Session, data json.RawMessage) (any, error) {
+		return map[string]any{"uid": s.Uid, "data": data}, nil
+	})
+	server := httptest.NewServer(NewServer(r, manager, router))
+	t.Cleanup(server.Close)
+	return server, manager, r
+}
+
+func dial(t *testing.T, server *httptest.Server, token string) (*websocket.Conn, *http.Response, error) {
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws?token=" + token
+	return websocket.DefaultDialer.Dial(url, nil)
+}
+
+func read(t *testing.T, conn *websocket.Conn) *Response {
+	t.Helper()
+	_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+	res := new(Response)
+	if err := conn.ReadJSON(res); err != nil {
+		t.Fatal(err)
+	}
+	return res
+}
+
+func TestServer_Handshake(t *testing.T) {
+	server, _, r := newTestServer(t)
+	if _, res, err := dial(t, server, "invalid"); err == nil || res.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("invalid token handshake err = %v", err)
+	}
+
+	// 会话被吊销后无法握手
+	token, err := jwts.Issue(context.Background(), r, "10000", "device")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = jwts.Revoke(context.Background(), r, "10000"); err != nil {
+		t.Fatal(err)
+	}
+	if _, res, err := dial(t, server, token.AccessToken); err == nil || res.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("revoked token handshake err = %v", err)
+	}
+}
+
+func TestServer_Dispatch(t *testing.T) {
+	server, _, r := newTestServer(t)
+	token, err := jwts.Issue(context.Background(), r, "10000", "device")
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn, _, err := dial(t, server, token.AccessToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	if err = conn.WriteJSON(&Request{Id: 1, Route: "test.echo", Data: json.RawMessage(`"hello"`)}); err != nil {
+		t.Fatal(err)
+	}
+	res := read(t, conn)
+	msg, _ := res.Msg.(map[string]any)
+	if res.Id != 1 || res.Code != biz.OK || msg["uid"] != "10000" || msg["data"] != "hello" {
+		t.Fatalf("response = %+v", res)
+	}
+
+	if err = conn.WriteJSON(&Request{Id: 2, Route: "test.unknown"}); err != nil {
+		t.Fatal(err)
+	}
+	if res = read(t, conn); res.Id != 2 || res.Code != biz.RequestDataError.Code {
+		t.Fatalf("unknown route response = %+v", res)
+	}
+}
+
+func TestManager_Kick(t *testing.T) {
+	server, manager, r := newTestServer(t)
+	token, err := jwts.Issue(context.Background(), r, "10000", "device")
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, _, err := dial(t, server, token.AccessToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer first.Close()
+
+	// 同一个uid再次连接，旧连接被踢下线
+	second, _, err := dial(t, server, token.AccessToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer second.Close()
+	if res := read(t, first); res.Route != PushKick {
+		t.Fatalf("first push = %+v, want kick", res)
+	}
+
+	manager.Kick("10000", "冻结")
+	res := read(t, second)
+	msg, _ := res.Msg.(map[string]any)
+	if res.Route != PushKick || msg["reason"] != "冻结" {
+		t.Fatalf("second push = %+v, want kick", res)
+	}
+	deadline := time.Now().Add(time.Second)
+	for manager.Count() != 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if n := manager.Count(); n != 0 {
+		t.Fatalf("count = %d, want 0", n)
+	}
+}
+
+func TestServer_Maintenance(t *testing.T) {
+	server, manager, r := newTestServer(t)
+	ctx := context.Background()
+	token, err := jwts.Issue(ctx, r, "10000", "device")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 维护还没开始时可以握手，并收到倒计时
+	m := &session.Maintenance{StartTime: time.Now().Add(time.Minute).UnixMilli(), Message: "停服更新"}
+	if err = session.SetMaintenance(ctx, r, m); err != nil {
+		t.Fatal(err)
+	}
+	conn, _, err := dial(t, server, token.AccessToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	res := read(t, conn)
+	msg, _ := res.Msg.(map[string]any)
+	if res.Route != PushMaintenance || msg["message"] != "停服更新" || msg["countdown"] != float64(60) {
+		t.Fatalf("maintenance push = %+v", res)
+	}
+	manager.Broadcast(PushMaintenance, MaintenanceNotice(nil))
+	if res = read(t, conn); res.Route != PushMaintenance || res.Msg.(map[string]any)["cancelled"] != true {
+		t.Fatalf("cancel push = %+v", res)
+	}
+
+	// 维护开始后只有白名单可以握手
+	m.StartTime = time.Now().UnixMilli()
+	if err = session.SetMaintenance(ctx, r, m); err != nil {
+		t.Fatal(err)
+	}
+	if _, res, err := dial(t, server, token.AccessToken); err == nil || res.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("maintenance handshake err = %v", err)
+	}
+	m.Uids = []string{"10000"}
+	if err = session.SetMaintenance(ctx, r, m); err != nil {
+		t.Fatal(err)
+	}
+	whitelisted, _, err := dial(t, server, token.AccessToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	whitelisted.Close()
+}
+
+func TestClientIp(t *testing.T) {
+	proxies := parseProxies([]string{"10.0.0.0/8", "192.168.1.1", "bad"})
+	tests := []struct {
+		name      string
+		remote    string
+		forwarded string
+		want      string
+	}{
+		{"direct", "1.2.3.4:1000", "", "1.2.3.4"},
+		{"forged by client", "1.2.3.4:1000", "8.8.8.8", "1.2.3.4"},
+		{"from proxy", "10.0.0.2:1000", "1.2.3.4", "1.2.3.4"},
+		{"single ip proxy", "192.168.1.1:1000", "1.2.3.4", "1.2.3.4"},
+		{"forged behind proxy", "10.0.0.2:1000", "8.8.8.8, 1.2.3.4", "1.2.3.4"},
+		{"proxy chain", "10.0.0.2:1000", "1.2.3.4, 10.0.0.3", "1.2.3.4"},
+		{"proxy without header", "10.0.0.2:1000", "", "10.0.0.2"},
+		{"invalid header", "10.0.0.2:1000", "unknown", "10.0.0.2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/ws", nil)
+			r.RemoteAddr = tt.remote
+			if tt.forwarded != "" {
+				r.Header.Set("X-Forwarded-For", tt.forwarded)
+			}
+			if got := clientIp(r, proxies); got != tt.want {
+				t.Fatalf("clientIp = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package ws
+
+import (
+	"common/logs"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	writeWait      = 10 * time.Second  // 写超时
+	pongWait       = 60 * time.Second  // 超过该时间没有收到pong视为断线
+	pingPeriod     = pongWait * 9 / 10 // 发送ping的间隔
+	maxMessageSize = 64 << 10          // 客户端消息的最大字节数
+	sendBufferSize = 256               // 发送队列长度，写满说明客户端太慢，直接断开
+)
+
+// Location 客户端上报的定位
+type Location struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// Session 客户端长连接，握手成功后已经确定uid
+type Session struct {
+	Uid  string
+	Ip   string
+	conn *websocket.Conn
+	send chan []byte
+
+	lock     sync.RWMutex
+	location *Location // 客户端上报的最新定位，进入开启定位检查的房间时使用
+
+	closeOnce sync.Once
+	closeCh   chan struct{}
+}
+
+func newSession(uid, ip string, conn *websocket.Conn) *Session {
+	return &Session{
+		Uid:     uid,
+		Ip:      ip,
+		conn:    conn,
+		send:    make(chan []byte, sendBufferSize),
+		closeCh: make(chan struct{}),
+	}
+}
+
+// SetLocation 保存客户端上报的定位
+func (s *Session) SetLocation(l *Location) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.location = l
+}
+
+// Location 客户端上报的定位，没有上报时为nil
+func (s *Session) Location() *Location {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.location
+}
+
+// Push 向客户端推送消息
+func (s *Session) Push(route string, msg any) {
+	s.write(&Response{Route: route, Msg: msg})
+}
+
+func (s *Session) write(res *Response) {
+	data, err := json.Marshal(res)
+	if err != nil {
+		logs.Error("session %s marshal %s err: %v", s.Uid, res.Route, err)
+		return
+	}
+	select {
+	case <-s.closeCh:
+	case s.send <- data:
+	default:
+		logs.Warn("session %s send buffer full, close", s.Uid)
+		s.Close()
+	}
+}
+
+// Close 关闭连接，可以重复调用
+func (s *Session) Close() {
+	s.closeOnce.Do(func() {
+		close(s.closeCh)
+	})
+}
+
+// Done 连接关闭后返回
+func (s *Session) Done() <-chan struct{} {
+	return s.closeCh
+}
+
+// readLoop 读取客户端消息交给handle处理，连接断开后返回
+func (s *Session) readLoop(handle func(req *Request)) {
+	defer s.Close()
+	s.conn.SetReadLimit(maxMessageSize)
+	_ = s.conn.SetReadDeadline(time.Now().Add(pongWait))
+	s.conn.SetPongHandler(func(string) error {
+		return s.conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+	for {
+		_, data, err := s.conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure) {
+				logs.Warn("session %s read err: %v", s.Uid, err)
+			}
+			return
+		}
+		req := new(Request)
+		if err = json.Unmarshal(data, req); err != nil || req.Route == "" {
+			logs.Warn("session %s invalid message: %s", s.Uid, data)
+			continue
+		}
+		handle(req)
+	}
+}
+
+// writeLoop 发送消息和心跳，连接关闭时先发完队列中的消息（如踢下线通知）再断开
+func (s *Session) writeLoop() {
+	ticker := time.NewTicker(pingPeriod)
+	defer func() {
+		ticker.Stop()
+		_ = s.conn.Close()
+	}()
+	for {
+		select {
+		case data := <-s.send:
+			if err := s.writeMessage(websocket.TextMessage, data); err != nil {
+				s.Close()
+				return
+			}
+		case <-ticker.C:
+			if err := s.writeMessage(websocket.PingMessage, nil); err != nil {
+				s.Close()
+				return
+			}
+		case <-s.closeCh:
+			for {
+				select {
+				case data := <-s.send:
+					_ = s.writeMessage(websocket.TextMessage, data)
+				default:
+					_ = s.writeMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+					return
+				}
+			}
+		}
+	}
+}
+
+func (s *Session) writeMessage(messageType int, data []byte) error {
+	_ = s.conn.SetWriteDeadline(time.Now().Add(writeWait))
+	return s.conn.WriteMessage(messageType, data)
+}
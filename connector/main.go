@@ -0,0 +1,27 @@
+package main
+
+import (
+	"common/config"
+	"context"
+	"flag"
+	"log"
+	"os"
+	"connector/app"
+)
+
+var configFile = flag.String("config", "application.yml", "config file")
+
+func main() {
+
+	// 1.加载配置文件
+	flag.Parse()
+	config.InitConfig(*configFile)
+
+	//2.启动websocket服务和监控服务
+	err := app.Run(context.Background())
+	if err != nil {
+		log.Println(err)
+		os.Exit(1)
+	}
+
+}
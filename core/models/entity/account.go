@@ -0,0 +1,30 @@
+package entity
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Account 登录账号，一个账号对应一个用户
+type Account struct {
+	Id            primitive.ObjectID `bson:"_id,omitempty"`
+	Uid           string             `bson:"uid"`
+	Account       string             `bson:"account"`
+	Password      string             `bson:"password"` // 加盐哈希后的密码
+	Phone         string             `bson:"phone,omitempty"`
+	LoginPlatform int32              `bson:"loginPlatform"`
+	CreateTime    int64              `bson:"createTime"`
+	Block         *Block             `bson:"block,omitempty"` // 冻结信息，未冻结时为空
+}
+
+// Block 账号冻结信息
+type Block struct {
+	Reason     string `bson:"reason"`
+	Operator   string `bson:"operator"`   // 操作人
+	ExpireTime int64  `bson:"expireTime"` // 解冻时间，0为永久冻结
+	CreateTime int64  `bson:"createTime"`
+}
+
+// Blocked 账号在now时是否处于冻结中
+func (a *Account) Blocked(now int64) bool {
+	return a.Block != nil && (a.Block.ExpireTime == 0 || a.Block.ExpireTime > now)
+}
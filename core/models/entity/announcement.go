@@ -0,0 +1,21 @@
+package entity
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Announcement 大厅公告
+type Announcement struct {
+	Id         primitive.ObjectID `bson:"_id,omitempty"`
+	Title      string             `bson:"title"`
+	Content    string             `bson:"content"`
+	Sort       int                `bson:"sort"`      // 越大越靠前
+	StartTime  int64              `bson:"startTime"` // 开始展示时间
+	EndTime    int64              `bson:"endTime"`   // 结束展示时间，0为一直展示
+	CreateTime int64              `bson:"createTime"`
+}
+
+// Active 公告在now时是否在展示期内
+func (a *Announcement) Active(now int64) bool {
+	return a.StartTime <= now && (a.EndTime == 0 || a.EndTime > now)
+}
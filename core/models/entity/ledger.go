@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 流水类型，同一次转移双方各记一条，转出方数量为负数
+const (
+	LedgerUnionRecharge = 101 // 后台给盟主充值积分
+	LedgerUnionGive     = 102 // 上级赠送积分给直属成员
+	LedgerUnionTake     = 103 // 上级收回直属成员的积分
+	LedgerUnionReturn   = 104 // 成员退出、被踢出时剩余积分退回上级
+	LedgerGameGold      = 201 // 金币场对局输赢
+	LedgerGameScore     = 202 // 联盟房间对局输赢
+)
+
+// Ledger 金币、积分的变动流水，只增不改
+type Ledger struct {
+	Id         primitive.ObjectID `bson:"_id,omitempty"`
+	Uid        string             `bson:"uid"`
+	UnionId    int64              `bson:"unionId"` // 联盟积分流水，金币流水为0
+	Type       int                `bson:"type"`
+	Amount     int64              `bson:"amount"`  // 变动数量，正数增加，负数减少
+	Balance    int64              `bson:"balance"` // 变动后的余额
+	RelatedUid string             `bson:"relatedUid"`
+	Remark     string             `bson:"remark"`
+	CreateTime int64              `bson:"createTime"`
+}
+
+// LedgerDaily 按天汇总的流水
+type LedgerDaily struct {
+	Day     string `bson:"_id"`     // 日期，格式2006-01-02
+	Income  int64  `bson:"income"`  // 增加的总数
+	Expense int64  `bson:"expense"` // 减少的总数，负数
+	Count   int64  `bson:"count"`
+}
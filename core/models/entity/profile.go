@@ -0,0 +1,22 @@
+package entity
+
+// Profile 用户公开信息，大厅、游戏、connector展示用，读多写少，会被缓存
+type Profile struct {
+	Uid      string `json:"uid"`
+	Nickname string `json:"nickname"`
+	Avatar   string `json:"avatar"`
+	Sex      int    `json:"sex"`
+	Gold     int64  `json:"gold"`
+	VipLevel int    `json:"vipLevel"`
+}
+
+func (u *User) Profile() *Profile {
+	return &Profile{
+		Uid:      u.Uid,
+		Nickname: u.Nickname,
+		Avatar:   u.Avatar,
+		Sex:      u.Sex,
+		Gold:     u.Gold,
+		VipLevel: u.VipLevel,
+	}
+}
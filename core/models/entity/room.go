@@ -0,0 +1,18 @@
+package entity
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Room 房间
+type Room struct {
+	Id         primitive.ObjectID `bson:"_id,omitempty"`
+	RoomId     string             `bson:"roomId"` // 6位加入码
+	GameType   int                `bson:"gameType"`
+	UnionId    int64              `bson:"unionId"` // 联盟房间，非联盟房间为0
+	Tier       int                `bson:"tier"`    // 金币场场次，开房间为0
+	CreatorUid string             `bson:"creatorUid"`
+	Node       string             `bson:"node"` // 房间所在的game节点
+	Rule       map[string]any     `bson:"rule"`
+	CreateTime int64              `bson:"createTime"`
+}
@@ -0,0 +1,20 @@
+package entity
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Settlement 写库失败、等待重试的对局结算，结算成功后删除
+type Settlement struct {
+	Id         primitive.ObjectID `bson:"_id,omitempty"`
+	Key        string             `bson:"key"` // 房间号、房间创建时间和局数，同一局只结算一次
+	RoomId     string             `bson:"roomId"`
+	Hand       int                `bson:"hand"`
+	UnionId    int64              `bson:"unionId"` // 联盟房间变动联盟积分，金币场为0
+	Uids       []string           `bson:"uids"`
+	Amounts    []int64            `bson:"amounts"` // 乘以底分后的输赢，实际变动按结算时的余额计算
+	Retries    int                `bson:"retries"`
+	LastError  string             `bson:"lastError"`
+	NextTime   int64              `bson:"nextTime"` // 下次重试的时间
+	CreateTime int64              `bson:"createTime"`
+}
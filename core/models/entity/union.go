@@ -0,0 +1,41 @@
+package entity
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Union 联盟（牌友圈）
+type Union struct {
+	Id                primitive.ObjectID `bson:"_id,omitempty"`
+	UnionId           int64              `bson:"unionId"`
+	Name              string             `bson:"name"`
+	OwnerUid          string             `bson:"ownerUid"`
+	CreateTime        int64              `bson:"createTime"`
+	ForbidGiveScore   bool               `bson:"forbidGiveScore"`   // 禁止管理员、代理赠送积分，只有盟主可以赠送
+	ForbidInviteScore bool               `bson:"forbidInviteScore"` // 禁止代理邀请玩家，只能通过盟主、管理员的邀请码加入
+}
+
+// 联盟成员的角色，数值越小权限越高
+const (
+	UnionRoleOwner  = 1 // 盟主
+	UnionRoleAdmin  = 2 // 管理员
+	UnionRoleAgent  = 3 // 代理
+	UnionRolePlayer = 4 // 玩家
+)
+
+// UnionMember 联盟成员，通过上级的邀请码加入，上级为邀请人
+type UnionMember struct {
+	Id          primitive.ObjectID `bson:"_id,omitempty"`
+	UnionId     int64              `bson:"unionId"`
+	Uid         string             `bson:"uid"`
+	Role        int                `bson:"role"`
+	SuperiorUid string             `bson:"superiorUid"`          // 上级，盟主为空
+	InviteCode  string             `bson:"inviteCode,omitempty"` // 盟主、管理员、代理才有邀请码
+	Score       int64              `bson:"score"`                // 联盟积分，在上下级之间转移，联盟房间的对局输赢
+	JoinTime    int64              `bson:"joinTime"`
+}
+
+// CanInvite 是否可以邀请成员
+func (m *UnionMember) CanInvite() bool {
+	return m.Role < UnionRolePlayer
+}
@@ -0,0 +1,22 @@
+package entity
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// User 用户信息，大厅、游戏中展示的昵称、头像、金币等
+type User struct {
+	Id            primitive.ObjectID `bson:"_id,omitempty"`
+	Uid           string             `bson:"uid"`
+	Nickname      string             `bson:"nickname"`
+	Avatar        string             `bson:"avatar"`
+	Sex           int                `bson:"sex"` // 0未知 1男 2女
+	Signature     string             `bson:"signature"`
+	Gold          int64              `bson:"gold"`
+	VipLevel      int                `bson:"vipLevel"`
+	RoomId        string             `bson:"roomId"` // 当前所在的房间，不在房间中为空
+	LastLoginIp   string             `bson:"lastLoginIp"`
+	LastLoginTime int64              `bson:"lastLoginTime"`
+	CreateTime    int64              `bson:"createTime"`
+	UpdateTime    int64              `bson:"updateTime"`
+}
@@ -0,0 +1,71 @@
+package repo
+
+import (
+	"common/database"
+	"context"
+	"core/models/entity"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type accountRepository struct {
+	c *mongo.Collection
+}
+
+func NewAccountRepository(db *mongo.Database) AccountRepository {
+	return &accountRepository{c: db.Collection(accountCollection)}
+}
+
+func (r *accountRepository) Create(ctx context.Context, account *entity.Account) error {
+	res, err := r.c.InsertOne(ctx, account)
+	if err != nil {
+		return mongoError(err)
+	}
+	account.Id = insertedId(res)
+	database.Compensate(ctx, func(ctx context.Context) error {
+		_, err := r.c.DeleteOne(ctx, bson.M{"_id": account.Id})
+		return err
+	})
+	return nil
+}
+
+func (r *accountRepository) FindByAccount(ctx context.Context, account string) (*entity.Account, error) {
+	return findOne[entity.Account](ctx, r.c, bson.M{"account": account})
+}
+
+func (r *accountRepository) FindByUid(ctx context.Context, uid string) (*entity.Account, error) {
+	return findOne[entity.Account](ctx, r.c, bson.M{"uid": uid})
+}
+
+func (r *accountRepository) FindByPhone(ctx context.Context, phone string) (*entity.Account, error) {
+	return findOne[entity.Account](ctx, r.c, bson.M{"phone": phone})
+}
+
+func (r *accountRepository) SetBlock(ctx context.Context, uid string, block *entity.Block) error {
+	var v any // 解冻时删除block字段
+	if block != nil {
+		v = block
+	}
+	return updateFields(ctx, r.c, bson.M{"uid": uid}, bson.M{"block": v})
+}
+
+func (r *accountRepository) SetPhone(ctx context.Context, uid, phone string) error {
+	return updateFields(ctx, r.c, bson.M{"uid": uid}, bson.M{"phone": phone})
+}
+
+func (r *accountRepository) ListBlocked(ctx context.Context, now int64, offset, limit int64) ([]*entity.Account, error) {
+	filter := bson.M{
+		"block": bson.M{"$type": "object"},
+		"$or": bson.A{
+			bson.M{"block.expireTime": 0},
+			bson.M{"block.expireTime": bson.M{"$gt": now}},
+		},
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "block.createTime", Value: -1}}).
+		SetSkip(offset).
+		SetLimit(limit)
+	return findMany[entity.Account](ctx, r.c, filter, opts)
+}
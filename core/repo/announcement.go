@@ -0,0 +1,55 @@
+package repo
+
+import (
+	"context"
+	"core/models/entity"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type announcementRepository struct {
+	c *mongo.Collection
+}
+
+func NewAnnouncementRepository(db *mongo.Database) AnnouncementRepository {
+	return &announcementRepository{c: db.Collection(announcementCollection)}
+}
+
+func (r *announcementRepository) Create(ctx context.Context, announcement *entity.Announcement) error {
+	res, err := r.c.InsertOne(ctx, announcement)
+	if err != nil {
+		return mongoError(err)
+	}
+	announcement.Id = insertedId(res)
+	return nil
+}
+
+func (r *announcementRepository) Delete(ctx context.Context, id string) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return ErrNotFound
+	}
+	res, err := r.c.DeleteOne(ctx, bson.M{"_id": oid})
+	if err != nil {
+		return mongoError(err)
+	}
+	if res.DeletedCount == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *announcementRepository) ListActive(ctx context.Context, now int64) ([]*entity.Announcement, error) {
+	filter := bson.M{
+		"startTime": bson.M{"$lte": now},
+		"$or": bson.A{
+			bson.M{"endTime": 0},
+			bson.M{"endTime": bson.M{"$gt": now}},
+		},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "sort", Value: -1}, {Key: "startTime", Value: -1}})
+	return findMany[entity.Announcement](ctx, r.c, filter, opts)
+}
@@ -0,0 +1,31 @@
+package repo
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type counterRepository struct {
+	c *mongo.Collection
+}
+
+func NewCounterRepository(db *mongo.Database) CounterRepository {
+	return &counterRepository{c: db.Collection(counterCollection)}
+}
+
+func (r *counterRepository) Next(ctx context.Context, name string, start int64) (int64, error) {
+	// 序列从1开始自增，加上起始值，保证第一个值为start
+	var doc struct {
+		Seq int64 `bson:"seq"`
+	}
+	update := bson.M{"$inc": bson.M{"seq": 1}}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	err := r.c.FindOneAndUpdate(ctx, bson.M{"_id": name}, update, opts).Decode(&doc)
+	if err != nil {
+		return 0, mongoError(err)
+	}
+	return start + doc.Seq - 1, nil
+}
@@ -0,0 +1,74 @@
+package repo
+
+import (
+	"common/database"
+	"context"
+	"core/models/entity"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ledgerRepository struct {
+	c *mongo.Collection
+}
+
+func NewLedgerRepository(db *mongo.Database) LedgerRepository {
+	return &ledgerRepository{c: db.Collection(ledgerCollection)}
+}
+
+func (r *ledgerRepository) Insert(ctx context.Context, ledger *entity.Ledger) error {
+	res, err := r.c.InsertOne(ctx, ledger)
+	if err != nil {
+		return mongoError(err)
+	}
+	ledger.Id = insertedId(res)
+	database.Compensate(ctx, func(ctx context.Context) error {
+		_, err := r.c.DeleteOne(ctx, bson.M{"_id": ledger.Id})
+		return err
+	})
+	return nil
+}
+
+func (r *ledgerRepository) ListByUid(ctx context.Context, uid string, unionId int64, offset, limit int64) ([]*entity.Ledger, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "createTime", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(offset).
+		SetLimit(limit)
+	return findMany[entity.Ledger](ctx, r.c, bson.M{"uid": uid, "unionId": unionId}, opts)
+}
+
+func (r *ledgerRepository) DailySummary(ctx context.Context, uid string, unionId int64, start, end int64) ([]*entity.LedgerDaily, error) {
+	match := bson.M{"uid": uid, "unionId": unionId, "createTime": bson.M{"$gte": start, "$lt": end}}
+	day := bson.M{"$dateToString": bson.M{
+		"format":   "%Y-%m-%d",
+		"date":     bson.M{"$toDate": "$createTime"},
+		"timezone": timezone(),
+	}}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.M{
+			"_id":     day,
+			"income":  bson.M{"$sum": bson.M{"$cond": bson.A{bson.M{"$gt": bson.A{"$amount", 0}}, "$amount", 0}}},
+			"expense": bson.M{"$sum": bson.M{"$cond": bson.A{bson.M{"$lt": bson.A{"$amount", 0}}, "$amount", 0}}},
+			"count":   bson.M{"$sum": 1},
+		}}},
+		{{Key: "$sort", Value: bson.M{"_id": -1}}},
+	}
+	cursor, err := r.c.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, mongoError(err)
+	}
+	list := make([]*entity.LedgerDaily, 0)
+	if err = cursor.All(ctx, &list); err != nil {
+		return nil, mongoError(err)
+	}
+	return list, nil
+}
+
+// timezone 本地时区的偏移，如+08:00，按天汇总时和服务器的本地时间保持一致
+func timezone() string {
+	return time.Now().Format("-07:00")
+}
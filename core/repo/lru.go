@@ -0,0 +1,76 @@
+package repo
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// lru 进程内带过期时间的LRU缓存，作为redis前面的一级缓存
+type lru[V any] struct {
+	lock  sync.Mutex
+	size  int
+	ttl   time.Duration
+	ll    *list.List
+	items map[string]*list.Element
+}
+
+type lruEntry[V any] struct {
+	key      string
+	value    V
+	expireAt time.Time
+}
+
+func newLru[V any](size int, ttl time.Duration) *lru[V] {
+	return &lru[V]{
+		size:  size,
+		ttl:   ttl,
+		ll:    list.New(),
+		items: make(map[string]*list.Element),
+	}
+}
+
+func (c *lru[V]) Get(key string) (v V, ok bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	e, ok := c.items[key]
+	if !ok {
+		return v, false
+	}
+	entry := e.Value.(*lruEntry[V])
+	if time.Now().After(entry.expireAt) {
+		c.ll.Remove(e)
+		delete(c.items, key)
+		return v, false
+	}
+	c.ll.MoveToFront(e)
+	return entry.value, true
+}
+
+func (c *lru[V]) Set(key string, value V) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	expireAt := time.Now().Add(c.ttl)
+	if e, ok := c.items[key]; ok {
+		entry := e.Value.(*lruEntry[V])
+		entry.value = value
+		entry.expireAt = expireAt
+		c.ll.MoveToFront(e)
+		return
+	}
+	c.items[key] = c.ll.PushFront(&lruEntry[V]{key: key, value: value, expireAt: expireAt})
+	if c.ll.Len() > c.size {
+		oldest := c.ll.Back()
+		c.ll.Remove(oldest)
+		delete(c.items, oldest.Value.(*lruEntry[V]).key)
+	}
+}
+
+func (c *lru[V]) Remove(key string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if e, ok := c.items[key]; ok {
+		c.ll.Remove(e)
+		delete(c.items, key)
+	}
+}
@@ -0,0 +1,76 @@
+package repo
+
+import (
+	"common/logs"
+	"context"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Migration 数据迁移，版本号递增，已执行的版本记录在migration集合中，不会重复执行
+type Migration struct {
+	Version int64
+	Name    string
+	Up      func(ctx context.Context, db *mongo.Database) error
+}
+
+// 执行记录
+type migrationRecord struct {
+	Version   int64  `bson:"version"`
+	Name      string `bson:"name"`
+	AppliedAt int64  `bson:"appliedAt"`
+}
+
+// 所有的迁移，新增迁移时追加到末尾，如：
+//
+//	{
+//		Version: 1,
+//		Name:    "init user vip level",
+//		Up: func(ctx context.Context, db *mongo.Database) error {
+//			_, err := db.Collection(userCollection).UpdateMany(ctx,
+//				bson.M{"vipLevel": bson.M{"$exists": false}},
+//				bson.M{"$set": bson.M{"vipLevel": 0}})
+//			return err
+//		},
+//	},
+var migrations []Migration
+
+// Migrate 按版本号顺序执行未执行过的迁移，多个节点同时启动时由调用方保证只有一个节点执行
+func Migrate(ctx context.Context, db *mongo.Database) error {
+	c := db.Collection(migrationCollection)
+	applied, err := findMany[migrationRecord](ctx, c, bson.M{})
+	if err != nil {
+		return err
+	}
+	done := make(map[int64]bool, len(applied))
+	for _, v := range applied {
+		done[v.Version] = true
+	}
+
+	list := make([]Migration, len(migrations))
+	copy(list, migrations)
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Version < list[j].Version
+	})
+	for _, m := range list {
+		if done[m.Version] {
+			continue
+		}
+		logs.Info("run migration %d: %s", m.Version, m.Name)
+		if err := m.Up(ctx, db); err != nil {
+			return err
+		}
+		_, err := c.InsertOne(ctx, migrationRecord{
+			Version:   m.Version,
+			Name:      m.Name,
+			AppliedAt: time.Now().UnixMilli(),
+		})
+		if err != nil {
+			return mongoError(err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package repo
+
+import (
+	"common/database"
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// 各集合需要的索引，启动时由EnsureIndexes创建
+var indexes = map[string][]mongo.IndexModel{
+	userCollection: {
+		{Keys: bson.D{{Key: "uid", Value: 1}}, Options: options.Index().SetUnique(true)},
+	},
+	accountCollection: {
+		{Keys: bson.D{{Key: "account", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "uid", Value: 1}}, Options: options.Index().SetUnique(true)},
+		// 未绑定手机号的账号没有phone字段，不参与唯一约束
+		{Keys: bson.D{{Key: "phone", Value: 1}}, Options: options.Index().SetUnique(true).
+			SetPartialFilterExpression(bson.M{"phone": bson.M{"$type": "string"}})},
+		// 只有被冻结过的账号有block字段
+		{Keys: bson.D{{Key: "block.createTime", Value: -1}}, Options: options.Index().
+			SetPartialFilterExpression(bson.M{"block": bson.M{"$type": "object"}})},
+	},
+	unionCollection: {
+		{Keys: bson.D{{Key: "unionId", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "ownerUid", Value: 1}}, Options: options.Index().SetUnique(true)},
+	},
+	unionMemberCollection: {
+		{Keys: bson.D{{Key: "unionId", Value: 1}, {Key: "uid", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "uid", Value: 1}, {Key: "joinTime", Value: 1}}},
+		{Keys: bson.D{{Key: "unionId", Value: 1}, {Key: "superiorUid", Value: 1}, {Key: "joinTime", Value: 1}}},
+		// 玩家没有邀请码，不参与唯一约束
+		{Keys: bson.D{{Key: "inviteCode", Value: 1}}, Options: options.Index().SetUnique(true).
+			SetPartialFilterExpression(bson.M{"inviteCode": bson.M{"$type": "string"}})},
+	},
+	roomCollection: {
+		{Keys: bson.D{{Key: "roomId", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "unionId", Value: 1}}},
+	},
+	ledgerCollection: {
+		{Keys: bson.D{{Key: "uid", Value: 1}, {Key: "unionId", Value: 1}, {Key: "createTime", Value: -1}}},
+	},
+	settlementCollection: {
+		{Keys: bson.D{{Key: "key", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "nextTime", Value: 1}}},
+	},
+	announcementCollection: {
+		{Keys: bson.D{{Key: "sort", Value: -1}, {Key: "startTime", Value: -1}}},
+	},
+	migrationCollection: {
+		{Keys: bson.D{{Key: "version", Value: 1}}, Options: options.Index().SetUnique(true)},
+	},
+}
+
+// EnsureIndexes 创建所有集合的索引，索引已存在时不做处理
+func EnsureIndexes(ctx context.Context, db *mongo.Database) error {
+	for name, models := range indexes {
+		if _, err := db.Collection(name).Indexes().CreateMany(ctx, models); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 把mongo的错误转换成repo的错误，业务层不需要关心具体的存储
+func mongoError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return ErrNotFound
+	}
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrDuplicate
+	}
+	return err
+}
+
+// insertedId 插入成功后回填_id
+func insertedId(res *mongo.InsertOneResult) primitive.ObjectID {
+	id, _ := res.InsertedID.(primitive.ObjectID)
+	return id
+}
+
+// findOne 查询单条记录
+func findOne[T any](ctx context.Context, c *mongo.Collection, filter any) (*T, error) {
+	v := new(T)
+	if err := c.FindOne(ctx, filter).Decode(v); err != nil {
+		return nil, mongoError(err)
+	}
+	return v, nil
+}
+
+// findMany 查询多条记录
+func findMany[T any](ctx context.Context, c *mongo.Collection, filter any, opts ...*options.FindOptions) ([]*T, error) {
+	cursor, err := c.Find(ctx, filter, opts...)
+	if err != nil {
+		return nil, mongoError(err)
+	}
+	list := make([]*T, 0)
+	if err = cursor.All(ctx, &list); err != nil {
+		return nil, mongoError(err)
+	}
+	return list, nil
+}
+
+// replaceOne 按条件整体替换，没有匹配的记录时返回ErrNotFound
+func replaceOne(ctx context.Context, c *mongo.Collection, filter, doc any) error {
+	res, err := c.ReplaceOne(ctx, filter, doc)
+	if err != nil {
+		return mongoError(err)
+	}
+	if res.MatchedCount == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// updateFields 按filter只修改fields中的字段，值为nil时删除字段，不会覆盖其他字段的并发修改
+// 补偿时恢复这些字段原来的值
+func updateFields(ctx context.Context, c *mongo.Collection, filter bson.M, fields bson.M) error {
+	projection := bson.M{"_id": 0}
+	for k := range fields {
+		projection[k] = 1
+	}
+	var old bson.M
+	err := c.FindOneAndUpdate(ctx, filter, fieldsUpdate(fields), options.FindOneAndUpdate().SetProjection(projection)).Decode(&old)
+	if err != nil {
+		return mongoError(err)
+	}
+	database.Compensate(ctx, func(ctx context.Context) error {
+		restore := make(bson.M, len(fields))
+		for k := range fields {
+			restore[k] = old[k] // 原来没有的字段为nil，恢复时删除
+		}
+		_, err := c.UpdateOne(ctx, filter, fieldsUpdate(restore))
+		return err
+	})
+	return nil
+}
+
+// fieldsUpdate 把字段转换为$set和$unset
+func fieldsUpdate(fields bson.M) bson.M {
+	set, unset := bson.M{}, bson.M{}
+	for k, v := range fields {
+		if v == nil {
+			unset[k] = ""
+		} else {
+			set[k] = v
+		}
+	}
+	update := bson.M{}
+	if len(set) > 0 {
+		update["$set"] = set
+	}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+	return update
+}
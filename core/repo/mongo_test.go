@@ -0,0 +1,27 @@
+package repo
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestFieldsUpdate(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields bson.M
+		want   bson.M
+	}{
+		{"set", bson.M{"phone": "13800000000"}, bson.M{"$set": bson.M{"phone": "13800000000"}}},
+		{"unset", bson.M{"block": nil}, bson.M{"$unset": bson.M{"block": ""}}},
+		{"both", bson.M{"nickname": "n", "phone": nil}, bson.M{"$set": bson.M{"nickname": "n"}, "$unset": bson.M{"phone": ""}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fieldsUpdate(tt.fields); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("update = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,239 @@
+package repo
+
+import (
+	"common/database"
+	"common/logs"
+	"common/metrics"
+	"context"
+	"core/models/entity"
+	"encoding/json"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	profileKeyPrefix      = "profile:"           // redis中缓存的key前缀
+	profileVersionPrefix  = "profile:ver:"       // 缓存版本的key前缀，每次失效时加1
+	profileInvalidChannel = "profile:invalidate" // 缓存失效的广播频道，通知各进程清除本地缓存
+	profileRedisTtl       = 30 * time.Minute     // redis缓存过期时间，版本的过期时间相同
+	profileLocalTtl       = 10 * time.Second     // 本地缓存过期时间，广播丢失时最多脏这么久
+	profileLocalSize      = 10000                // 本地缓存的最大条数
+	profileLoadTimeout    = 5 * time.Second      // 未命中时查询redis、mongo的超时时间
+)
+
+// 版本没有变化时才回写缓存，查询mongo期间缓存被失效时不会把旧数据写回去
+var profileSetScript = database.NewScript(`
+if (redis.call("GET", KEYS[2]) or "") ~= ARGV[3] then
+	return 0
+end
+redis.call("SET", KEYS[1], ARGV[1], "PX", ARGV[2])
+return 1`)
+
+var profileCacheRequests = metrics.Counter("profile_cache_requests_total", "用户信息缓存的命中情况", "tier", "result")
+
+// ProfileCache 用户信息读穿缓存：本地LRU -> redis -> mongo
+// 同一个uid并发未命中时只会查询一次mongo；用户信息变更时删除redis缓存并广播通知各进程清除本地缓存
+type ProfileCache struct {
+	users   UserRepository
+	redis   *database.RedisManager
+	local   *lru[*entity.Profile]
+	group   singleflight.Group
+	closeCh chan struct{}
+}
+
+func NewProfileCache(users UserRepository, redis *database.RedisManager) *ProfileCache {
+	c := &ProfileCache{
+		users:   users,
+		redis:   redis,
+		local:   newLru[*entity.Profile](profileLocalSize, profileLocalTtl),
+		closeCh: make(chan struct{}),
+	}
+	go c.subscribe()
+	return c
+}
+
+// Get 获取用户信息，用户不存在时返回ErrNotFound
+// 返回的是副本，调用方修改不会影响缓存和其他调用方
+func (c *ProfileCache) Get(ctx context.Context, uid string) (*entity.Profile, error) {
+	if p, ok := c.local.Get(uid); ok {
+		profileCacheRequests.WithLabelValues("local", "hit").Inc()
+		return copyProfile(p), nil
+	}
+	profileCacheRequests.WithLabelValues("local", "miss").Inc()
+
+	// 并发未命中的请求共享同一次查询，查询使用独立的ctx，一个调用方取消不会让其他调用方失败
+	ch := c.group.DoChan(uid, func() (any, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), profileLoadTimeout)
+		defer cancel()
+		return c.load(ctx, uid)
+	})
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		v := res.Val.(*profileLoad)
+		if v.fresh {
+			c.local.Set(uid, v.profile)
+		}
+		return copyProfile(v.profile), nil
+	}
+}
+
+// profileLoad 未命中时的查询结果，查询期间缓存被失效时fresh为false，不写入本地缓存
+type profileLoad struct {
+	profile *entity.Profile
+	fresh   bool
+}
+
+func copyProfile(p *entity.Profile) *entity.Profile {
+	cp := *p
+	return &cp
+}
+
+// GetMany 批量获取用户信息，不存在的用户会被忽略
+func (c *ProfileCache) GetMany(ctx context.Context, uids []string) ([]*entity.Profile, error) {
+	list := make([]*entity.Profile, 0, len(uids))
+	for _, uid := range uids {
+		p, err := c.Get(ctx, uid)
+		if err == ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, p)
+	}
+	return list, nil
+}
+
+// load 先查redis，未命中再查mongo，缓存版本没有变化时回写redis
+func (c *ProfileCache) load(ctx context.Context, uid string) (*profileLoad, error) {
+	key, versionKey := profileKeyPrefix+uid, profileVersionPrefix+uid
+	// 在查询mongo之前读取版本
+	version, err := c.redis.Get(ctx, versionKey)
+	fresh := err == nil || database.IsNil(err)
+	if !fresh {
+		logs.Error("profile cache get uid %s version err: %v", uid, err)
+	}
+	data, err := c.redis.Get(ctx, key)
+	if err == nil {
+		p := new(entity.Profile)
+		if err = json.Unmarshal([]byte(data), p); err == nil {
+			profileCacheRequests.WithLabelValues("redis", "hit").Inc()
+			return &profileLoad{profile: p, fresh: true}, nil
+		}
+		logs.Error("profile cache unmarshal uid %s err: %v", uid, err)
+	} else if !database.IsNil(err) {
+		// redis不可用时降级直接查mongo
+		logs.Error("profile cache get uid %s err: %v", uid, err)
+	}
+	profileCacheRequests.WithLabelValues("redis", "miss").Inc()
+
+	user, err := c.users.FindByUid(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	p := user.Profile()
+	if !fresh {
+		return &profileLoad{profile: p}, nil
+	}
+	b, err := json.Marshal(p)
+	if err != nil {
+		return &profileLoad{profile: p}, nil
+	}
+	ok, err := c.redis.Eval(ctx, profileSetScript, []string{key, versionKey}, string(b), profileRedisTtl.Milliseconds(), version)
+	if err != nil {
+		logs.Error("profile cache set uid %s err: %v", uid, err)
+		return &profileLoad{profile: p}, nil
+	}
+	return &profileLoad{profile: p, fresh: ok == int64(1)}, nil
+}
+
+// Invalidate 用户信息变更后调用，删除redis缓存、增加缓存版本并通知所有进程清除本地缓存
+// 版本变化后，失效之前开始的查询不会再把旧数据写回缓存
+func (c *ProfileCache) Invalidate(ctx context.Context, uid string) error {
+	c.local.Remove(uid)
+	versionKey := profileVersionPrefix + uid
+	_, err := c.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Incr(ctx, versionKey)
+		pipe.PExpire(ctx, versionKey, profileRedisTtl)
+		pipe.Del(ctx, profileKeyPrefix+uid)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return c.redis.Cli.Publish(ctx, profileInvalidChannel, uid).Err()
+}
+
+// subscribe 监听其他进程的缓存失效广播
+func (c *ProfileCache) subscribe() {
+	sub := c.redis.Cli.Subscribe(context.Background(), profileInvalidChannel)
+	defer sub.Close()
+	ch := sub.Channel()
+	for {
+		select {
+		case <-c.closeCh:
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+			c.local.Remove(msg.Payload)
+		}
+	}
+}
+
+func (c *ProfileCache) Close() {
+	close(c.closeCh)
+}
+
+// cachedUserRepository 写操作成功后使缓存失效的UserRepository
+// 在事务中时等事务提交或回滚之后再删除缓存，补偿写回滚时也删除缓存
+type cachedUserRepository struct {
+	UserRepository
+	cache *ProfileCache
+}
+
+func newCachedUserRepository(users UserRepository, cache *ProfileCache) UserRepository {
+	return &cachedUserRepository{UserRepository: users, cache: cache}
+}
+
+func (r *cachedUserRepository) UpdateProfile(ctx context.Context, user *entity.User) error {
+	r.compensate(ctx, user.Uid)
+	if err := r.UserRepository.UpdateProfile(ctx, user); err != nil {
+		return err
+	}
+	r.invalidate(ctx, user.Uid)
+	return nil
+}
+
+func (r *cachedUserRepository) IncrGold(ctx context.Context, uid string, delta int64) (int64, error) {
+	r.compensate(ctx, uid)
+	gold, err := r.UserRepository.IncrGold(ctx, uid, delta)
+	if err != nil {
+		return 0, err
+	}
+	r.invalidate(ctx, uid)
+	return gold, nil
+}
+
+func (r *cachedUserRepository) invalidate(ctx context.Context, uid string) {
+	database.AfterTransaction(ctx, func(ctx context.Context) {
+		if err := r.cache.Invalidate(ctx, uid); err != nil {
+			logs.Error("profile cache invalidate uid %s err: %v", uid, err)
+		}
+	})
+}
+
+// compensate 补偿操作逆序执行，在写操作之前登记，回滚时先撤销写操作再删除缓存
+func (r *cachedUserRepository) compensate(ctx context.Context, uid string) {
+	database.Compensate(ctx, func(ctx context.Context) error {
+		return r.cache.Invalidate(ctx, uid)
+	})
+}
@@ -0,0 +1,308 @@
+package repo
+
+import (
+	"common/database"
+	"context"
+	"core/models/entity"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// testUserRepository 只实现缓存测试用到的方法，内存实现在repotest中，这里不能引用
+type testUserRepository struct {
+	UserRepository
+	lock  sync.Mutex
+	users map[string]entity.User
+}
+
+func (r *testUserRepository) Create(ctx context.Context, user *entity.User) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.users[user.Uid] = *user
+	return nil
+}
+
+func (r *testUserRepository) FindByUid(ctx context.Context, uid string) (*entity.User, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	user, ok := r.users[uid]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &user, nil
+}
+
+func (r *testUserRepository) IncrGold(ctx context.Context, uid string, delta int64) (int64, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	user := r.users[uid]
+	user.Gold += delta
+	r.users[uid] = user
+	database.Compensate(ctx, func(ctx context.Context) error {
+		_, err := r.IncrGold(ctx, uid, -delta)
+		return err
+	})
+	return user.Gold, nil
+}
+
+func newTestProfileCache(t *testing.T) (*ProfileCache, UserRepository, *miniredis.Miniredis) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	r := &database.RedisManager{Cli: redis.NewClient(&redis.Options{Addr: server.Addr()})}
+	users := &testUserRepository{users: make(map[string]entity.User)}
+	cache := NewProfileCache(users, r)
+	t.Cleanup(cache.Close)
+	if err := users.Create(context.Background(), &entity.User{Uid: "u1", Nickname: "n1"}); err != nil {
+		t.Fatal(err)
+	}
+	return cache, newCachedUserRepository(users, cache), server
+}
+
+// 事务中的写操作在事务结束之后才删除缓存，回滚后缓存中不会留下被回滚的数据
+func TestCachedUserRepository_Transaction(t *testing.T) {
+	ctx := context.Background()
+	cache, users, server := newTestProfileCache(t)
+	if _, err := cache.Get(ctx, "u1"); err != nil {
+		t.Fatal(err)
+	}
+	err := database.Compensating{}.WithTransaction(ctx, func(ctx context.Context) error {
+		if _, err := users.IncrGold(ctx, "u1", 100); err != nil {
+			return err
+		}
+		if !server.Exists(profileKeyPrefix + "u1") {
+			t.Fatal("invalidated before transaction end")
+		}
+		// 没有隔离时其他请求读到未提交的数据写入缓存
+		cache.local.Remove("u1")
+		server.Del(profileKeyPrefix + "u1")
+		if p, _ := cache.Get(ctx, "u1"); p.Gold != 100 {
+			t.Fatalf("uncommitted gold = %d", p.Gold)
+		}
+		return errors.New("failed")
+	})
+	if err == nil {
+		t.Fatal("transaction should fail")
+	}
+	p, err := cache.Get(ctx, "u1")
+	if err != nil || p.Gold != 0 {
+		t.Fatalf("gold after rollback = %v, %v", p, err)
+	}
+
+	err = database.Compensating{}.WithTransaction(ctx, func(ctx context.Context) error {
+		_, err := users.IncrGold(ctx, "u1", 50)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p, _ = cache.Get(ctx, "u1"); p.Gold != 50 {
+		t.Fatalf("gold after commit = %d", p.Gold)
+	}
+}
+
+func TestProfileCache_GetReturnsCopy(t *testing.T) {
+	ctx := context.Background()
+	cache, _, _ := newTestProfileCache(t)
+	p, err := cache.Get(ctx, "u1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Nickname = "changed"
+	list, err := cache.GetMany(ctx, []string{"u1", "none"})
+	if err != nil || len(list) != 1 || list[0].Nickname != "n1" {
+		t.Fatalf("get many = %v, %v", list, err)
+	}
+	list[0].Gold = 100
+	if p, _ = cache.Get(ctx, "u1"); p.Nickname != "n1" || p.Gold != 0 {
+		t.Fatalf("cached profile modified: %+v", p)
+	}
+}
+
+func TestLru(t *testing.T) {
+	c := newLru[int](2, time.Minute)
+	c.Set("a", 1)
+	c.Set("b", 2)
+	// 访问a之后b是最久未使用的，超过容量时被淘汰
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Fatalf("get a = %d, %v", v, ok)
+	}
+	c.Set("c", 3)
+	if _, ok := c.Get("b"); ok {
+		t.Fatal("b should be evicted")
+	}
+	for key, want := range map[string]int{"a": 1, "c": 3} {
+		if v, ok := c.Get(key); !ok || v != want {
+			t.Fatalf("get %s = %d, %v", key, v, ok)
+		}
+	}
+	c.Remove("a")
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("a should be removed")
+	}
+
+	expired := newLru[int](2, -time.Second)
+	expired.Set("a", 1)
+	if _, ok := expired.Get("a"); ok {
+		t.Fatal("a should be expired")
+	}
+}
+
+// countUserRepository 统计FindByUid的次数，release关闭之前查询一直阻塞
+type countUserRepository struct {
+	UserRepository
+	count   atomic.Int32
+	release chan struct{}
+}
+
+func (r *countUserRepository) FindByUid(ctx context.Context, uid string) (*entity.User, error) {
+	r.count.Add(1)
+	<-r.release
+	return r.UserRepository.FindByUid(ctx, uid)
+}
+
+// 同一个uid并发未命中时只查询一次mongo
+func TestProfileCache_Singleflight(t *testing.T) {
+	ctx := context.Background()
+	cache, _, _ := newTestProfileCache(t)
+	users := &countUserRepository{UserRepository: cache.users, release: make(chan struct{})}
+	cache.users = users
+
+	var wg sync.WaitGroup
+	results := make([]*entity.Profile, 10)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = cache.Get(ctx, "u1")
+		}(i)
+	}
+	// 等待所有请求进入singleflight后再返回查询结果
+	time.Sleep(50 * time.Millisecond)
+	close(users.release)
+	wg.Wait()
+	if n := users.count.Load(); n != 1 {
+		t.Fatalf("find count = %d, want 1", n)
+	}
+	for i, p := range results {
+		if p == nil || p.Nickname != "n1" {
+			t.Fatalf("result %d = %v", i, p)
+		}
+		if i > 0 && p == results[0] {
+			t.Fatal("results share the same profile")
+		}
+	}
+}
+
+// 一个进程中的修改通过广播清除其他进程的本地缓存
+func TestProfileCache_Invalidate(t *testing.T) {
+	ctx := context.Background()
+	cache, users, server := newTestProfileCache(t)
+	other := NewProfileCache(cache.users, cache.redis)
+	t.Cleanup(other.Close)
+	for _, c := range []*ProfileCache{cache, other} {
+		if _, err := c.Get(ctx, "u1"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := users.IncrGold(ctx, "u1", 100); err != nil {
+		t.Fatal(err)
+	}
+	if server.Exists(profileKeyPrefix + "u1") {
+		t.Fatal("redis cache not deleted")
+	}
+	if _, ok := cache.local.Get("u1"); ok {
+		t.Fatal("local cache not removed")
+	}
+	deadline := time.Now().Add(time.Second)
+	for {
+		if _, ok := other.local.Get("u1"); !ok {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("other process local cache not removed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if p, err := other.Get(ctx, "u1"); err != nil || p.Gold != 100 {
+		t.Fatalf("other get = %v, %v", p, err)
+	}
+}
+
+// hookUserRepository 查询到用户之后调用after，模拟查询和回写缓存之间发生的修改
+type hookUserRepository struct {
+	UserRepository
+	after func()
+}
+
+func (r *hookUserRepository) FindByUid(ctx context.Context, uid string) (*entity.User, error) {
+	user, err := r.UserRepository.FindByUid(ctx, uid)
+	r.after()
+	return user, err
+}
+
+// 查询mongo期间缓存被失效，旧数据不会写回redis和本地缓存
+func TestProfileCache_InvalidateDuringLoad(t *testing.T) {
+	ctx := context.Background()
+	cache, users, server := newTestProfileCache(t)
+	raw := cache.users
+	cache.users = &hookUserRepository{UserRepository: raw, after: func() {
+		if _, err := users.IncrGold(ctx, "u1", 100); err != nil {
+			t.Error(err)
+		}
+	}}
+	p, err := cache.Get(ctx, "u1")
+	if err != nil || p.Gold != 0 {
+		t.Fatalf("get = %v, %v", p, err)
+	}
+	if server.Exists(profileKeyPrefix + "u1") {
+		t.Fatal("stale profile written to redis")
+	}
+	if _, ok := cache.local.Get("u1"); ok {
+		t.Fatal("stale profile written to local cache")
+	}
+	cache.users = raw
+	if p, _ = cache.Get(ctx, "u1"); p.Gold != 100 {
+		t.Fatalf("gold = %d, want 100", p.Gold)
+	}
+	if !server.Exists(profileKeyPrefix + "u1") {
+		t.Fatal("profile not cached")
+	}
+}
+
+// 一个调用方取消不影响共享同一次查询的其他调用方
+func TestProfileCache_CancelledCaller(t *testing.T) {
+	ctx := context.Background()
+	cache, _, _ := newTestProfileCache(t)
+	users := &countUserRepository{UserRepository: cache.users, release: make(chan struct{})}
+	cache.users = users
+
+	cancelCtx, cancel := context.WithCancel(ctx)
+	cancelled := make(chan error, 1)
+	go func() {
+		_, err := cache.Get(cancelCtx, "u1")
+		cancelled <- err
+	}()
+	result := make(chan *entity.Profile, 1)
+	go func() {
+		p, _ := cache.Get(ctx, "u1")
+		result <- p
+	}()
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	if err := <-cancelled; err != context.Canceled {
+		t.Fatalf("cancelled caller err = %v", err)
+	}
+	close(users.release)
+	if p := <-result; p == nil || p.Nickname != "n1" {
+		t.Fatalf("other caller got %v", p)
+	}
+	if n := users.count.Load(); n != 1 {
+		t.Fatalf("find count = %d, want 1", n)
+	}
+}
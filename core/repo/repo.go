@@ -1,14 +1,38 @@
 package repo
 
-import "common/database"
+import (
+	"common/database"
+	"common/logs"
+	"context"
+	"time"
+)
 
-// DB连接管理器
+// 数据库初始化（创建索引、执行迁移）的超时时间
+const initTimeout = time.Minute
+
+// DB连接管理器，业务层通过其中的Repository接口访问数据，不直接依赖mongo
 type Manager struct {
 	Mongo *database.MongoManager
 	Redis *database.RedisManager
+
+	Users         UserRepository
+	Accounts      AccountRepository
+	Unions        UnionRepository
+	UnionMembers  UnionMemberRepository
+	Rooms         RoomRepository
+	Ledgers       LedgerRepository
+	Counters      CounterRepository
+	Announcements AnnouncementRepository
+	Settlements   SettlementRepository
+	Profiles      *ProfileCache
+	// Tx 多文档事务，fn中使用传入的ctx调用Repository即可加入事务
+	Tx database.Transactor
 }
 
 func (m *Manager) Close() {
+	if m.Profiles != nil {
+		m.Profiles.Close()
+	}
 	if m.Mongo != nil {
 		m.Mongo.Close()
 	}
@@ -17,9 +41,44 @@ func (m *Manager) Close() {
 	}
 }
 
-func New()*Manager  {
-	return &Manager{
+func New() *Manager {
+	m := &Manager{
 		Mongo: database.NewMongo(),
 		Redis: database.NewRedis(),
 	}
+	db := m.Mongo.Db
+	m.Tx = m.Mongo
+	m.WithUsers(NewUserRepository(db))
+	m.Accounts = NewAccountRepository(db)
+	m.Unions = NewUnionRepository(db)
+	m.UnionMembers = NewUnionMemberRepository(db)
+	m.Rooms = NewRoomRepository(db)
+	m.Ledgers = NewLedgerRepository(db)
+	m.Counters = NewCounterRepository(db)
+	m.Announcements = NewAnnouncementRepository(db)
+	m.Settlements = NewSettlementRepository(db)
+
+	if err := m.init(); err != nil {
+		logs.Fatal("repo init err: %v", err)
+	}
+	return m
+}
+
+// WithUsers 设置用户信息的Repository，带上读穿缓存，写操作后自动使缓存失效
+// 需要先设置Redis，测试中使用其他实现时通过它设置
+func (m *Manager) WithUsers(users UserRepository) {
+	m.Profiles = NewProfileCache(users, m.Redis)
+	m.Users = newCachedUserRepository(users, m.Profiles)
+}
+
+// init 启动时创建索引，执行数据迁移，多个节点同时启动时通过分布式锁保证只有一个节点在执行
+func (m *Manager) init() error {
+	ctx, cancel := context.WithTimeout(context.Background(), initTimeout)
+	defer cancel()
+	return m.Redis.WithLock(ctx, "lock:repo:init", 30*time.Second, initTimeout, func(ctx context.Context) error {
+		if err := EnsureIndexes(ctx, m.Mongo.Db); err != nil {
+			return err
+		}
+		return Migrate(ctx, m.Mongo.Db)
+	})
 }
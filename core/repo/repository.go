@@ -0,0 +1,132 @@
+package repo
+
+import (
+	"context"
+	"core/models/entity"
+	"errors"
+)
+
+var (
+	ErrNotFound  = errors.New("record not found")
+	ErrDuplicate = errors.New("record already exists")
+	ErrNotEnough = errors.New("balance not enough")
+	ErrConflict  = errors.New("record has been changed")
+)
+
+// 集合名称
+const (
+	userCollection         = "user"
+	accountCollection      = "account"
+	unionCollection        = "union"
+	unionMemberCollection  = "unionMember"
+	roomCollection         = "room"
+	ledgerCollection       = "ledger"
+	counterCollection      = "counter"
+	migrationCollection    = "migration"
+	announcementCollection = "announcement"
+	settlementCollection   = "settlement"
+)
+
+// UserRepository 用户信息
+type UserRepository interface {
+	Create(ctx context.Context, user *entity.User) error
+	FindByUid(ctx context.Context, uid string) (*entity.User, error)
+	FindByUids(ctx context.Context, uids []string) ([]*entity.User, error)
+	// UpdateProfile 只修改昵称、头像、性别、签名和更新时间，不会覆盖同时进行的金币、房间修改
+	UpdateProfile(ctx context.Context, user *entity.User) error
+	// SetLastLoginTime 只修改最后登录时间
+	SetLastLoginTime(ctx context.Context, uid string, loginTime int64) error
+	// IncrGold 增减金币，扣减后小于0时返回ErrNotEnough，返回变动后的金币
+	IncrGold(ctx context.Context, uid string, delta int64) (int64, error)
+	// SetRoomId 当前所在房间为from时修改为to，否则返回ErrConflict，保证同时只能在一个房间中
+	SetRoomId(ctx context.Context, uid string, from, to string) error
+}
+
+// AccountRepository 登录账号
+type AccountRepository interface {
+	// Create 账号或手机号已存在时返回ErrDuplicate
+	Create(ctx context.Context, account *entity.Account) error
+	FindByAccount(ctx context.Context, account string) (*entity.Account, error)
+	FindByUid(ctx context.Context, uid string) (*entity.Account, error)
+	FindByPhone(ctx context.Context, phone string) (*entity.Account, error)
+	// SetBlock 只修改冻结信息，block为nil时解冻
+	SetBlock(ctx context.Context, uid string, block *entity.Block) error
+	// SetPhone 只修改手机号，手机号已被绑定时返回ErrDuplicate
+	SetPhone(ctx context.Context, uid, phone string) error
+	// ListBlocked 在now时处于冻结中的账号，按冻结时间倒序
+	ListBlocked(ctx context.Context, now int64, offset, limit int64) ([]*entity.Account, error)
+}
+
+// UnionRepository 联盟
+type UnionRepository interface {
+	// Create 同一个盟主只能创建一个联盟，重复创建返回ErrDuplicate
+	Create(ctx context.Context, union *entity.Union) error
+	FindByUnionId(ctx context.Context, unionId int64) (*entity.Union, error)
+	FindByOwner(ctx context.Context, ownerUid string) (*entity.Union, error)
+	Update(ctx context.Context, union *entity.Union) error
+}
+
+// UnionMemberRepository 联盟成员
+type UnionMemberRepository interface {
+	// Create 已经是联盟成员或邀请码重复时返回ErrDuplicate
+	Create(ctx context.Context, member *entity.UnionMember) error
+	Find(ctx context.Context, unionId int64, uid string) (*entity.UnionMember, error)
+	FindByInviteCode(ctx context.Context, inviteCode string) (*entity.UnionMember, error)
+	// ListByUid 用户加入的所有联盟，按加入时间排序
+	ListByUid(ctx context.Context, uid string) ([]*entity.UnionMember, error)
+	// List 按加入时间分页查询联盟成员，superiorUid不为空时只查询其直属成员
+	List(ctx context.Context, unionId int64, superiorUid string, offset, limit int64) ([]*entity.UnionMember, error)
+	Count(ctx context.Context, unionId int64, superiorUid string) (int64, error)
+	// UpdateRole 修改角色和邀请码，邀请码为空时删除，邀请码重复时返回ErrDuplicate
+	// 不整体替换，避免覆盖并发修改的积分
+	UpdateRole(ctx context.Context, unionId int64, uid string, role int, inviteCode string) error
+	Delete(ctx context.Context, unionId int64, uid string) error
+	// TransferSubordinates 把from的直属成员转给to，成员退出、降级为玩家时使用
+	TransferSubordinates(ctx context.Context, unionId int64, from, to string) error
+	// IncrScore 增减联盟积分，扣减后小于0时返回ErrNotEnough，返回变动后的积分
+	IncrScore(ctx context.Context, unionId int64, uid string, delta int64) (int64, error)
+}
+
+// RoomRepository 房间
+type RoomRepository interface {
+	// Create 房间号已存在时返回ErrDuplicate
+	Create(ctx context.Context, room *entity.Room) error
+	FindByRoomId(ctx context.Context, roomId string) (*entity.Room, error)
+	Delete(ctx context.Context, roomId string) error
+	CountByUnion(ctx context.Context, unionId int64) (int64, error)
+}
+
+// LedgerRepository 金币、积分流水
+type LedgerRepository interface {
+	Insert(ctx context.Context, ledger *entity.Ledger) error
+	// ListByUid 按时间倒序分页查询，unionId为0时查询金币流水
+	ListByUid(ctx context.Context, uid string, unionId int64, offset, limit int64) ([]*entity.Ledger, error)
+	// DailySummary 按本地时间的天汇总[start, end)内的流水，按日期倒序
+	DailySummary(ctx context.Context, uid string, unionId int64, start, end int64) ([]*entity.LedgerDaily, error)
+}
+
+// CounterRepository 自增序列，用于生成uid、联盟id等短id
+type CounterRepository interface {
+	// Next 获取下一个序列值，序列不存在时从start开始
+	Next(ctx context.Context, name string, start int64) (int64, error)
+}
+
+// AnnouncementRepository 大厅公告
+type AnnouncementRepository interface {
+	Create(ctx context.Context, announcement *entity.Announcement) error
+	// Delete id为公告_id的十六进制字符串，不存在时返回ErrNotFound
+	Delete(ctx context.Context, id string) error
+	// ListActive 在now时展示中的公告，按sort、开始时间倒序
+	ListActive(ctx context.Context, now int64) ([]*entity.Announcement, error)
+}
+
+// SettlementRepository 等待重试的对局结算
+type SettlementRepository interface {
+	// Create 同一局已经存在时返回ErrDuplicate
+	Create(ctx context.Context, settlement *entity.Settlement) error
+	// ListDue 在now时需要重试的结算，按重试时间排序
+	ListDue(ctx context.Context, now int64, limit int64) ([]*entity.Settlement, error)
+	// Retry 重试失败，重试次数加1并设置下次重试的时间
+	Retry(ctx context.Context, key string, nextTime int64, lastError string) error
+	Delete(ctx context.Context, key string) error
+}
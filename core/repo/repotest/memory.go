@@ -0,0 +1,803 @@
+package repotest
+
+import (
+	"common/database"
+	"context"
+	"core/models/entity"
+	"core/repo"
+	"sort"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 内存版的Repository实现，行为（唯一约束、错误）与mongo版保持一致，用于单元测试
+// 存取时都会拷贝一份，避免调用方修改返回值影响存储的数据
+
+type memUserRepository struct {
+	lock  sync.RWMutex
+	users map[string]entity.User
+}
+
+func NewUserRepository() repo.UserRepository {
+	return &memUserRepository{users: make(map[string]entity.User)}
+}
+
+func (r *memUserRepository) Create(ctx context.Context, user *entity.User) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if _, ok := r.users[user.Uid]; ok {
+		return repo.ErrDuplicate
+	}
+	user.Id = primitive.NewObjectID()
+	r.users[user.Uid] = *user
+	uid := user.Uid
+	database.Compensate(ctx, func(ctx context.Context) error {
+		r.lock.Lock()
+		defer r.lock.Unlock()
+		delete(r.users, uid)
+		return nil
+	})
+	return nil
+}
+
+func (r *memUserRepository) FindByUid(ctx context.Context, uid string) (*entity.User, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	user, ok := r.users[uid]
+	if !ok {
+		return nil, repo.ErrNotFound
+	}
+	return &user, nil
+}
+
+func (r *memUserRepository) FindByUids(ctx context.Context, uids []string) ([]*entity.User, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	list := make([]*entity.User, 0, len(uids))
+	for _, uid := range uids {
+		if user, ok := r.users[uid]; ok {
+			list = append(list, &user)
+		}
+	}
+	return list, nil
+}
+
+func (r *memUserRepository) UpdateProfile(ctx context.Context, user *entity.User) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	old, ok := r.users[user.Uid]
+	if !ok {
+		return repo.ErrNotFound
+	}
+	u := old
+	u.Nickname, u.Avatar, u.Sex, u.Signature, u.UpdateTime = user.Nickname, user.Avatar, user.Sex, user.Signature, user.UpdateTime
+	r.users[user.Uid] = u
+	database.Compensate(ctx, func(ctx context.Context) error {
+		r.lock.Lock()
+		defer r.lock.Unlock()
+		u := r.users[old.Uid]
+		u.Nickname, u.Avatar, u.Sex, u.Signature, u.UpdateTime = old.Nickname, old.Avatar, old.Sex, old.Signature, old.UpdateTime
+		r.users[old.Uid] = u
+		return nil
+	})
+	return nil
+}
+
+func (r *memUserRepository) SetLastLoginTime(ctx context.Context, uid string, loginTime int64) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	user, ok := r.users[uid]
+	if !ok {
+		return repo.ErrNotFound
+	}
+	old := user.LastLoginTime
+	user.LastLoginTime = loginTime
+	r.users[uid] = user
+	database.Compensate(ctx, func(ctx context.Context) error {
+		r.lock.Lock()
+		defer r.lock.Unlock()
+		u := r.users[uid]
+		u.LastLoginTime = old
+		r.users[uid] = u
+		return nil
+	})
+	return nil
+}
+
+func (r *memUserRepository) IncrGold(ctx context.Context, uid string, delta int64) (int64, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	user, ok := r.users[uid]
+	if !ok {
+		return 0, repo.ErrNotFound
+	}
+	if user.Gold+delta < 0 {
+		return 0, repo.ErrNotEnough
+	}
+	user.Gold += delta
+	r.users[uid] = user
+	database.Compensate(ctx, func(ctx context.Context) error {
+		r.lock.Lock()
+		defer r.lock.Unlock()
+		u := r.users[uid]
+		u.Gold -= delta
+		r.users[uid] = u
+		return nil
+	})
+	return user.Gold, nil
+}
+
+func (r *memUserRepository) SetRoomId(ctx context.Context, uid string, from, to string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	user, ok := r.users[uid]
+	if !ok {
+		return repo.ErrNotFound
+	}
+	if user.RoomId != from {
+		return repo.ErrConflict
+	}
+	user.RoomId = to
+	r.users[uid] = user
+	database.Compensate(ctx, func(ctx context.Context) error {
+		r.lock.Lock()
+		defer r.lock.Unlock()
+		u := r.users[uid]
+		if u.RoomId == to {
+			u.RoomId = from
+			r.users[uid] = u
+		}
+		return nil
+	})
+	return nil
+}
+
+type memAccountRepository struct {
+	lock     sync.RWMutex
+	accounts map[string]entity.Account // key为uid
+}
+
+func NewAccountRepository() repo.AccountRepository {
+	return &memAccountRepository{accounts: make(map[string]entity.Account)}
+}
+
+// conflict 检查账号、手机号的唯一约束，uid相同的为自身不算冲突
+func (r *memAccountRepository) conflict(account *entity.Account) bool {
+	for uid, v := range r.accounts {
+		if uid == account.Uid {
+			continue
+		}
+		if v.Account == account.Account || (account.Phone != "" && v.Phone == account.Phone) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *memAccountRepository) find(match func(v *entity.Account) bool) (*entity.Account, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	for _, v := range r.accounts {
+		if match(&v) {
+			return copyAccount(v), nil
+		}
+	}
+	return nil, repo.ErrNotFound
+}
+
+func (r *memAccountRepository) Create(ctx context.Context, account *entity.Account) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if _, ok := r.accounts[account.Uid]; ok || r.conflict(account) {
+		return repo.ErrDuplicate
+	}
+	account.Id = primitive.NewObjectID()
+	r.accounts[account.Uid] = *copyAccount(*account)
+	uid := account.Uid
+	database.Compensate(ctx, func(ctx context.Context) error {
+		r.lock.Lock()
+		defer r.lock.Unlock()
+		delete(r.accounts, uid)
+		return nil
+	})
+	return nil
+}
+
+func (r *memAccountRepository) FindByAccount(ctx context.Context, account string) (*entity.Account, error) {
+	return r.find(func(v *entity.Account) bool { return v.Account == account })
+}
+
+func (r *memAccountRepository) FindByUid(ctx context.Context, uid string) (*entity.Account, error) {
+	return r.find(func(v *entity.Account) bool { return v.Uid == uid })
+}
+
+func (r *memAccountRepository) FindByPhone(ctx context.Context, phone string) (*entity.Account, error) {
+	return r.find(func(v *entity.Account) bool { return phone != "" && v.Phone == phone })
+}
+
+func (r *memAccountRepository) SetBlock(ctx context.Context, uid string, block *entity.Block) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	account, ok := r.accounts[uid]
+	if !ok {
+		return repo.ErrNotFound
+	}
+	old := account.Block
+	account.Block = block
+	r.accounts[uid] = *copyAccount(account)
+	database.Compensate(ctx, func(ctx context.Context) error {
+		r.lock.Lock()
+		defer r.lock.Unlock()
+		a := r.accounts[uid]
+		a.Block = old
+		r.accounts[uid] = a
+		return nil
+	})
+	return nil
+}
+
+func (r *memAccountRepository) SetPhone(ctx context.Context, uid, phone string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	account, ok := r.accounts[uid]
+	if !ok {
+		return repo.ErrNotFound
+	}
+	old := account.Phone
+	account.Phone = phone
+	if r.conflict(&account) {
+		return repo.ErrDuplicate
+	}
+	r.accounts[uid] = account
+	database.Compensate(ctx, func(ctx context.Context) error {
+		r.lock.Lock()
+		defer r.lock.Unlock()
+		a := r.accounts[uid]
+		a.Phone = old
+		r.accounts[uid] = a
+		return nil
+	})
+	return nil
+}
+
+func (r *memAccountRepository) ListBlocked(ctx context.Context, now int64, offset, limit int64) ([]*entity.Account, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	list := make([]*entity.Account, 0)
+	for _, v := range r.accounts {
+		if v.Blocked(now) {
+			list = append(list, copyAccount(v))
+		}
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].Block.CreateTime > list[j].Block.CreateTime
+	})
+	return page(list, offset, limit), nil
+}
+
+// copyAccount 冻结信息是指针，需要单独拷贝
+func copyAccount(v entity.Account) *entity.Account {
+	if v.Block != nil {
+		block := *v.Block
+		v.Block = &block
+	}
+	return &v
+}
+
+type memUnionRepository struct {
+	lock   sync.RWMutex
+	unions map[int64]entity.Union
+}
+
+func NewUnionRepository() repo.UnionRepository {
+	return &memUnionRepository{unions: make(map[int64]entity.Union)}
+}
+
+func (r *memUnionRepository) Create(ctx context.Context, union *entity.Union) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if _, ok := r.unions[union.UnionId]; ok {
+		return repo.ErrDuplicate
+	}
+	for _, v := range r.unions {
+		if v.OwnerUid == union.OwnerUid {
+			return repo.ErrDuplicate
+		}
+	}
+	union.Id = primitive.NewObjectID()
+	r.unions[union.UnionId] = *union
+	unionId := union.UnionId
+	database.Compensate(ctx, func(ctx context.Context) error {
+		r.lock.Lock()
+		defer r.lock.Unlock()
+		delete(r.unions, unionId)
+		return nil
+	})
+	return nil
+}
+
+func (r *memUnionRepository) FindByUnionId(ctx context.Context, unionId int64) (*entity.Union, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	union, ok := r.unions[unionId]
+	if !ok {
+		return nil, repo.ErrNotFound
+	}
+	return &union, nil
+}
+
+func (r *memUnionRepository) FindByOwner(ctx context.Context, ownerUid string) (*entity.Union, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	for _, v := range r.unions {
+		if v.OwnerUid == ownerUid {
+			return &v, nil
+		}
+	}
+	return nil, repo.ErrNotFound
+}
+
+func (r *memUnionRepository) Update(ctx context.Context, union *entity.Union) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	old, ok := r.unions[union.UnionId]
+	if !ok {
+		return repo.ErrNotFound
+	}
+	u := *union
+	u.Id = old.Id
+	r.unions[union.UnionId] = u
+	return nil
+}
+
+type memberKey struct {
+	unionId int64
+	uid     string
+}
+
+type memUnionMemberRepository struct {
+	lock    sync.RWMutex
+	members map[memberKey]entity.UnionMember
+}
+
+func NewUnionMemberRepository() repo.UnionMemberRepository {
+	return &memUnionMemberRepository{members: make(map[memberKey]entity.UnionMember)}
+}
+
+// conflict 检查邀请码的唯一约束
+func (r *memUnionMemberRepository) conflict(member *entity.UnionMember) bool {
+	if member.InviteCode == "" {
+		return false
+	}
+	for k, v := range r.members {
+		if k != (memberKey{member.UnionId, member.Uid}) && v.InviteCode == member.InviteCode {
+			return true
+		}
+	}
+	return false
+}
+
+// set 写入成员并登记补偿，old为nil时补偿为删除
+func (r *memUnionMemberRepository) set(ctx context.Context, key memberKey, member *entity.UnionMember, old *entity.UnionMember) {
+	if member == nil {
+		delete(r.members, key)
+	} else {
+		r.members[key] = *member
+	}
+	database.Compensate(ctx, func(ctx context.Context) error {
+		r.lock.Lock()
+		defer r.lock.Unlock()
+		if old == nil {
+			delete(r.members, key)
+		} else {
+			r.members[key] = *old
+		}
+		return nil
+	})
+}
+
+// list 按加入时间排序
+func (r *memUnionMemberRepository) list(match func(v *entity.UnionMember) bool) []*entity.UnionMember {
+	list := make([]*entity.UnionMember, 0)
+	for _, v := range r.members {
+		if match(&v) {
+			m := v
+			list = append(list, &m)
+		}
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].JoinTime != list[j].JoinTime {
+			return list[i].JoinTime < list[j].JoinTime
+		}
+		return list[i].Id.Hex() < list[j].Id.Hex()
+	})
+	return list
+}
+
+func (r *memUnionMemberRepository) Create(ctx context.Context, member *entity.UnionMember) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	key := memberKey{member.UnionId, member.Uid}
+	if _, ok := r.members[key]; ok || r.conflict(member) {
+		return repo.ErrDuplicate
+	}
+	member.Id = primitive.NewObjectID()
+	r.set(ctx, key, member, nil)
+	return nil
+}
+
+func (r *memUnionMemberRepository) Find(ctx context.Context, unionId int64, uid string) (*entity.UnionMember, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	member, ok := r.members[memberKey{unionId, uid}]
+	if !ok {
+		return nil, repo.ErrNotFound
+	}
+	return &member, nil
+}
+
+func (r *memUnionMemberRepository) FindByInviteCode(ctx context.Context, inviteCode string) (*entity.UnionMember, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	for _, v := range r.members {
+		if inviteCode != "" && v.InviteCode == inviteCode {
+			return &v, nil
+		}
+	}
+	return nil, repo.ErrNotFound
+}
+
+func (r *memUnionMemberRepository) ListByUid(ctx context.Context, uid string) ([]*entity.UnionMember, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.list(func(v *entity.UnionMember) bool { return v.Uid == uid }), nil
+}
+
+func (r *memUnionMemberRepository) List(ctx context.Context, unionId int64, superiorUid string, offset, limit int64) ([]*entity.UnionMember, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	list := r.list(func(v *entity.UnionMember) bool {
+		return v.UnionId == unionId && (superiorUid == "" || v.SuperiorUid == superiorUid)
+	})
+	return page(list, offset, limit), nil
+}
+
+func (r *memUnionMemberRepository) Count(ctx context.Context, unionId int64, superiorUid string) (int64, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	var n int64
+	for _, v := range r.members {
+		if v.UnionId == unionId && (superiorUid == "" || v.SuperiorUid == superiorUid) {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (r *memUnionMemberRepository) UpdateRole(ctx context.Context, unionId int64, uid string, role int, inviteCode string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	key := memberKey{unionId, uid}
+	old, ok := r.members[key]
+	if !ok {
+		return repo.ErrNotFound
+	}
+	m := old
+	m.Role = role
+	m.InviteCode = inviteCode
+	if r.conflict(&m) {
+		return repo.ErrDuplicate
+	}
+	r.set(ctx, key, &m, &old)
+	return nil
+}
+
+func (r *memUnionMemberRepository) Delete(ctx context.Context, unionId int64, uid string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	key := memberKey{unionId, uid}
+	old, ok := r.members[key]
+	if !ok {
+		return repo.ErrNotFound
+	}
+	r.set(ctx, key, nil, &old)
+	return nil
+}
+
+func (r *memUnionMemberRepository) TransferSubordinates(ctx context.Context, unionId int64, from, to string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for k, v := range r.members {
+		if v.UnionId == unionId && v.SuperiorUid == from {
+			old := v
+			v.SuperiorUid = to
+			r.set(ctx, k, &v, &old)
+		}
+	}
+	return nil
+}
+
+func (r *memUnionMemberRepository) IncrScore(ctx context.Context, unionId int64, uid string, delta int64) (int64, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	key := memberKey{unionId, uid}
+	member, ok := r.members[key]
+	if !ok {
+		return 0, repo.ErrNotFound
+	}
+	if member.Score+delta < 0 {
+		return 0, repo.ErrNotEnough
+	}
+	member.Score += delta
+	r.members[key] = member
+	database.Compensate(ctx, func(ctx context.Context) error {
+		r.lock.Lock()
+		defer r.lock.Unlock()
+		m := r.members[key]
+		m.Score -= delta
+		r.members[key] = m
+		return nil
+	})
+	return member.Score, nil
+}
+
+type memRoomRepository struct {
+	lock  sync.RWMutex
+	rooms map[string]entity.Room
+}
+
+func NewRoomRepository() repo.RoomRepository {
+	return &memRoomRepository{rooms: make(map[string]entity.Room)}
+}
+
+func (r *memRoomRepository) Create(ctx context.Context, room *entity.Room) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if _, ok := r.rooms[room.RoomId]; ok {
+		return repo.ErrDuplicate
+	}
+	room.Id = primitive.NewObjectID()
+	r.rooms[room.RoomId] = *room
+	return nil
+}
+
+func (r *memRoomRepository) FindByRoomId(ctx context.Context, roomId string) (*entity.Room, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	room, ok := r.rooms[roomId]
+	if !ok {
+		return nil, repo.ErrNotFound
+	}
+	return &room, nil
+}
+
+func (r *memRoomRepository) Delete(ctx context.Context, roomId string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	delete(r.rooms, roomId)
+	return nil
+}
+
+func (r *memRoomRepository) CountByUnion(ctx context.Context, unionId int64) (int64, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	var n int64
+	for _, v := range r.rooms {
+		if v.UnionId == unionId {
+			n++
+		}
+	}
+	return n, nil
+}
+
+type memLedgerRepository struct {
+	lock    sync.RWMutex
+	ledgers []entity.Ledger
+}
+
+func NewLedgerRepository() repo.LedgerRepository {
+	return &memLedgerRepository{}
+}
+
+func (r *memLedgerRepository) Insert(ctx context.Context, ledger *entity.Ledger) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	ledger.Id = primitive.NewObjectID()
+	r.ledgers = append(r.ledgers, *ledger)
+	id := ledger.Id
+	database.Compensate(ctx, func(ctx context.Context) error {
+		r.lock.Lock()
+		defer r.lock.Unlock()
+		for i := range r.ledgers {
+			if r.ledgers[i].Id == id {
+				r.ledgers = append(r.ledgers[:i], r.ledgers[i+1:]...)
+				break
+			}
+		}
+		return nil
+	})
+	return nil
+}
+
+func (r *memLedgerRepository) ListByUid(ctx context.Context, uid string, unionId int64, offset, limit int64) ([]*entity.Ledger, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	list := make([]*entity.Ledger, 0)
+	// 倒序遍历，同一时间的流水后写入的在前
+	for i := len(r.ledgers) - 1; i >= 0; i-- {
+		v := r.ledgers[i]
+		if v.Uid == uid && v.UnionId == unionId {
+			list = append(list, &v)
+		}
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].CreateTime > list[j].CreateTime
+	})
+	return page(list, offset, limit), nil
+}
+
+func (r *memLedgerRepository) DailySummary(ctx context.Context, uid string, unionId int64, start, end int64) ([]*entity.LedgerDaily, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	days := make(map[string]*entity.LedgerDaily)
+	list := make([]*entity.LedgerDaily, 0)
+	for _, v := range r.ledgers {
+		if v.Uid != uid || v.UnionId != unionId || v.CreateTime < start || v.CreateTime >= end {
+			continue
+		}
+		day := time.UnixMilli(v.CreateTime).Format("2006-01-02")
+		d, ok := days[day]
+		if !ok {
+			d = &entity.LedgerDaily{Day: day}
+			days[day] = d
+			list = append(list, d)
+		}
+		if v.Amount > 0 {
+			d.Income += v.Amount
+		} else {
+			d.Expense += v.Amount
+		}
+		d.Count++
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Day > list[j].Day
+	})
+	return list, nil
+}
+
+type memCounterRepository struct {
+	lock sync.Mutex
+	seqs map[string]int64
+}
+
+func NewCounterRepository() repo.CounterRepository {
+	return &memCounterRepository{seqs: make(map[string]int64)}
+}
+
+func (r *memCounterRepository) Next(ctx context.Context, name string, start int64) (int64, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.seqs[name]++
+	return start + r.seqs[name] - 1, nil
+}
+
+type memAnnouncementRepository struct {
+	lock          sync.RWMutex
+	announcements []entity.Announcement
+}
+
+func NewAnnouncementRepository() repo.AnnouncementRepository {
+	return &memAnnouncementRepository{}
+}
+
+func (r *memAnnouncementRepository) Create(ctx context.Context, announcement *entity.Announcement) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	announcement.Id = primitive.NewObjectID()
+	r.announcements = append(r.announcements, *announcement)
+	return nil
+}
+
+func (r *memAnnouncementRepository) Delete(ctx context.Context, id string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for i := range r.announcements {
+		if r.announcements[i].Id.Hex() == id {
+			r.announcements = append(r.announcements[:i], r.announcements[i+1:]...)
+			return nil
+		}
+	}
+	return repo.ErrNotFound
+}
+
+func (r *memAnnouncementRepository) ListActive(ctx context.Context, now int64) ([]*entity.Announcement, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	list := make([]*entity.Announcement, 0)
+	for i := range r.announcements {
+		v := r.announcements[i]
+		if v.Active(now) {
+			list = append(list, &v)
+		}
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].Sort != list[j].Sort {
+			return list[i].Sort > list[j].Sort
+		}
+		return list[i].StartTime > list[j].StartTime
+	})
+	return list, nil
+}
+
+// page 内存分页
+func page[T any](list []T, offset, limit int64) []T {
+	if offset >= int64(len(list)) {
+		return list[:0]
+	}
+	end := int64(len(list))
+	if limit > 0 && offset+limit < end {
+		end = offset + limit
+	}
+	return list[offset:end]
+}
+
+type memSettlementRepository struct {
+	lock        sync.RWMutex
+	settlements map[string]entity.Settlement
+}
+
+func NewSettlementRepository() repo.SettlementRepository {
+	return &memSettlementRepository{settlements: make(map[string]entity.Settlement)}
+}
+
+func (r *memSettlementRepository) Create(ctx context.Context, settlement *entity.Settlement) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if _, ok := r.settlements[settlement.Key]; ok {
+		return repo.ErrDuplicate
+	}
+	settlement.Id = primitive.NewObjectID()
+	r.settlements[settlement.Key] = *settlement
+	return nil
+}
+
+func (r *memSettlementRepository) ListDue(ctx context.Context, now int64, limit int64) ([]*entity.Settlement, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	list := make([]*entity.Settlement, 0)
+	for _, v := range r.settlements {
+		if v.NextTime <= now {
+			v := v
+			list = append(list, &v)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].NextTime < list[j].NextTime
+	})
+	if int64(len(list)) > limit {
+		list = list[:limit]
+	}
+	return list, nil
+}
+
+func (r *memSettlementRepository) Retry(ctx context.Context, key string, nextTime int64, lastError string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	v, ok := r.settlements[key]
+	if !ok {
+		return repo.ErrNotFound
+	}
+	v.Retries++
+	v.NextTime, v.LastError = nextTime, lastError
+	r.settlements[key] = v
+	return nil
+}
+
+func (r *memSettlementRepository) Delete(ctx context.Context, key string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	delete(r.settlements, key)
+	return nil
+}
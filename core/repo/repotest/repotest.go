@@ -0,0 +1,34 @@
+package repotest
+
+import (
+	"common/database"
+	"core/repo"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// New 创建不依赖外部mongo、redis进程的Manager，用于单元测试，测试结束时自动关闭
+// Repository使用本包中的内存实现，事务使用补偿实现，redis使用进程内的miniredis，RedisManager的所有操作（包括lua脚本）都可以正常使用
+func New(t testing.TB) *repo.Manager {
+	t.Helper()
+	server := miniredis.RunT(t)
+	m := &repo.Manager{
+		Redis: &database.RedisManager{
+			Cli: redis.NewClient(&redis.Options{Addr: server.Addr()}),
+		},
+		Accounts:      NewAccountRepository(),
+		Unions:        NewUnionRepository(),
+		UnionMembers:  NewUnionMemberRepository(),
+		Rooms:         NewRoomRepository(),
+		Ledgers:       NewLedgerRepository(),
+		Counters:      NewCounterRepository(),
+		Announcements: NewAnnouncementRepository(),
+		Settlements:   NewSettlementRepository(),
+		Tx:            database.Compensating{},
+	}
+	m.WithUsers(NewUserRepository())
+	t.Cleanup(m.Close)
+	return m
+}
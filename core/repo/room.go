@@ -0,0 +1,40 @@
+package repo
+
+import (
+	"context"
+	"core/models/entity"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type roomRepository struct {
+	c *mongo.Collection
+}
+
+func NewRoomRepository(db *mongo.Database) RoomRepository {
+	return &roomRepository{c: db.Collection(roomCollection)}
+}
+
+func (r *roomRepository) Create(ctx context.Context, room *entity.Room) error {
+	res, err := r.c.InsertOne(ctx, room)
+	if err != nil {
+		return mongoError(err)
+	}
+	room.Id = insertedId(res)
+	return nil
+}
+
+func (r *roomRepository) FindByRoomId(ctx context.Context, roomId string) (*entity.Room, error) {
+	return findOne[entity.Room](ctx, r.c, bson.M{"roomId": roomId})
+}
+
+func (r *roomRepository) Delete(ctx context.Context, roomId string) error {
+	_, err := r.c.DeleteOne(ctx, bson.M{"roomId": roomId})
+	return mongoError(err)
+}
+
+func (r *roomRepository) CountByUnion(ctx context.Context, unionId int64) (int64, error) {
+	n, err := r.c.CountDocuments(ctx, bson.M{"unionId": unionId})
+	return n, mongoError(err)
+}
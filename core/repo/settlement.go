@@ -0,0 +1,51 @@
+package repo
+
+import (
+	"context"
+	"core/models/entity"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type settlementRepository struct {
+	c *mongo.Collection
+}
+
+func NewSettlementRepository(db *mongo.Database) SettlementRepository {
+	return &settlementRepository{c: db.Collection(settlementCollection)}
+}
+
+func (r *settlementRepository) Create(ctx context.Context, settlement *entity.Settlement) error {
+	res, err := r.c.InsertOne(ctx, settlement)
+	if err != nil {
+		return mongoError(err)
+	}
+	settlement.Id = insertedId(res)
+	return nil
+}
+
+func (r *settlementRepository) ListDue(ctx context.Context, now int64, limit int64) ([]*entity.Settlement, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "nextTime", Value: 1}}).SetLimit(limit)
+	return findMany[entity.Settlement](ctx, r.c, bson.M{"nextTime": bson.M{"$lte": now}}, opts)
+}
+
+func (r *settlementRepository) Retry(ctx context.Context, key string, nextTime int64, lastError string) error {
+	res, err := r.c.UpdateOne(ctx, bson.M{"key": key}, bson.M{
+		"$inc": bson.M{"retries": 1},
+		"$set": bson.M{"nextTime": nextTime, "lastError": lastError},
+	})
+	if err != nil {
+		return mongoError(err)
+	}
+	if res.MatchedCount == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *settlementRepository) Delete(ctx context.Context, key string) error {
+	_, err := r.c.DeleteOne(ctx, bson.M{"key": key})
+	return mongoError(err)
+}
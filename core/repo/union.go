@@ -0,0 +1,43 @@
+package repo
+
+import (
+	"common/database"
+	"context"
+	"core/models/entity"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type unionRepository struct {
+	c *mongo.Collection
+}
+
+func NewUnionRepository(db *mongo.Database) UnionRepository {
+	return &unionRepository{c: db.Collection(unionCollection)}
+}
+
+func (r *unionRepository) Create(ctx context.Context, union *entity.Union) error {
+	res, err := r.c.InsertOne(ctx, union)
+	if err != nil {
+		return mongoError(err)
+	}
+	union.Id = insertedId(res)
+	database.Compensate(ctx, func(ctx context.Context) error {
+		_, err := r.c.DeleteOne(ctx, bson.M{"_id": union.Id})
+		return err
+	})
+	return nil
+}
+
+func (r *unionRepository) FindByUnionId(ctx context.Context, unionId int64) (*entity.Union, error) {
+	return findOne[entity.Union](ctx, r.c, bson.M{"unionId": unionId})
+}
+
+func (r *unionRepository) FindByOwner(ctx context.Context, ownerUid string) (*entity.Union, error) {
+	return findOne[entity.Union](ctx, r.c, bson.M{"ownerUid": ownerUid})
+}
+
+func (r *unionRepository) Update(ctx context.Context, union *entity.Union) error {
+	return replaceOne(ctx, r.c, bson.M{"unionId": union.UnionId}, union)
+}
@@ -0,0 +1,151 @@
+package repo
+
+import (
+	"common/database"
+	"context"
+	"core/models/entity"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type unionMemberRepository struct {
+	c *mongo.Collection
+}
+
+func NewUnionMemberRepository(db *mongo.Database) UnionMemberRepository {
+	return &unionMemberRepository{c: db.Collection(unionMemberCollection)}
+}
+
+func (r *unionMemberRepository) Create(ctx context.Context, member *entity.UnionMember) error {
+	res, err := r.c.InsertOne(ctx, member)
+	if err != nil {
+		return mongoError(err)
+	}
+	member.Id = insertedId(res)
+	database.Compensate(ctx, func(ctx context.Context) error {
+		_, err := r.c.DeleteOne(ctx, bson.M{"_id": member.Id})
+		return err
+	})
+	return nil
+}
+
+func (r *unionMemberRepository) Find(ctx context.Context, unionId int64, uid string) (*entity.UnionMember, error) {
+	return findOne[entity.UnionMember](ctx, r.c, bson.M{"unionId": unionId, "uid": uid})
+}
+
+func (r *unionMemberRepository) FindByInviteCode(ctx context.Context, inviteCode string) (*entity.UnionMember, error) {
+	return findOne[entity.UnionMember](ctx, r.c, bson.M{"inviteCode": inviteCode})
+}
+
+func (r *unionMemberRepository) ListByUid(ctx context.Context, uid string) ([]*entity.UnionMember, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "joinTime", Value: 1}})
+	return findMany[entity.UnionMember](ctx, r.c, bson.M{"uid": uid}, opts)
+}
+
+func (r *unionMemberRepository) List(ctx context.Context, unionId int64, superiorUid string, offset, limit int64) ([]*entity.UnionMember, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "joinTime", Value: 1}, {Key: "_id", Value: 1}}).
+		SetSkip(offset).
+		SetLimit(limit)
+	return findMany[entity.UnionMember](ctx, r.c, memberFilter(unionId, superiorUid), opts)
+}
+
+func (r *unionMemberRepository) Count(ctx context.Context, unionId int64, superiorUid string) (int64, error) {
+	n, err := r.c.CountDocuments(ctx, memberFilter(unionId, superiorUid))
+	return n, mongoError(err)
+}
+
+func (r *unionMemberRepository) UpdateRole(ctx context.Context, unionId int64, uid string, role int, inviteCode string) error {
+	old := new(entity.UnionMember)
+	err := r.c.FindOneAndUpdate(ctx, bson.M{"unionId": unionId, "uid": uid}, roleUpdate(role, inviteCode)).Decode(old)
+	if err != nil {
+		return mongoError(err)
+	}
+	database.Compensate(ctx, func(ctx context.Context) error {
+		_, err := r.c.UpdateOne(ctx, bson.M{"_id": old.Id}, roleUpdate(old.Role, old.InviteCode))
+		return err
+	})
+	return nil
+}
+
+func (r *unionMemberRepository) Delete(ctx context.Context, unionId int64, uid string) error {
+	old := new(entity.UnionMember)
+	err := r.c.FindOneAndDelete(ctx, bson.M{"unionId": unionId, "uid": uid}).Decode(old)
+	if err != nil {
+		return mongoError(err)
+	}
+	database.Compensate(ctx, func(ctx context.Context) error {
+		_, err := r.c.InsertOne(ctx, old)
+		return err
+	})
+	return nil
+}
+
+func (r *unionMemberRepository) TransferSubordinates(ctx context.Context, unionId int64, from, to string) error {
+	filter := memberFilter(unionId, from)
+	if !database.InCompensation(ctx) {
+		_, err := r.c.UpdateMany(ctx, filter, bson.M{"$set": bson.M{"superiorUid": to}})
+		return mongoError(err)
+	}
+	// 补偿时只能还原本次转移的成员，先记下这些成员
+	members, err := findMany[entity.UnionMember](ctx, r.c, filter)
+	if err != nil || len(members) == 0 {
+		return err
+	}
+	ids := make(bson.A, 0, len(members))
+	for _, v := range members {
+		ids = append(ids, v.Id)
+	}
+	if _, err = r.c.UpdateMany(ctx, bson.M{"_id": bson.M{"$in": ids}}, bson.M{"$set": bson.M{"superiorUid": to}}); err != nil {
+		return mongoError(err)
+	}
+	database.Compensate(ctx, func(ctx context.Context) error {
+		_, err := r.c.UpdateMany(ctx, bson.M{"_id": bson.M{"$in": ids}}, bson.M{"$set": bson.M{"superiorUid": from}})
+		return err
+	})
+	return nil
+}
+
+func (r *unionMemberRepository) IncrScore(ctx context.Context, unionId int64, uid string, delta int64) (int64, error) {
+	filter := bson.M{"unionId": unionId, "uid": uid}
+	if delta < 0 {
+		// 扣减时要求积分足够，保证原子性
+		filter["score"] = bson.M{"$gte": -delta}
+	}
+	member := new(entity.UnionMember)
+	err := r.c.FindOneAndUpdate(ctx, filter, bson.M{"$inc": bson.M{"score": delta}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(member)
+	if err != nil {
+		err = mongoError(err)
+		if err == ErrNotFound && delta < 0 {
+			// 区分成员不存在和积分不足
+			if _, findErr := r.Find(ctx, unionId, uid); findErr == nil {
+				return 0, ErrNotEnough
+			}
+		}
+		return 0, err
+	}
+	database.Compensate(ctx, func(ctx context.Context) error {
+		_, err := r.c.UpdateOne(ctx, bson.M{"unionId": unionId, "uid": uid}, bson.M{"$inc": bson.M{"score": -delta}})
+		return err
+	})
+	return member.Score, nil
+}
+
+func roleUpdate(role int, inviteCode string) bson.M {
+	if inviteCode == "" {
+		return bson.M{"$set": bson.M{"role": role}, "$unset": bson.M{"inviteCode": ""}}
+	}
+	return bson.M{"$set": bson.M{"role": role, "inviteCode": inviteCode}}
+}
+
+// memberFilter superiorUid为空时查询联盟所有成员
+func memberFilter(unionId int64, superiorUid string) bson.M {
+	filter := bson.M{"unionId": unionId}
+	if superiorUid != "" {
+		filter["superiorUid"] = superiorUid
+	}
+	return filter
+}
@@ -0,0 +1,99 @@
+package repo
+
+import (
+	"common/database"
+	"context"
+	"core/models/entity"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type userRepository struct {
+	c *mongo.Collection
+}
+
+func NewUserRepository(db *mongo.Database) UserRepository {
+	return &userRepository{c: db.Collection(userCollection)}
+}
+
+func (r *userRepository) Create(ctx context.Context, user *entity.User) error {
+	res, err := r.c.InsertOne(ctx, user)
+	if err != nil {
+		return mongoError(err)
+	}
+	user.Id = insertedId(res)
+	database.Compensate(ctx, func(ctx context.Context) error {
+		_, err := r.c.DeleteOne(ctx, bson.M{"_id": user.Id})
+		return err
+	})
+	return nil
+}
+
+func (r *userRepository) FindByUid(ctx context.Context, uid string) (*entity.User, error) {
+	return findOne[entity.User](ctx, r.c, bson.M{"uid": uid})
+}
+
+func (r *userRepository) FindByUids(ctx context.Context, uids []string) ([]*entity.User, error) {
+	return findMany[entity.User](ctx, r.c, bson.M{"uid": bson.M{"$in": uids}})
+}
+
+func (r *userRepository) UpdateProfile(ctx context.Context, user *entity.User) error {
+	return updateFields(ctx, r.c, bson.M{"uid": user.Uid}, bson.M{
+		"nickname":   user.Nickname,
+		"avatar":     user.Avatar,
+		"sex":        user.Sex,
+		"signature":  user.Signature,
+		"updateTime": user.UpdateTime,
+	})
+}
+
+func (r *userRepository) SetLastLoginTime(ctx context.Context, uid string, loginTime int64) error {
+	return updateFields(ctx, r.c, bson.M{"uid": uid}, bson.M{"lastLoginTime": loginTime})
+}
+
+func (r *userRepository) SetRoomId(ctx context.Context, uid string, from, to string) error {
+	res, err := r.c.UpdateOne(ctx, bson.M{"uid": uid, "roomId": from}, bson.M{"$set": bson.M{"roomId": to}})
+	if err != nil {
+		return mongoError(err)
+	}
+	if res.MatchedCount == 0 {
+		// 区分用户不存在和房间已变化
+		if _, err = r.FindByUid(ctx, uid); err != nil {
+			return err
+		}
+		return ErrConflict
+	}
+	database.Compensate(ctx, func(ctx context.Context) error {
+		_, err := r.c.UpdateOne(ctx, bson.M{"uid": uid, "roomId": to}, bson.M{"$set": bson.M{"roomId": from}})
+		return err
+	})
+	return nil
+}
+
+func (r *userRepository) IncrGold(ctx context.Context, uid string, delta int64) (int64, error) {
+	filter := bson.M{"uid": uid}
+	if delta < 0 {
+		// 扣减时要求余额足够，保证原子性
+		filter["gold"] = bson.M{"$gte": -delta}
+	}
+	user := new(entity.User)
+	err := r.c.FindOneAndUpdate(ctx, filter, bson.M{"$inc": bson.M{"gold": delta}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(user)
+	if err != nil {
+		err = mongoError(err)
+		if err == ErrNotFound && delta < 0 {
+			// 区分用户不存在和余额不足
+			if _, findErr := r.FindByUid(ctx, uid); findErr == nil {
+				return 0, ErrNotEnough
+			}
+		}
+		return 0, err
+	}
+	database.Compensate(ctx, func(ctx context.Context) error {
+		_, err := r.c.UpdateOne(ctx, bson.M{"uid": uid}, bson.M{"$inc": bson.M{"gold": -delta}})
+		return err
+	})
+	return user.Gold, nil
+}
@@ -0,0 +1,49 @@
+package session
+
+import (
+	"common/database"
+	"common/logs"
+	"context"
+	"encoding/json"
+)
+
+// 踢下线的广播频道，所有connector订阅，持有该uid连接的connector负责断开
+const kickChannel = "session:kick"
+
+// KickMessage 踢下线通知
+type KickMessage struct {
+	Uid    string `json:"uid"`
+	Reason string `json:"reason"`
+}
+
+// Kick 通知connector断开uid的长连接
+func Kick(ctx context.Context, redis *database.RedisManager, uid string, reason string) error {
+	data, err := json.Marshal(&KickMessage{Uid: uid, Reason: reason})
+	if err != nil {
+		return err
+	}
+	return redis.Cli.Publish(ctx, kickChannel, data).Err()
+}
+
+// SubscribeKick 订阅踢下线通知，直到ctx结束
+func SubscribeKick(ctx context.Context, redis *database.RedisManager, fn func(msg *KickMessage)) {
+	sub := redis.Cli.Subscribe(ctx, kickChannel)
+	defer sub.Close()
+	ch := sub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case m, ok := <-ch:
+			if !ok {
+				return
+			}
+			msg := new(KickMessage)
+			if err := json.Unmarshal([]byte(m.Payload), msg); err != nil {
+				logs.Error("kick message unmarshal err: %v", err)
+				continue
+			}
+			fn(msg)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package session
+
+import (
+	"common/biz"
+	"common/database"
+	"context"
+	"errors"
+	"strconv"
+	"time"
+)
+
+// 跨user、game、hall节点串行化对同一数据的操作
+// lock:user:<uid> 用户的金币、所在房间；lock:union:<unionId> 联盟的房间数量；lock:union:<unionId>:member:<uid> 联盟积分
+const (
+	lockKeyPrefix = "lock:"
+	lockTTL       = 10 * time.Second
+	lockWait      = 3 * time.Second
+)
+
+// LockUsers 持有用户的锁执行fn，如：扣减金币、进入房间，获取不到锁时返回biz.UserDataLocked
+func LockUsers(ctx context.Context, r *database.RedisManager, uids []string, fn func(ctx context.Context) error) error {
+	keys := make([]string, 0, len(uids))
+	for _, uid := range uids {
+		keys = append(keys, lockKeyPrefix+"user:"+uid)
+	}
+	return withLocks(ctx, r, keys, fn)
+}
+
+// LockMembers 持有联盟成员的锁执行fn，用于变动联盟积分
+func LockMembers(ctx context.Context, r *database.RedisManager, unionId int64, uids []string, fn func(ctx context.Context) error) error {
+	keys := make([]string, 0, len(uids))
+	for _, uid := range uids {
+		keys = append(keys, unionLockKey(unionId)+":member:"+uid)
+	}
+	return withLocks(ctx, r, keys, fn)
+}
+
+// LockUnion 持有联盟的锁执行fn，如：检查联盟的房间数量后创建房间
+func LockUnion(ctx context.Context, r *database.RedisManager, unionId int64, fn func(ctx context.Context) error) error {
+	return withLocks(ctx, r, []string{unionLockKey(unionId)}, fn)
+}
+
+func unionLockKey(unionId int64) string {
+	return lockKeyPrefix + "union:" + strconv.FormatInt(unionId, 10)
+}
+
+func withLocks(ctx context.Context, r *database.RedisManager, keys []string, fn func(ctx context.Context) error) error {
+	err := r.WithLocks(ctx, keys, lockTTL, lockWait, fn)
+	if errors.Is(err, database.ErrLockNotObtained) {
+		return biz.UserDataLocked
+	}
+	return err
+}
@@ -0,0 +1,127 @@
+package session
+
+import (
+	"common/biz"
+	"common/database"
+	"common/logs"
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// 全平台的维护状态保存在redis中，修改后广播给所有connector推送倒计时
+const (
+	maintenanceKey     = "session:maintenance"
+	maintenanceChannel = "session:maintenance:notify"
+)
+
+// Maintenance 维护计划，startTime之前只推送倒计时，之后不在白名单中的用户不能登录、握手、创建房间
+type Maintenance struct {
+	StartTime int64    `json:"startTime"` // 开始时间，毫秒
+	EndTime   int64    `json:"endTime"`   // 预计结束时间，0为未知
+	Message   string   `json:"message"`
+	Uids      []string `json:"uids,omitempty"` // 白名单
+	Ips       []string `json:"ips,omitempty"`
+	Operator  string   `json:"operator,omitempty"`
+}
+
+// Active 维护是否进行中，到了预计结束时间后自动结束
+func (m *Maintenance) Active() bool {
+	return m.activeAt(time.Now().UnixMilli())
+}
+
+func (m *Maintenance) activeAt(now int64) bool {
+	return m != nil && now >= m.StartTime && (m.EndTime == 0 || now < m.EndTime)
+}
+
+// Allow uid或ip在白名单中，为空的参数不参与判断
+func (m *Maintenance) Allow(uid, ip string) bool {
+	for _, v := range m.Uids {
+		if uid != "" && v == uid {
+			return true
+		}
+	}
+	for _, v := range m.Ips {
+		if ip != "" && v == ip {
+			return true
+		}
+	}
+	return false
+}
+
+// SetMaintenance 保存维护计划并通知connector
+func SetMaintenance(ctx context.Context, redis *database.RedisManager, m *Maintenance) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	if err = redis.Set(ctx, maintenanceKey, string(data), 0); err != nil {
+		return err
+	}
+	return redis.Cli.Publish(ctx, maintenanceChannel, data).Err()
+}
+
+// CancelMaintenance 取消维护并通知connector
+func CancelMaintenance(ctx context.Context, redis *database.RedisManager) error {
+	if _, err := redis.Del(ctx, maintenanceKey); err != nil {
+		return err
+	}
+	return redis.Cli.Publish(ctx, maintenanceChannel, "").Err()
+}
+
+// GetMaintenance 当前的维护计划，没有时返回nil
+func GetMaintenance(ctx context.Context, redis *database.RedisManager) (*Maintenance, error) {
+	data, err := redis.Get(ctx, maintenanceKey)
+	if database.IsNil(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	m := new(Maintenance)
+	if err = json.Unmarshal([]byte(data), m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// CheckMaintenance 维护中并且uid、ip都不在白名单中时返回ServerMaintenance
+// 读取失败时只记录日志，不影响正常请求
+func CheckMaintenance(ctx context.Context, redis *database.RedisManager, uid, ip string) error {
+	m, err := GetMaintenance(ctx, redis)
+	if err != nil {
+		logs.ErrorCtx(ctx, "get maintenance err: %v", err)
+		return nil
+	}
+	if m.Active() && !m.Allow(uid, ip) {
+		return biz.ServerMaintenance
+	}
+	return nil
+}
+
+// SubscribeMaintenance 订阅维护计划的变更，取消维护时m为nil，直到ctx结束
+func SubscribeMaintenance(ctx context.Context, redis *database.RedisManager, fn func(m *Maintenance)) {
+	sub := redis.Cli.Subscribe(ctx, maintenanceChannel)
+	defer sub.Close()
+	ch := sub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+			if msg.Payload == "" {
+				fn(nil)
+				continue
+			}
+			m := new(Maintenance)
+			if err := json.Unmarshal([]byte(msg.Payload), m); err != nil {
+				logs.Error("maintenance message unmarshal err: %v", err)
+				continue
+			}
+			fn(m)
+		}
+	}
+}
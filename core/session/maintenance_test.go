@@ -0,0 +1,27 @@
+package session
+
+import "testing"
+
+func TestMaintenance_Active(t *testing.T) {
+	tests := []struct {
+		name string
+		m    *Maintenance
+		now  int64
+		want bool
+	}{
+		{name: "nil", m: nil, now: 100, want: false},
+		{name: "not started", m: &Maintenance{StartTime: 200}, now: 100, want: false},
+		{name: "started", m: &Maintenance{StartTime: 100}, now: 100, want: true},
+		{name: "unknown end", m: &Maintenance{StartTime: 100}, now: 1 << 40, want: true},
+		{name: "before end", m: &Maintenance{StartTime: 100, EndTime: 200}, now: 199, want: true},
+		{name: "at end", m: &Maintenance{StartTime: 100, EndTime: 200}, now: 200, want: false},
+		{name: "after end", m: &Maintenance{StartTime: 100, EndTime: 200}, now: 300, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.m.activeAt(tt.now); got != tt.want {
+				t.Errorf("activeAt(%d) = %v, want %v", tt.now, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package session
+
+import (
+	"common/database"
+	"context"
+	"encoding/json"
+	"time"
+)
+
+const (
+	onlineKey      = "session:online"   // hash，field为connector的id，value为在线数和上报时间
+	OnlineInterval = 10 * time.Second   // connector上报在线数的间隔
+	onlineExpire   = 3 * OnlineInterval // 超过该时间未上报的connector视为已下线，不计入在线数
+)
+
+type onlineReport struct {
+	Count int64 `json:"count"`
+	Time  int64 `json:"time"` // 上报时间，毫秒
+}
+
+// ReportOnline connector定时上报当前的在线会话数
+func ReportOnline(ctx context.Context, redis *database.RedisManager, node string, count int64) error {
+	data, err := json.Marshal(&onlineReport{Count: count, Time: time.Now().UnixMilli()})
+	if err != nil {
+		return err
+	}
+	return redis.HSet(ctx, onlineKey, node, string(data))
+}
+
+// RemoveOnline connector停止时删除自己的上报
+func RemoveOnline(ctx context.Context, redis *database.RedisManager, node string) error {
+	_, err := redis.HDel(ctx, onlineKey, node)
+	return err
+}
+
+// OnlineCount 所有connector的在线会话数之和，顺便清理已下线connector的上报
+func OnlineCount(ctx context.Context, redis *database.RedisManager) (int64, error) {
+	reports, err := redis.HGetAll(ctx, onlineKey)
+	if err != nil {
+		return 0, err
+	}
+	deadline := time.Now().Add(-onlineExpire).UnixMilli()
+	var total int64
+	for node, v := range reports {
+		var r onlineReport
+		if err = json.Unmarshal([]byte(v), &r); err != nil || r.Time < deadline {
+			_, _ = redis.HDel(ctx, onlineKey, node)
+			continue
+		}
+		total += r.Count
+	}
+	return total, nil
+}
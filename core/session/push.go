@@ -0,0 +1,57 @@
+package session
+
+import (
+	"common/database"
+	"common/logs"
+	"context"
+	"encoding/json"
+)
+
+// 推送消息的广播频道，所有connector订阅，持有uid连接的connector负责推送给客户端
+const pushChannel = "session:push"
+
+// PushMessage 推送给一组用户的消息
+type PushMessage struct {
+	Uids  []string        `json:"uids"`
+	Route string          `json:"route"`
+	Data  json.RawMessage `json:"data"`
+}
+
+// Push 通过connector推送消息给uids，不在线的用户收不到
+func Push(ctx context.Context, redis *database.RedisManager, uids []string, route string, data any) error {
+	if len(uids) == 0 {
+		return nil
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	msg, err := json.Marshal(&PushMessage{Uids: uids, Route: route, Data: raw})
+	if err != nil {
+		return err
+	}
+	return redis.Cli.Publish(ctx, pushChannel, msg).Err()
+}
+
+// SubscribePush 订阅推送消息，直到ctx结束
+func SubscribePush(ctx context.Context, redis *database.RedisManager, fn func(msg *PushMessage)) {
+	sub := redis.Cli.Subscribe(ctx, pushChannel)
+	defer sub.Close()
+	ch := sub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case m, ok := <-ch:
+			if !ok {
+				return
+			}
+			msg := new(PushMessage)
+			if err := json.Unmarshal([]byte(m.Payload), msg); err != nil {
+				logs.Error("push message unmarshal err: %v", err)
+				continue
+			}
+			fn(msg)
+		}
+	}
+}
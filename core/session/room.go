@@ -0,0 +1,84 @@
+package session
+
+import (
+	"common/database"
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 房间所在的game节点：room:node:<roomId> -> game节点的grpc地址
+// 房间由创建它的game节点独占，connector据此把房间内的请求转发到该节点
+// 节点定时续期，节点宕机后映射过期，房间随之失效
+const (
+	roomNodeKeyPrefix = "room:node:"
+	RoomNodeTTL       = time.Minute
+	RoomRenewInterval = RoomNodeTTL / 3
+)
+
+// 只删除本节点的映射
+var unbindRoomScript = database.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+
+// BindRoom 记录房间所在的节点，房间号已被使用时返回false
+func BindRoom(ctx context.Context, r *database.RedisManager, roomId, node string) (bool, error) {
+	return r.SetNX(ctx, roomNodeKeyPrefix+roomId, node, RoomNodeTTL)
+}
+
+// UnbindRoom 房间解散后删除映射
+func UnbindRoom(ctx context.Context, r *database.RedisManager, roomId, node string) error {
+	_, err := r.Eval(ctx, unbindRoomScript, []string{roomNodeKeyPrefix + roomId}, node)
+	return err
+}
+
+// RoomNode 房间所在的节点，房间不存在时返回空
+func RoomNode(ctx context.Context, r *database.RedisManager, roomId string) (string, error) {
+	node, err := r.Get(ctx, roomNodeKeyPrefix+roomId)
+	if database.IsNil(err) {
+		return "", nil
+	}
+	return node, err
+}
+
+// RenewRooms 节点定时为自己的所有房间续期
+func RenewRooms(ctx context.Context, r *database.RedisManager, roomIds []string) error {
+	if len(roomIds) == 0 {
+		return nil
+	}
+	_, err := r.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, roomId := range roomIds {
+			pipe.Expire(ctx, roomNodeKeyPrefix+roomId, RoomNodeTTL)
+		}
+		return nil
+	})
+	return err
+}
+
+// 金币场等待中的房间：room:waiting:<gameType>:<tier> -> 有空座位的房间号，有序集合，分数为进入等待的时间
+// 所有game节点共享，匹配时本节点没有可以加入的房间再加入其他节点的房间
+// 节点宕机后遗留的房间号在匹配时检查房间所在的节点后清理
+const roomWaitingKeyPrefix = "room:waiting:"
+
+func waitingKey(gameType, tier int) string {
+	return roomWaitingKeyPrefix + strconv.Itoa(gameType) + ":" + strconv.Itoa(tier)
+}
+
+// AddWaitingRoom 房间有空座位时加入等待，已经在等待中时保留原来的时间
+func AddWaitingRoom(ctx context.Context, r *database.RedisManager, gameType, tier int, roomId string) error {
+	return r.Cli.ZAddNX(ctx, waitingKey(gameType, tier), redis.Z{Score: float64(time.Now().UnixMilli()), Member: roomId}).Err()
+}
+
+// RemoveWaitingRoom 房间满员或解散后移出等待
+func RemoveWaitingRoom(ctx context.Context, r *database.RedisManager, gameType, tier int, roomId string) error {
+	return r.Cli.ZRem(ctx, waitingKey(gameType, tier), roomId).Err()
+}
+
+// WaitingRooms 等待时间最长的limit个房间
+func WaitingRooms(ctx context.Context, r *database.RedisManager, gameType, tier int, limit int64) ([]string, error) {
+	return r.Cli.ZRange(ctx, waitingKey(gameType, tier), 0, limit-1).Result()
+}
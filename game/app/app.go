@@ -0,0 +1,113 @@
+package app
+
+import (
+	"common/config"
+	"common/discovery"
+	"common/lifecycle"
+	"common/logs"
+	"common/metrics"
+	"common/tracing"
+	"context"
+	"core/repo"
+	"fmt"
+	"game/internal/ddz"
+	"game/internal/engine"
+	"game/internal/mahjong"
+	"game/internal/room"
+	"game/internal/service"
+	"game/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Run 启动程序
+func Run(ctx context.Context) error {
+
+	// 1.初始化日志库
+	logs.InitLog(config.Current().AppName)
+
+	// 2.初始化链路追踪
+	shutdownTrace, err := tracing.Init(config.Current().AppName, config.Current().Trace)
+	if err != nil {
+		return err
+	}
+
+	// 3.初始化数据库管理
+	manager := repo.New()
+
+	// 4.获取etcd注册客户端实例
+	register := discovery.NewRegister()
+
+	// 5.注册玩法，创建房间管理器，房间绑定到本节点注册到etcd的地址，connector按此地址转发房间内的请求
+	engine.Register(ddz.GameType, ddz.New)
+	engine.Register(mahjong.GameType, mahjong.New)
+	rooms := room.NewManager(config.Current().Etcd.Register.Addr, manager)
+	rooms.SetForwarder(service.NewForwarder()) // 匹配其他节点上等待中的金币场房间
+
+	// 6.创建gRPC服务端，注册 game service 和grpc标准健康检查服务
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(tracing.UnaryServerInterceptor(), metrics.UnaryServerInterceptor()))
+	healthServer := health.NewServer()
+	pb.RegisterGameServiceServer(server, service.NewGameService(rooms))
+	healthpb.RegisterHealthServer(server, healthServer)
+
+	// 就绪检查：数据库连接和etcd注册状态
+	metrics.RegisterChecker(
+		metrics.NewChecker("mongo", manager.Mongo.Ping),
+		metrics.NewChecker("redis", manager.Redis.Ping),
+		metrics.NewChecker("etcd", register.Check),
+	)
+	metricServer, err := metrics.NewServer(fmt.Sprintf("0.0.0.0:%d", config.Current().MetricPort))
+	if err != nil {
+		return err
+	}
+
+	// 7.按顺序启动各组件，停止时逆序：先从etcd注销，再等待grpc处理完存量请求，然后解散房间，最后关闭数据库连接
+	lc := lifecycle.New(config.Current().AppName)
+	lc.Append(lc.HttpServer("metrics", metricServer))
+	lc.Append(lifecycle.Hook{
+		Name:   "trace",
+		OnStop: shutdownTrace,
+	})
+	lc.Append(lifecycle.Hook{
+		Name: "database",
+		OnStop: func(ctx context.Context) error {
+			manager.Close()
+			return nil
+		},
+	})
+	renewCtx, stopRenew := context.WithCancel(context.Background())
+	lc.Append(lifecycle.Hook{
+		Name: "rooms",
+		OnStart: func(ctx context.Context) error {
+			go rooms.Run(renewCtx)
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			stopRenew()
+			rooms.Close(ctx)
+			return nil
+		},
+	})
+	lc.Append(lc.GrpcServer("grpc", server, config.Current().Grpc.Addr))
+	lc.Append(lifecycle.Hook{
+		Name: "etcd",
+		OnStart: func(ctx context.Context) error {
+			// gRPC服务启动成功之后，再注册到etcd
+			if err := register.Register(config.Current().Etcd); err != nil {
+				return err
+			}
+			healthServer.Resume()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			healthServer.Shutdown() // 健康检查置为NOT_SERVING
+			register.Close()        // 从etcd注销，不再有新请求进来
+			return nil
+		},
+	})
+	lc.OnReload(func() {
+		logs.SetLevel(config.Current().Log.Level)
+	})
+	return lc.Run(ctx)
+}
@@ -0,0 +1,271 @@
+package ddz
+
+import "sort"
+
+// Card 一张牌，0-51按点数从3到2排列，每个点数4种花色，52为小王，53为大王
+// 牌的大小顺序和数值顺序一致，按数值排序即按点数排序
+type Card int
+
+const (
+	SmallJoker Card = 52
+	BigJoker   Card = 53
+	deckSize        = 54
+)
+
+// 点数，3-14为3到A，15为2
+const (
+	rank3          = 3
+	rankA          = 14
+	rank2          = 15
+	rankSmallJoker = 16
+	rankBigJoker   = 17
+	rankCount      = 18
+)
+
+// Rank 牌的点数
+func (c Card) Rank() int {
+	if c >= SmallJoker {
+		return rankSmallJoker + int(c-SmallJoker)
+	}
+	return int(c)/4 + rank3
+}
+
+func (c Card) Valid() bool {
+	return c >= 0 && c < deckSize
+}
+
+// Kind 牌型
+type Kind int
+
+const (
+	KindInvalid      Kind = iota
+	KindSingle            // 单张
+	KindPair              // 对子
+	KindTriple            // 三张
+	KindTripleSingle      // 三带一
+	KindTriplePair        // 三带一对
+	KindStraight          // 顺子，5张及以上连续的单张，不含2和王
+	KindPairStraight      // 连对，3对及以上连续的对子
+	KindPlane             // 飞机，2个及以上连续的三张
+	KindPlaneSingle       // 飞机带单张，每个三张带一张
+	KindPlanePair         // 飞机带对子，每个三张带一对
+	KindFourSingle        // 四带二，带两张单牌
+	KindFourPair          // 四带两对
+	KindBomb              // 炸弹
+	KindRocket            // 王炸
+)
+
+// Pattern 解析后的牌型，rank为主体（不含带牌）的最大点数，len为顺子、连对、飞机的长度
+// 牌型和长度相同时张数也相同，只比较rank
+type Pattern struct {
+	Kind Kind `json:"kind"`
+	Rank int  `json:"rank"`
+	Len  int  `json:"len"`
+}
+
+// Parse 解析牌型，一手牌可以解析为多种牌型时返回优先的一种
+func Parse(cards []Card) (Pattern, bool) {
+	list := parse(cards)
+	if len(list) == 0 {
+		return Pattern{}, false
+	}
+	return list[0], true
+}
+
+// Beats cards能否压过上家的牌型prev，可以时返回按prev解析的牌型
+func Beats(cards []Card, prev Pattern) (Pattern, bool) {
+	for _, p := range parse(cards) {
+		if p.Beats(prev) {
+			return p, true
+		}
+	}
+	return Pattern{}, false
+}
+
+// Beats 王炸最大，炸弹大于其他牌型，其他牌型需要牌型和长度相同并且点数更大
+func (p Pattern) Beats(prev Pattern) bool {
+	switch {
+	case p.Kind == KindRocket:
+		return true
+	case prev.Kind == KindRocket:
+		return false
+	case p.Kind == KindBomb && prev.Kind != KindBomb:
+		return true
+	case p.Kind != prev.Kind || p.Len != prev.Len:
+		return false
+	}
+	return p.Rank > prev.Rank
+}
+
+// IsBomb 炸弹和王炸，出牌后翻倍
+func (p Pattern) IsBomb() bool {
+	return p.Kind == KindBomb || p.Kind == KindRocket
+}
+
+// parse 所有可能的牌型，如333444555666既是四连飞机，也是三连飞机带三张单牌
+func parse(cards []Card) []Pattern {
+	n := len(cards)
+	if n == 0 || !distinct(cards) {
+		return nil
+	}
+	var counts [rankCount]int
+	for _, c := range cards {
+		counts[c.Rank()]++
+	}
+	if n == 2 && counts[rankSmallJoker] == 1 && counts[rankBigJoker] == 1 {
+		return []Pattern{{Kind: KindRocket, Rank: rankBigJoker, Len: 1}}
+	}
+	var list []Pattern
+	if r := rankOf(counts, n); r > 0 {
+		kinds := [...]Kind{KindInvalid, KindSingle, KindPair, KindTriple, KindBomb}
+		if n < len(kinds) {
+			list = append(list, Pattern{Kind: kinds[n], Rank: r, Len: 1})
+		}
+	}
+	switch n {
+	case 4:
+		if r := rankOf(counts, 3); r > 0 {
+			list = append(list, Pattern{Kind: KindTripleSingle, Rank: r, Len: 1})
+		}
+	case 5:
+		if r := rankOf(counts, 3); r > 0 && rankOf(counts, 2) > 0 {
+			list = append(list, Pattern{Kind: KindTriplePair, Rank: r, Len: 1})
+		}
+	case 6:
+		if r := rankOf(counts, 4); r > 0 {
+			list = append(list, Pattern{Kind: KindFourSingle, Rank: r, Len: 1})
+		}
+	case 8:
+		// 四带两对，两个炸弹时可以按任意一个作为主体
+		for r := rankA + 1; r >= rank3; r-- {
+			if counts[r] == 4 && restEven(counts, r, r, 4) {
+				list = append(list, Pattern{Kind: KindFourPair, Rank: r, Len: 1})
+			}
+		}
+	}
+	if r := chain(counts, 1, n); r > 0 && n >= 5 {
+		list = append(list, Pattern{Kind: KindStraight, Rank: r, Len: n})
+	}
+	if r := chain(counts, 2, n/2); r > 0 && n%2 == 0 && n >= 6 {
+		list = append(list, Pattern{Kind: KindPairStraight, Rank: r, Len: n / 2})
+	}
+	return append(list, planes(counts, n)...)
+}
+
+// planes 飞机，长的优先，相同长度时点数大的优先
+func planes(counts [rankCount]int, n int) []Pattern {
+	var list []Pattern
+	for k := n / 3; k >= 2; k-- {
+		for hi := rankA; hi-k+1 >= rank3; hi-- {
+			lo := hi - k + 1
+			if !atLeast(counts, lo, hi, 3) {
+				continue
+			}
+			switch rest := n - 3*k; {
+			case rest == 0:
+				list = append(list, Pattern{Kind: KindPlane, Rank: hi, Len: k})
+			case rest == k:
+				list = append(list, Pattern{Kind: KindPlaneSingle, Rank: hi, Len: k})
+			case rest == 2*k && restEven(counts, lo, hi, 3):
+				list = append(list, Pattern{Kind: KindPlanePair, Rank: hi, Len: k})
+			}
+		}
+	}
+	return list
+}
+
+// rankOf 张数为count的点数，有多个时返回最大的，没有时返回0
+func rankOf(counts [rankCount]int, count int) int {
+	for r := rankCount - 1; r >= rank3; r-- {
+		if counts[r] == count {
+			return r
+		}
+	}
+	return 0
+}
+
+// chain 每个点数恰好unit张并且连续length个点数，最大不超过A，返回最大的点数
+func chain(counts [rankCount]int, unit, length int) int {
+	lo, hi := 0, 0
+	for r := rank3; r < rankCount; r++ {
+		if counts[r] == 0 {
+			continue
+		}
+		if counts[r] != unit {
+			return 0
+		}
+		if lo == 0 {
+			lo = r
+		}
+		hi = r
+	}
+	if lo == 0 || hi > rankA || hi-lo+1 != length {
+		return 0
+	}
+	for r := lo; r <= hi; r++ {
+		if counts[r] != unit {
+			return 0
+		}
+	}
+	return hi
+}
+
+func atLeast(counts [rankCount]int, lo, hi, count int) bool {
+	for r := lo; r <= hi; r++ {
+		if counts[r] < count {
+			return false
+		}
+	}
+	return true
+}
+
+// restEven 去掉[lo, hi]每个点数的used张后，剩余的牌都能组成对子
+func restEven(counts [rankCount]int, lo, hi, used int) bool {
+	for r := rank3; r < rankCount; r++ {
+		rest := counts[r]
+		if r >= lo && r <= hi {
+			rest -= used
+		}
+		if rest%2 != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func distinct(cards []Card) bool {
+	var seen [deckSize]bool
+	for _, c := range cards {
+		if !c.Valid() || seen[c] {
+			return false
+		}
+		seen[c] = true
+	}
+	return true
+}
+
+func sortCards(cards []Card) {
+	sort.Slice(cards, func(i, j int) bool { return cards[i] < cards[j] })
+}
+
+// remove 从手牌中移除cards，有不在手牌中的牌时返回false，手牌不变
+func remove(hand, cards []Card) ([]Card, bool) {
+	var take [deckSize]bool
+	for _, c := range cards {
+		take[c] = true
+	}
+	rest := make([]Card, 0, len(hand))
+	for _, c := range hand {
+		if take[c] {
+			take[c] = false
+			continue
+		}
+		rest = append(rest, c)
+	}
+	for _, v := range take {
+		if v {
+			return hand, false
+		}
+	}
+	return rest, true
+}
@@ -0,0 +1,170 @@
+package ddz
+
+import "testing"
+
+// cards 按点数生成牌，同一点数依次使用不同的花色，16、17为小王、大王
+func cards(ranks ...int) []Card {
+	var used [rankCount]int
+	list := make([]Card, 0, len(ranks))
+	for _, r := range ranks {
+		switch r {
+		case rankSmallJoker:
+			list = append(list, SmallJoker)
+		case rankBigJoker:
+			list = append(list, BigJoker)
+		default:
+			list = append(list, Card((r-rank3)*4+used[r]))
+		}
+		used[r]++
+	}
+	return list
+}
+
+func TestCard_Rank(t *testing.T) {
+	tests := []struct {
+		card Card
+		rank int
+	}{
+		{0, 3}, {3, 3}, {4, 4}, {47, 14}, {48, 15}, {51, 15}, {SmallJoker, 16}, {BigJoker, 17},
+	}
+	for _, tt := range tests {
+		if got := tt.card.Rank(); got != tt.rank {
+			t.Errorf("Card(%d).Rank() = %d, want %d", tt.card, got, tt.rank)
+		}
+	}
+	if Card(-1).Valid() || Card(54).Valid() || !BigJoker.Valid() {
+		t.Error("Valid() wrong")
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		cards []Card
+		want  Pattern
+		ok    bool
+	}{
+		{"single", cards(3), Pattern{KindSingle, 3, 1}, true},
+		{"single two", cards(15), Pattern{KindSingle, 15, 1}, true},
+		{"single joker", cards(17), Pattern{KindSingle, 17, 1}, true},
+		{"pair", cards(5, 5), Pattern{KindPair, 5, 1}, true},
+		{"not pair", cards(5, 6), Pattern{}, false},
+		{"rocket", cards(16, 17), Pattern{KindRocket, 17, 1}, true},
+		{"joker and two", cards(15, 17), Pattern{}, false},
+		{"triple", cards(7, 7, 7), Pattern{KindTriple, 7, 1}, true},
+		{"not triple", cards(3, 3, 4), Pattern{}, false},
+		{"bomb", cards(8, 8, 8, 8), Pattern{KindBomb, 8, 1}, true},
+		{"bomb of two", cards(15, 15, 15, 15), Pattern{KindBomb, 15, 1}, true},
+		{"triple single", cards(9, 9, 9, 3), Pattern{KindTripleSingle, 9, 1}, true},
+		{"triple single joker", cards(16, 9, 9, 9), Pattern{KindTripleSingle, 9, 1}, true},
+		{"two pairs", cards(3, 3, 4, 4), Pattern{}, false},
+		{"triple pair", cards(9, 9, 9, 3, 3), Pattern{KindTriplePair, 9, 1}, true},
+		{"triple two singles", cards(9, 9, 9, 3, 4), Pattern{}, false},
+		{"triple jokers", cards(9, 9, 9, 16, 17), Pattern{}, false},
+		{"straight", cards(3, 4, 5, 6, 7), Pattern{KindStraight, 7, 5}, true},
+		{"straight unordered", cards(14, 10, 12, 11, 13), Pattern{KindStraight, 14, 5}, true},
+		{"straight longest", cards(3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14), Pattern{KindStraight, 14, 12}, true},
+		{"straight with two", cards(11, 12, 13, 14, 15), Pattern{}, false},
+		{"straight too short", cards(3, 4, 5, 6), Pattern{}, false},
+		{"straight gap", cards(3, 4, 5, 6, 8), Pattern{}, false},
+		{"straight duplicate", cards(3, 4, 5, 6, 7, 7), Pattern{}, false},
+		{"pair straight", cards(3, 3, 4, 4, 5, 5), Pattern{KindPairStraight, 5, 3}, true},
+		{"pair straight long", cards(9, 9, 10, 10, 11, 11, 12, 12, 13, 13, 14, 14), Pattern{KindPairStraight, 14, 6}, true},
+		{"pair straight too short", cards(3, 3, 4, 4), Pattern{}, false},
+		{"pair straight with two", cards(13, 13, 14, 14, 15, 15), Pattern{}, false},
+		{"pair straight gap", cards(3, 3, 4, 4, 6, 6), Pattern{}, false},
+		{"plane", cards(3, 3, 3, 4, 4, 4), Pattern{KindPlane, 4, 2}, true},
+		{"plane with two", cards(14, 14, 14, 15, 15, 15), Pattern{}, false},
+		{"plane gap", cards(3, 3, 3, 5, 5, 5), Pattern{}, false},
+		{"plane single", cards(3, 3, 3, 4, 4, 4, 7, 9), Pattern{KindPlaneSingle, 4, 2}, true},
+		{"plane single pair kicker", cards(3, 3, 3, 4, 4, 4, 7, 7), Pattern{KindPlaneSingle, 4, 2}, true},
+		{"plane single jokers", cards(3, 3, 3, 4, 4, 4, 16, 17), Pattern{KindPlaneSingle, 4, 2}, true},
+		{"plane single three", cards(3, 3, 3, 4, 4, 4, 5, 5, 5, 7, 8, 9), Pattern{KindPlaneSingle, 5, 3}, true},
+		{"plane pair", cards(3, 3, 3, 4, 4, 4, 7, 7, 9, 9), Pattern{KindPlanePair, 4, 2}, true},
+		{"plane pair with four", cards(3, 3, 3, 4, 4, 4, 7, 7, 7, 7), Pattern{KindPlanePair, 4, 2}, true},
+		{"plane wrong kickers", cards(3, 3, 3, 4, 4, 4, 5, 5, 5, 7), Pattern{}, false},
+		{"plane four long", cards(3, 3, 3, 4, 4, 4, 5, 5, 5, 6, 6, 6), Pattern{KindPlane, 6, 4}, true},
+		{"four single", cards(5, 5, 5, 5, 3, 4), Pattern{KindFourSingle, 5, 1}, true},
+		{"four single pair", cards(5, 5, 5, 5, 3, 3), Pattern{KindFourSingle, 5, 1}, true},
+		{"four pair", cards(5, 5, 5, 5, 3, 3, 4, 4), Pattern{KindFourPair, 5, 1}, true},
+		{"two bombs", cards(5, 5, 5, 5, 6, 6, 6, 6), Pattern{KindFourPair, 6, 1}, true},
+		{"four pair singles", cards(5, 5, 5, 5, 3, 3, 4, 6), Pattern{}, false},
+		{"empty", nil, Pattern{}, false},
+		{"duplicate card", []Card{0, 0}, Pattern{}, false},
+		{"invalid card", []Card{54}, Pattern{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Parse(tt.cards)
+			if ok != tt.ok || got != tt.want {
+				t.Fatalf("Parse(%v) = %+v, %v, want %+v, %v", tt.cards, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestBeats(t *testing.T) {
+	tests := []struct {
+		name  string
+		cards []Card
+		prev  []Card
+		want  bool
+	}{
+		{"bigger single", cards(4), cards(3), true},
+		{"same single", cards(3), cards(3), false},
+		{"smaller single", cards(3), cards(4), false},
+		{"two over ace", cards(15), cards(14), true},
+		{"joker over two", cards(16), cards(15), true},
+		{"big joker over small", cards(17), cards(16), true},
+		{"pair over single", cards(5, 5), cards(3), false},
+		{"bigger pair", cards(6, 6), cards(5, 5), true},
+		{"triple single by triple", cards(4, 4, 4, 3), cards(3, 3, 3, 14), true},
+		{"triple single over triple pair", cards(4, 4, 4, 3), cards(3, 3, 3, 5, 5), false},
+		{"bigger triple pair", cards(10, 10, 10, 3, 3), cards(9, 9, 9, 14, 14), true},
+		{"bigger straight", cards(4, 5, 6, 7, 8), cards(3, 4, 5, 6, 7), true},
+		{"longer straight", cards(4, 5, 6, 7, 8, 9), cards(3, 4, 5, 6, 7), false},
+		{"bigger pair straight", cards(4, 4, 5, 5, 6, 6), cards(3, 3, 4, 4, 5, 5), true},
+		{"shorter pair straight", cards(9, 9, 10, 10, 11, 11), cards(3, 3, 4, 4, 5, 5, 6, 6), false},
+		{"bigger plane", cards(5, 5, 5, 6, 6, 6), cards(3, 3, 3, 4, 4, 4), true},
+		{"bigger plane single", cards(5, 5, 5, 6, 6, 6, 3, 4), cards(3, 3, 3, 4, 4, 4, 14, 15), true},
+		{"plane single over plane pair", cards(5, 5, 5, 6, 6, 6, 3, 4), cards(3, 3, 3, 4, 4, 4, 7, 7, 8, 8), false},
+		{"bigger plane pair", cards(5, 5, 5, 6, 6, 6, 3, 3, 4, 4), cards(3, 3, 3, 4, 4, 4, 7, 7, 8, 8), true},
+		// 333444555666按444555666带3、3、3压过三连飞机带单张
+		{"ambiguous as plane single", cards(3, 3, 3, 4, 4, 4, 5, 5, 5, 6, 6, 6), cards(3, 3, 3, 4, 4, 4, 5, 5, 5, 7, 8, 9), true},
+		{"ambiguous smaller plane single", cards(3, 3, 3, 4, 4, 4, 5, 5, 5, 6, 6, 6), cards(7, 7, 7, 8, 8, 8, 9, 9, 9, 10, 11, 12), false},
+		{"bigger four single", cards(6, 6, 6, 6, 3, 4), cards(5, 5, 5, 5, 14, 15), true},
+		{"four single over four pair", cards(6, 6, 6, 6, 3, 4), cards(5, 5, 5, 5, 3, 3, 4, 4), false},
+		{"bigger four pair", cards(6, 6, 6, 6, 3, 3, 4, 4), cards(5, 5, 5, 5, 7, 7, 8, 8), true},
+		{"bomb over single", cards(3, 3, 3, 3), cards(17), true},
+		{"bomb over straight", cards(3, 3, 3, 3), cards(10, 11, 12, 13, 14), true},
+		{"bomb over four pair", cards(3, 3, 3, 3), cards(6, 6, 6, 6, 3, 3, 4, 4), true},
+		{"bigger bomb", cards(4, 4, 4, 4), cards(3, 3, 3, 3), true},
+		{"smaller bomb", cards(3, 3, 3, 3), cards(15, 15, 15, 15), false},
+		{"rocket over bomb", cards(16, 17), cards(15, 15, 15, 15), true},
+		{"bomb over rocket", cards(15, 15, 15, 15), cards(16, 17), false},
+		{"pair over rocket", cards(15, 15), cards(16, 17), false},
+		{"invalid cards", cards(3, 5), cards(4), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prev, ok := Parse(tt.prev)
+			if !ok {
+				t.Fatalf("prev %v invalid", tt.prev)
+			}
+			if _, got := Beats(tt.cards, prev); got != tt.want {
+				t.Fatalf("Beats(%v, %+v) = %v, want %v", tt.cards, prev, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRemove(t *testing.T) {
+	hand := cards(3, 3, 4, 5)
+	rest, ok := remove(hand, hand[1:3])
+	if !ok || len(rest) != 2 || rest[0] != hand[0] || rest[1] != hand[3] {
+		t.Fatalf("remove = %v, %v", rest, ok)
+	}
+	if rest, ok = remove(hand, cards(3, 6)); ok || len(rest) != len(hand) {
+		t.Fatalf("remove missing = %v, %v", rest, ok)
+	}
+}
@@ -0,0 +1,323 @@
+package ddz
+
+import (
+	"common/biz"
+	"encoding/json"
+	"game/internal/engine"
+)
+
+// GameType 斗地主在配置中的游戏类型
+const GameType = 1
+
+const (
+	players    = 3
+	handSize   = 17
+	maxBid     = 3
+	maxRedeals = 3 // 连续无人叫分的重新发牌次数，超过后第一个叫分的座位以1分当地主
+
+	optionMaxBomb = "maxbomb" // 炸弹翻倍的次数上限，0为不限制
+)
+
+// 操作类型
+const (
+	ActionBid  = "bid"  // 叫分，data: {"score": 0-3}，0为不叫
+	ActionPlay = "play" // 出牌，data: {"cards": [...]}
+	ActionPass = "pass" // 不出
+)
+
+// 推送的路由
+const (
+	PushDeal     = "onDdzDeal"     // 发牌，只发给自己的手牌
+	PushBid      = "onDdzBid"      // 叫分
+	PushLandlord = "onDdzLandlord" // 确定地主，公开底牌
+	PushPlay     = "onDdzPlay"     // 出牌、不出
+)
+
+type stage int
+
+const (
+	stageBidding stage = iota
+	stagePlaying
+)
+
+// Game 一局斗地主，座位0-2
+type Game struct {
+	maxBomb int
+
+	stage   stage
+	hands   [players][]Card
+	bottom  []Card
+	current int
+
+	first   int // 第一个叫分的座位
+	bids    [players]int
+	turns   int // 本轮已经叫分的人数
+	redeals int
+	bid     int // 最高叫分，即底分
+	bidder  int
+
+	landlord  int
+	lastSeat  int // 最后出牌的座位，-1表示还没有出牌
+	last      Pattern
+	lastCards []Card
+	bombs     int
+	plays     [players]int // 每个座位出牌的次数，用于判断春天
+	winner    int
+}
+
+// New 按房间规则创建斗地主，只支持3人
+func New(rule map[string]int, n int) (engine.GameLogic, error) {
+	if n != players {
+		return nil, biz.RequestDataError
+	}
+	return &Game{maxBomb: rule[optionMaxBomb]}, nil
+}
+
+type bidData struct {
+	Score int `json:"score"`
+}
+
+type playData struct {
+	Cards []Card `json:"cards"`
+}
+
+// Deal 洗牌后每人17张，留3张底牌，随机选择第一个叫分的座位
+func (g *Game) Deal(t *engine.Table) error {
+	deck := make([]Card, deckSize)
+	for i := range deck {
+		deck[i] = Card(i)
+	}
+	t.Rand.Shuffle(len(deck), func(i, j int) { deck[i], deck[j] = deck[j], deck[i] })
+	for i := 0; i < players; i++ {
+		g.hands[i] = append([]Card(nil), deck[i*handSize:(i+1)*handSize]...)
+		sortCards(g.hands[i])
+	}
+	g.bottom = append([]Card(nil), deck[players*handSize:]...)
+	sortCards(g.bottom)
+
+	g.stage = stageBidding
+	g.first = t.Rand.Intn(players)
+	g.current = g.first
+	g.bids = [players]int{-1, -1, -1}
+	g.turns, g.bid, g.bidder = 0, 0, -1
+	g.landlord, g.lastSeat, g.lastCards, g.bombs, g.winner = -1, -1, nil, 0, -1
+	g.plays = [players]int{}
+	for i := 0; i < players; i++ {
+		t.Send(i, PushDeal, map[string]any{"hand": g.hands[i], "first": g.first})
+	}
+	t.Await(0, g.current)
+	return nil
+}
+
+func (g *Game) Action(t *engine.Table, seat int, a *engine.Action) error {
+	if g.stage == stageBidding {
+		if a.Type != ActionBid {
+			return biz.InvalidAction
+		}
+		var data bidData
+		if json.Unmarshal(a.Data, &data) != nil {
+			return biz.InvalidAction
+		}
+		return g.doBid(t, seat, data.Score)
+	}
+	switch a.Type {
+	case ActionPlay:
+		var data playData
+		if json.Unmarshal(a.Data, &data) != nil {
+			return biz.InvalidAction
+		}
+		return g.doPlay(t, seat, data.Cards)
+	case ActionPass:
+		return g.doPass(t, seat)
+	}
+	return biz.InvalidAction
+}
+
+// doBid 叫分需要大于当前的最高分，叫3分或者每人都叫过后确定地主
+func (g *Game) doBid(t *engine.Table, seat, score int) error {
+	if score < 0 || score > maxBid || (score > 0 && score <= g.bid) {
+		return biz.InvalidAction
+	}
+	g.bids[seat] = score
+	g.turns++
+	if score > 0 {
+		g.bid, g.bidder = score, seat
+	}
+	t.Broadcast(PushBid, map[string]any{"seat": seat, "score": score})
+	if score < maxBid && g.turns < players {
+		g.current = (seat + 1) % players
+		t.Await(0, g.current)
+		return nil
+	}
+	if g.bid == 0 {
+		g.redeals++
+		if g.redeals < maxRedeals {
+			return g.Deal(t)
+		}
+		g.bid, g.bidder = 1, g.first
+	}
+	g.redeals = 0
+	g.landlord = g.bidder
+	g.hands[g.landlord] = append(g.hands[g.landlord], g.bottom...)
+	sortCards(g.hands[g.landlord])
+	g.stage = stagePlaying
+	g.current = g.landlord
+	t.Broadcast(PushLandlord, map[string]any{"seat": g.landlord, "score": g.bid, "bottom": g.bottom})
+	t.Await(0, g.current)
+	return nil
+}
+
+// doPlay 首家出任意牌型，跟牌需要压过上家
+func (g *Game) doPlay(t *engine.Table, seat int, cards []Card) error {
+	p, ok := g.check(seat, cards)
+	if !ok {
+		return biz.InvalidAction
+	}
+	g.hands[seat], _ = remove(g.hands[seat], cards)
+	g.plays[seat]++
+	if p.IsBomb() {
+		g.bombs++
+	}
+	g.lastSeat, g.last, g.lastCards = seat, p, cards
+	t.Broadcast(PushPlay, map[string]any{"seat": seat, "cards": cards, "pattern": p, "left": len(g.hands[seat])})
+	if len(g.hands[seat]) == 0 {
+		g.winner = seat
+		t.End()
+		return nil
+	}
+	g.next(t, seat)
+	return nil
+}
+
+func (g *Game) doPass(t *engine.Table, seat int) error {
+	if g.leading(seat) {
+		return biz.InvalidAction
+	}
+	t.Broadcast(PushPlay, map[string]any{"seat": seat, "pass": true})
+	g.next(t, seat)
+	return nil
+}
+
+// check cards在手牌中并且是合法的牌型，跟牌时需要压过上家
+func (g *Game) check(seat int, cards []Card) (Pattern, bool) {
+	if !distinct(cards) {
+		return Pattern{}, false
+	}
+	if _, ok := remove(g.hands[seat], cards); !ok {
+		return Pattern{}, false
+	}
+	if g.leading(seat) {
+		return Parse(cards)
+	}
+	return Beats(cards, g.last)
+}
+
+// leading 还没有人出牌或者其他人都不出时由该座位首先出牌
+func (g *Game) leading(seat int) bool {
+	return g.lastSeat < 0 || g.lastSeat == seat
+}
+
+func (g *Game) next(t *engine.Table, seat int) {
+	g.current = (seat + 1) % players
+	t.Await(0, g.current)
+}
+
+// AutoPlay 叫分时不叫，首家出最小的单张，跟牌时不出
+func (g *Game) AutoPlay(_ *engine.Table, seat int) *engine.Action {
+	if g.stage == stageBidding {
+		data, _ := json.Marshal(bidData{Score: 0})
+		return &engine.Action{Type: ActionBid, Data: data}
+	}
+	if !g.leading(seat) {
+		return &engine.Action{Type: ActionPass}
+	}
+	data, _ := json.Marshal(playData{Cards: g.hands[seat][:1]})
+	return &engine.Action{Type: ActionPlay, Data: data}
+}
+
+// Settle 地主赢时两个农民各输一份，地主赢两份，地主输时相反
+func (g *Game) Settle(*engine.Table) *engine.Result {
+	unit := g.multiple()
+	scores := make([]int64, players)
+	landlordWin := g.winner == g.landlord
+	for i := range scores {
+		switch {
+		case i == g.landlord && landlordWin:
+			scores[i] = 2 * unit
+		case i == g.landlord:
+			scores[i] = -2 * unit
+		case landlordWin:
+			scores[i] = -unit
+		default:
+			scores[i] = unit
+		}
+	}
+	hands := make([][]Card, players)
+	for i := range hands {
+		hands[i] = g.hands[i]
+	}
+	return &engine.Result{
+		Scores: scores,
+		Detail: map[string]any{
+			"landlord": g.landlord,
+			"score":    g.bid,
+			"bombs":    g.bombs,
+			"spring":   g.spring(),
+			"multiple": unit,
+			"hands":    hands,
+		},
+	}
+}
+
+// multiple 每份的分数：叫分 × 2^炸弹数（不超过上限）× 春天2倍
+func (g *Game) multiple() int64 {
+	bombs := g.bombs
+	if g.maxBomb > 0 && bombs > g.maxBomb {
+		bombs = g.maxBomb
+	}
+	unit := int64(g.bid) << bombs
+	if g.spring() {
+		unit *= 2
+	}
+	return unit
+}
+
+// spring 春天：地主赢并且农民没有出过牌；反春：农民赢并且地主只出过第一手牌
+func (g *Game) spring() bool {
+	if g.winner < 0 || g.landlord < 0 {
+		return false
+	}
+	if g.winner == g.landlord {
+		for i := 0; i < players; i++ {
+			if i != g.landlord && g.plays[i] > 0 {
+				return false
+			}
+		}
+		return true
+	}
+	return g.plays[g.landlord] == 1
+}
+
+// View 自己的手牌、其他人的手牌数量和桌面上的牌
+func (g *Game) View(_ *engine.Table, seat int) any {
+	counts := make([]int, players)
+	for i := range counts {
+		counts[i] = len(g.hands[i])
+	}
+	view := map[string]any{
+		"stage":    g.stage,
+		"hand":     g.hands[seat],
+		"counts":   counts,
+		"current":  g.current,
+		"bids":     g.bids,
+		"landlord": g.landlord,
+		"lastSeat": g.lastSeat,
+		"bombs":    g.bombs,
+	}
+	if g.stage == stagePlaying {
+		view["bottom"] = g.bottom
+		view["score"] = g.bid
+		view["lastCards"] = g.lastCards
+	}
+	return view
+}
@@ -0,0 +1,228 @@
+package ddz
+
+import (
+	"common/biz"
+	"encoding/json"
+	"game/internal/engine"
+	"testing"
+	"time"
+)
+
+var testUids = []string{"u0", "u1", "u2"}
+
+func newTestTable(t *testing.T) (*engine.Table, *Game, *engine.ManualClock, *[]*engine.Result) {
+	t.Helper()
+	logic, err := New(map[string]int{optionMaxBomb: 3}, players)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clock := engine.NewManualClock(time.Unix(0, 0))
+	var results []*engine.Result
+	table := engine.NewTable("100000", nil, logic, engine.Options{
+		Clock: clock,
+		OnFinish: func(_ *engine.Table, result *engine.Result) {
+			results = append(results, result)
+		},
+	})
+	t.Cleanup(table.Close)
+	return table, logic.(*Game), clock, &results
+}
+
+func bid(score int) *engine.Action {
+	data, _ := json.Marshal(bidData{Score: score})
+	return &engine.Action{Type: ActionBid, Data: data}
+}
+
+func play(cards ...Card) *engine.Action {
+	data, _ := json.Marshal(playData{Cards: cards})
+	return &engine.Action{Type: ActionPlay, Data: data}
+}
+
+var pass = &engine.Action{Type: ActionPass}
+
+func TestNew(t *testing.T) {
+	if _, err := New(nil, 4); err != biz.RequestDataError {
+		t.Fatalf("4 players err = %v", err)
+	}
+}
+
+func TestGame_Bid(t *testing.T) {
+	table, g, _, _ := newTestTable(t)
+	if err := table.Start(1, testUids); err != nil {
+		t.Fatal(err)
+	}
+	var first int
+	_ = table.Do(func() error {
+		first = g.current
+		return nil
+	})
+	seat := func(i int) string { return testUids[(first+i)%players] }
+
+	tests := []struct {
+		name string
+		uid  string
+		a    *engine.Action
+		err  error
+	}{
+		{"play while bidding", seat(0), play(0), biz.InvalidAction},
+		{"score too big", seat(0), bid(4), biz.InvalidAction},
+		{"bid 1", seat(0), bid(1), nil},
+		{"not bigger", seat(1), bid(1), biz.InvalidAction},
+		{"pass", seat(1), bid(0), nil},
+		{"bid 3", seat(2), bid(3), nil},
+		{"bidding finished", seat(2), bid(2), biz.InvalidAction},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := table.Action(tt.uid, tt.a); err != tt.err {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+		})
+	}
+	_ = table.Do(func() error {
+		landlord := (first + 2) % players
+		if g.stage != stagePlaying || g.landlord != landlord || g.bid != 3 || g.current != landlord {
+			t.Errorf("stage = %d, landlord = %d, bid = %d", g.stage, g.landlord, g.bid)
+		}
+		for i := 0; i < players; i++ {
+			want := handSize
+			if i == landlord {
+				want += len(g.bottom)
+			}
+			if len(g.hands[i]) != want {
+				t.Errorf("seat %d hand = %d, want %d", i, len(g.hands[i]), want)
+			}
+		}
+		return nil
+	})
+}
+
+func TestGame_Play(t *testing.T) {
+	table, g, _, results := newTestTable(t)
+	if err := table.Start(1, testUids); err != nil {
+		t.Fatal(err)
+	}
+	// 各座位的点数不同，生成的牌不会重复
+	hands := [players][]Card{cards(3, 3, 7, 8), cards(4, 4, 11), cards(5, 6, 13)}
+	// 座位0以2分当地主，设置确定的手牌
+	_ = table.Do(func() error {
+		g.stage, g.landlord, g.bid, g.current = stagePlaying, 0, 2, 0
+		g.hands = hands
+		table.Await(0, 0)
+		return nil
+	})
+
+	tests := []struct {
+		name string
+		uid  string
+		a    *engine.Action
+		err  error
+	}{
+		{"not your turn", "u1", play(hands[1][0]), biz.NotYourTurn},
+		{"pass when leading", "u0", pass, biz.InvalidAction},
+		{"not in hand", "u0", play(hands[1][0]), biz.InvalidAction},
+		{"invalid pattern", "u0", play(hands[0][0], hands[0][2]), biz.InvalidAction},
+		{"duplicate card", "u0", play(hands[0][0], hands[0][0]), biz.InvalidAction},
+		{"bid while playing", "u0", bid(3), biz.InvalidAction},
+		{"lead pair", "u0", play(hands[0][0], hands[0][1]), nil},
+		{"wrong pattern", "u1", play(hands[1][2]), biz.InvalidAction},
+		{"bigger pair", "u1", play(hands[1][0], hands[1][1]), nil},
+		{"pass", "u2", pass, nil},
+		{"landlord pass", "u0", pass, nil},
+		{"lead again", "u1", play(hands[1][2]), nil},
+		{"finished", "u2", pass, biz.GameNotPlaying},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := table.Action(tt.uid, tt.a); err != tt.err {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+		})
+	}
+	if len(*results) != 1 {
+		t.Fatalf("results = %d", len(*results))
+	}
+	// 地主只出了一手牌，反春翻倍：2分 × 2 = 4
+	scores := (*results)[0].Scores
+	if scores[0] != -8 || scores[1] != 4 || scores[2] != 4 {
+		t.Fatalf("scores = %v", scores)
+	}
+}
+
+// 全部托管时无人叫分，重新发牌3次后第一个叫分的座位以1分当地主，农民一直不出，地主春天
+func TestGame_AutoPlay(t *testing.T) {
+	run := func(seed int64) *engine.Result {
+		table, _, clock, results := newTestTable(t)
+		if err := table.Start(seed, testUids); err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 200 && len(*results) == 0; i++ {
+			clock.Advance(15 * time.Second)
+		}
+		if len(*results) != 1 {
+			t.Fatalf("seed %d not finished", seed)
+		}
+		return (*results)[0]
+	}
+	for _, seed := range []int64{1, 7, 2024} {
+		result := run(seed)
+		detail := result.Detail.(map[string]any)
+		landlord := detail["landlord"].(int)
+		if detail["spring"] != true || detail["score"] != 1 || result.Scores[landlord] != 4 {
+			t.Fatalf("seed %d result = %+v", seed, result)
+		}
+		// 相同的种子得到相同的结果
+		again := run(seed)
+		if again.Detail.(map[string]any)["landlord"] != landlord {
+			t.Fatalf("seed %d landlord differs", seed)
+		}
+		hands, againHands := detail["hands"].([][]Card), again.Detail.(map[string]any)["hands"].([][]Card)
+		for i := range hands {
+			for j := range hands[i] {
+				if hands[i][j] != againHands[i][j] {
+					t.Fatalf("seed %d hands differ", seed)
+				}
+			}
+		}
+	}
+}
+
+func TestGame_Multiple(t *testing.T) {
+	tests := []struct {
+		name     string
+		bid      int
+		bombs    int
+		maxBomb  int
+		winner   int
+		plays    [players]int
+		multiple int64
+		spring   bool
+	}{
+		{"base", 3, 0, 0, 0, [players]int{5, 3, 2}, 3, false},
+		{"bombs", 2, 2, 0, 1, [players]int{5, 3, 2}, 8, false},
+		{"bombs capped", 1, 5, 3, 1, [players]int{5, 3, 2}, 8, false},
+		{"spring", 2, 1, 0, 0, [players]int{6, 0, 0}, 8, true},
+		{"not spring", 2, 0, 0, 0, [players]int{6, 1, 0}, 2, false},
+		{"anti spring", 1, 0, 0, 2, [players]int{1, 3, 4}, 2, true},
+		{"not anti spring", 1, 0, 0, 2, [players]int{2, 3, 4}, 1, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &Game{maxBomb: tt.maxBomb, bid: tt.bid, bombs: tt.bombs, landlord: 0, winner: tt.winner, plays: tt.plays}
+			if got := g.spring(); got != tt.spring {
+				t.Fatalf("spring = %v, want %v", got, tt.spring)
+			}
+			if got := g.multiple(); got != tt.multiple {
+				t.Fatalf("multiple = %d, want %d", got, tt.multiple)
+			}
+			scores := g.Settle(nil).Scores
+			sign := int64(1)
+			if tt.winner != 0 {
+				sign = -1
+			}
+			if scores[0] != sign*2*tt.multiple || scores[1] != -sign*tt.multiple || scores[0]+scores[1]+scores[2] != 0 {
+				t.Fatalf("scores = %v", scores)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package engine
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock 计时器的时间来源，测试和回放时使用ManualClock
+type Clock interface {
+	Now() time.Time
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Timer 可以取消的计时器
+type Timer interface {
+	Stop() bool
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) AfterFunc(d time.Duration, f func()) Timer {
+	return time.AfterFunc(d, f)
+}
+
+// ManualClock 手动推进的时钟，Advance时在调用方的协程中按到期顺序执行回调
+type ManualClock struct {
+	lock   sync.Mutex
+	now    time.Time
+	seq    int
+	timers []*manualTimer
+}
+
+type manualTimer struct {
+	clock   *ManualClock
+	at      time.Time
+	seq     int // 到期时间相同时按创建顺序执行
+	f       func()
+	stopped bool // 已经停止或者已经执行
+}
+
+func NewManualClock(now time.Time) *ManualClock {
+	return &ManualClock{now: now}
+}
+
+func (c *ManualClock) Now() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.now
+}
+
+func (c *ManualClock) AfterFunc(d time.Duration, f func()) Timer {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.seq++
+	t := &manualTimer{clock: c, at: c.now.Add(d), seq: c.seq, f: f}
+	c.timers = append(c.timers, t)
+	return t
+}
+
+// Advance 推进时间，执行期间新建的已到期计时器也会执行
+func (c *ManualClock) Advance(d time.Duration) {
+	c.lock.Lock()
+	end := c.now.Add(d)
+	c.lock.Unlock()
+	for {
+		c.lock.Lock()
+		sort.Slice(c.timers, func(i, j int) bool {
+			if c.timers[i].at.Equal(c.timers[j].at) {
+				return c.timers[i].seq < c.timers[j].seq
+			}
+			return c.timers[i].at.Before(c.timers[j].at)
+		})
+		if len(c.timers) == 0 || c.timers[0].at.After(end) {
+			c.now = end
+			c.lock.Unlock()
+			return
+		}
+		// 已经停止的计时器不在列表中，取出后标记为已停止
+		t := c.timers[0]
+		t.stopped = true
+		c.timers = c.timers[1:]
+		c.now = t.at
+		c.lock.Unlock()
+		t.f()
+	}
+}
+
+func (t *manualTimer) Stop() bool {
+	t.clock.lock.Lock()
+	defer t.clock.lock.Unlock()
+	if t.stopped {
+		return false
+	}
+	t.stopped = true
+	for i, v := range t.clock.timers {
+		if v == t {
+			t.clock.timers = append(t.clock.timers[:i], t.clock.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
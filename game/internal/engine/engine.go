@@ -0,0 +1,88 @@
+package engine
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// State 对局状态：等待开始 -> 发牌 -> 出牌 -> 结算 -> 结束，结束后可以开始下一局
+type State int32
+
+const (
+	StateWaiting State = iota
+	StateDealing
+	StatePlaying
+	StateSettling
+	StateFinished
+)
+
+// 推送给客户端的路由
+const (
+	PushTurn   = "onGameTurn"   // 轮到座位操作
+	PushResult = "onGameResult" // 一局结束的结算结果
+	PushView   = "onGameView"   // 断线重连后的对局状态
+	PushAbort  = "onGameAbort"  // 对局无法继续，本局作废不结算
+)
+
+// ErrClosed 房间已经解散，对局已关闭
+var ErrClosed = errors.New("table closed")
+
+// Action 玩家的操作，type由具体的玩法定义，data为该操作的参数
+type Action struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// Result 一局的结算结果，scores按座位号，seed用于复现本局
+type Result struct {
+	Hand   int      `json:"hand"`
+	Seed   int64    `json:"seed"`
+	Uids   []string `json:"uids"`
+	Scores []int64  `json:"scores"`
+	Detail any      `json:"detail,omitempty"` // 玩法相关的结算明细
+}
+
+// GameLogic 具体玩法的规则，所有方法都在对局的协程中串行调用，不需要加锁
+// 随机操作必须使用Table.Rand，相同的种子和操作序列得到相同的结果
+type GameLogic interface {
+	// Deal 洗牌、发牌，完成后调用Table.Await等待第一个操作
+	Deal(t *Table) error
+	// Action 处理座位的操作，不合法时返回biz.InvalidAction，对局结束时调用Table.End
+	Action(t *Table, seat int, a *Action) error
+	// AutoPlay 超时或托管时替座位选择一个合法的操作
+	AutoPlay(t *Table, seat int) *Action
+	// Settle 对局结束后计算每个座位的输赢
+	Settle(t *Table) *Result
+	// View 座位看到的对局状态，断线重连时发送，需要隐藏其他玩家的手牌
+	View(t *Table, seat int) any
+}
+
+// Notifier 推送消息给玩家
+type Notifier interface {
+	Push(uids []string, route string, data any)
+}
+
+// NotifierFunc 函数形式的Notifier
+type NotifierFunc func(uids []string, route string, data any)
+
+func (f NotifierFunc) Push(uids []string, route string, data any) {
+	f(uids, route, data)
+}
+
+// Options 对局的配置，零值的字段使用默认值
+type Options struct {
+	Clock        Clock
+	Notifier     Notifier
+	TurnTimeout  time.Duration // 操作时间，超时后自动操作并进入托管
+	TrusteeDelay time.Duration // 托管时的自动操作延迟
+	// OnFinish 一局结算完成后在对局协程中调用
+	OnFinish func(t *Table, result *Result)
+	// OnAbort 本局作废后在对局协程中调用
+	OnAbort func(t *Table)
+}
+
+const (
+	defaultTurnTimeout  = 15 * time.Second
+	defaultTrusteeDelay = time.Second
+)
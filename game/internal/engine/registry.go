@@ -0,0 +1,32 @@
+package engine
+
+import (
+	"common/biz"
+	"sync"
+)
+
+// Factory 按房间规则创建玩法，players为房间人数，规则不支持时返回错误
+type Factory func(rule map[string]int, players int) (GameLogic, error)
+
+var (
+	factoryLock sync.RWMutex
+	factories   = make(map[int]Factory)
+)
+
+// Register 注册游戏类型的玩法，各玩法在init中注册
+func Register(gameType int, f Factory) {
+	factoryLock.Lock()
+	defer factoryLock.Unlock()
+	factories[gameType] = f
+}
+
+// NewLogic 创建游戏类型的玩法，没有注册时返回biz.RequestDataError
+func NewLogic(gameType int, rule map[string]int, players int) (GameLogic, error) {
+	factoryLock.RLock()
+	f, ok := factories[gameType]
+	factoryLock.RUnlock()
+	if !ok {
+		return nil, biz.RequestDataError
+	}
+	return f(rule, players)
+}
@@ -0,0 +1,361 @@
+package engine
+
+import (
+	"common/biz"
+	"common/logs"
+	"math/rand"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// 等待执行的任务数，超过后提交任务会阻塞
+const taskQueueSize = 64
+
+// Seat 座位，index从0开始
+type Seat struct {
+	Index    int
+	Uid      string
+	Trustee  bool // 托管中，轮到时延迟TrusteeDelay后自动操作
+	Timeouts int  // 本局的超时次数
+}
+
+// Table 一个房间的对局，房间内的所有操作和计时器回调都放入队列，在同一个协程中串行执行
+// 对局状态只能在队列的任务中读写，外部通过Do提交任务
+type Table struct {
+	RoomId string
+	Rule   map[string]int
+	Seats  []*Seat
+	Hand   int        // 第几局，从1开始
+	Seed   int64      // 本局的随机种子
+	Rand   *rand.Rand // 本局的随机数，玩法中所有的随机操作都要使用它
+
+	logic GameLogic
+	opts  Options
+	state atomic.Int32
+
+	tasks     chan func()
+	closed    chan struct{}
+	closeOnce sync.Once
+
+	turn    int64   // 每次Await加1，用于丢弃过期的计时器回调
+	waiting []bool  // 按座位，等待该座位操作
+	timers  []Timer // 按座位的操作计时器
+	ended   bool    // 玩法调用了End，当前操作处理完后进入结算
+}
+
+// NewTable 创建对局并启动处理协程，房间解散时需要调用Close
+func NewTable(roomId string, rule map[string]int, logic GameLogic, opts Options) *Table {
+	if opts.Clock == nil {
+		opts.Clock = realClock{}
+	}
+	if opts.Notifier == nil {
+		opts.Notifier = NotifierFunc(func([]string, string, any) {})
+	}
+	if opts.TurnTimeout <= 0 {
+		opts.TurnTimeout = defaultTurnTimeout
+	}
+	if opts.TrusteeDelay <= 0 {
+		opts.TrusteeDelay = defaultTrusteeDelay
+	}
+	t := &Table{
+		RoomId: roomId,
+		Rule:   rule,
+		logic:  logic,
+		opts:   opts,
+		tasks:  make(chan func(), taskQueueSize),
+		closed: make(chan struct{}),
+	}
+	go t.run()
+	return t
+}
+
+// State 当前的对局状态，可以在任意协程中调用
+func (t *Table) State() State {
+	return State(t.state.Load())
+}
+
+// Do 提交任务并等待执行完成，对局已关闭时返回ErrClosed
+func (t *Table) Do(fn func() error) error {
+	done := make(chan error, 1)
+	select {
+	case t.tasks <- func() { done <- t.call(fn) }:
+	case <-t.closed:
+		return ErrClosed
+	}
+	select {
+	case err := <-done:
+		return err
+	case <-t.closed:
+		return ErrClosed
+	}
+}
+
+// Close 关闭对局，进行中的对局直接结束，不结算
+func (t *Table) Close() {
+	t.closeOnce.Do(func() {
+		close(t.closed)
+	})
+}
+
+func (t *Table) run() {
+	for {
+		select {
+		case <-t.closed:
+			t.stopTimers()
+			return
+		case task := <-t.tasks:
+			task()
+		}
+	}
+}
+
+// call 玩法中的panic只影响当前任务，不影响节点上的其他房间
+func (t *Table) call(fn func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			logs.Error("room %s table task panic: %v\n%s", t.RoomId, r, debug.Stack())
+			err = biz.Fail
+		}
+	}()
+	return fn()
+}
+
+// Start 按uids的顺序入座并开始新的一局，只能在等待开始或上一局结束后调用
+func (t *Table) Start(seed int64, uids []string) error {
+	return t.Do(func() error {
+		if s := t.State(); s != StateWaiting && s != StateFinished {
+			return biz.RequestDataError
+		}
+		t.Hand++
+		t.Seed = seed
+		t.Rand = rand.New(rand.NewSource(seed))
+		t.Seats = make([]*Seat, len(uids))
+		for i, uid := range uids {
+			t.Seats[i] = &Seat{Index: i, Uid: uid}
+		}
+		t.waiting = make([]bool, len(uids))
+		t.timers = make([]Timer, len(uids))
+		t.ended = false
+		t.setState(StateDealing)
+		if err := t.logic.Deal(t); err != nil {
+			logs.Error("room %s hand %d deal err: %v", t.RoomId, t.Hand, err)
+			t.stopTimers()
+			t.setState(StateWaiting)
+			return err
+		}
+		t.setState(StatePlaying)
+		t.settleIfEnded()
+		return nil
+	})
+}
+
+// Action 玩家的操作，只能在轮到该座位时操作，玩家主动操作后取消托管
+func (t *Table) Action(uid string, a *Action) error {
+	return t.Do(func() error {
+		if t.State() != StatePlaying {
+			return biz.GameNotPlaying
+		}
+		seat := t.SeatOf(uid)
+		if seat < 0 {
+			return biz.NotInRoom
+		}
+		if !t.waiting[seat] {
+			return biz.NotYourTurn
+		}
+		if err := t.logic.Action(t, seat, a); err != nil {
+			return err
+		}
+		t.Seats[seat].Trustee = false
+		t.settleIfEnded()
+		return nil
+	})
+}
+
+// SetTrustee 玩家设置、取消托管，托管时如果正在等待该座位操作，延迟后自动操作
+func (t *Table) SetTrustee(uid string, trustee bool) error {
+	return t.Do(func() error {
+		if t.State() != StatePlaying {
+			return biz.GameNotPlaying
+		}
+		seat := t.SeatOf(uid)
+		if seat < 0 {
+			return biz.NotInRoom
+		}
+		t.Seats[seat].Trustee = trustee
+		if trustee && t.waiting[seat] {
+			t.startTimer(seat, t.opts.TrusteeDelay)
+		}
+		return nil
+	})
+}
+
+// View uid看到的对局状态，没有开始时返回nil
+func (t *Table) View(uid string) (any, error) {
+	var view any
+	err := t.Do(func() error {
+		seat := t.SeatOf(uid)
+		if seat < 0 || t.State() != StatePlaying {
+			return nil
+		}
+		view = t.logic.View(t, seat)
+		return nil
+	})
+	return view, err
+}
+
+// 以下方法只能在玩法的回调中调用
+
+// Await 等待seats操作，timeout为0时使用默认的操作时间，之前的等待全部取消
+func (t *Table) Await(timeout time.Duration, seats ...int) {
+	if timeout <= 0 {
+		timeout = t.opts.TurnTimeout
+	}
+	t.stopTimers()
+	t.turn++
+	for i := range t.waiting {
+		t.waiting[i] = false
+	}
+	for _, seat := range seats {
+		t.waiting[seat] = true
+		if t.Seats[seat].Trustee {
+			t.startTimer(seat, t.opts.TrusteeDelay)
+		} else {
+			t.startTimer(seat, timeout)
+		}
+	}
+	t.Broadcast(PushTurn, map[string]any{
+		"seats":    seats,
+		"deadline": t.opts.Clock.Now().Add(timeout).UnixMilli(),
+	})
+}
+
+// Release 座位已经完成操作，其他座位继续等待
+func (t *Table) Release(seat int) {
+	t.waiting[seat] = false
+	if t.timers[seat] != nil {
+		t.timers[seat].Stop()
+		t.timers[seat] = nil
+	}
+}
+
+// Waiting 是否在等待座位操作
+func (t *Table) Waiting(seat int) bool {
+	return t.waiting[seat]
+}
+
+// End 本局结束，当前回调返回后进入结算
+func (t *Table) End() {
+	t.ended = true
+}
+
+// Broadcast 推送给所有座位
+func (t *Table) Broadcast(route string, data any) {
+	t.opts.Notifier.Push(t.uids(), route, data)
+}
+
+// Send 推送给一个座位
+func (t *Table) Send(seat int, route string, data any) {
+	t.opts.Notifier.Push([]string{t.Seats[seat].Uid}, route, data)
+}
+
+// SeatOf uid所在的座位，不在座位上时返回-1
+func (t *Table) SeatOf(uid string) int {
+	for _, s := range t.Seats {
+		if s.Uid == uid {
+			return s.Index
+		}
+	}
+	return -1
+}
+
+func (t *Table) uids() []string {
+	uids := make([]string, len(t.Seats))
+	for i, s := range t.Seats {
+		uids[i] = s.Uid
+	}
+	return uids
+}
+
+func (t *Table) setState(s State) {
+	t.state.Store(int32(s))
+}
+
+// startTimer 到期后在队列中替座位自动操作，超时的座位进入托管
+func (t *Table) startTimer(seat int, d time.Duration) {
+	if t.timers[seat] != nil {
+		t.timers[seat].Stop()
+	}
+	turn := t.turn
+	t.timers[seat] = t.opts.Clock.AfterFunc(d, func() {
+		_ = t.Do(func() error {
+			t.timeout(turn, seat)
+			return nil
+		})
+	})
+}
+
+func (t *Table) timeout(turn int64, seat int) {
+	if t.turn != turn || t.State() != StatePlaying || !t.waiting[seat] {
+		return
+	}
+	s := t.Seats[seat]
+	if !s.Trustee {
+		s.Timeouts++
+		s.Trustee = true
+	}
+	a := t.logic.AutoPlay(t, seat)
+	if err := t.logic.Action(t, seat, a); err != nil {
+		// 自动操作必须合法，否则没有座位能让对局继续，只能作废本局
+		logs.Error("room %s hand %d seat %d auto play %+v err: %v", t.RoomId, t.Hand, seat, a, err)
+		t.abort()
+		return
+	}
+	t.settleIfEnded()
+}
+
+// abort 本局作废不结算，回到可以开始下一局的状态
+func (t *Table) abort() {
+	t.ended = false
+	t.stopTimers()
+	for i := range t.waiting {
+		t.waiting[i] = false
+	}
+	t.setState(StateFinished)
+	t.Broadcast(PushAbort, map[string]any{"hand": t.Hand})
+	if t.opts.OnAbort != nil {
+		t.opts.OnAbort(t)
+	}
+}
+
+func (t *Table) stopTimers() {
+	for i, timer := range t.timers {
+		if timer != nil {
+			timer.Stop()
+			t.timers[i] = nil
+		}
+	}
+}
+
+// settleIfEnded 玩法调用End后结算，推送结果并回调OnFinish
+func (t *Table) settleIfEnded() {
+	if !t.ended {
+		return
+	}
+	t.ended = false
+	t.stopTimers()
+	for i := range t.waiting {
+		t.waiting[i] = false
+	}
+	t.setState(StateSettling)
+	result := t.logic.Settle(t)
+	result.Hand = t.Hand
+	result.Seed = t.Seed
+	result.Uids = t.uids()
+	t.setState(StateFinished)
+	t.Broadcast(PushResult, result)
+	if t.opts.OnFinish != nil {
+		t.opts.OnFinish(t, result)
+	}
+}
@@ -0,0 +1,311 @@
+package engine
+
+import (
+	"common/biz"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+)
+
+// countLogic 测试用的玩法：从随机座位开始轮流报数，每次加1到3，先报到target的座位获胜
+type countLogic struct {
+	target  int
+	total   int
+	current int
+	winner  int
+	history []int
+	invalid bool // 自动操作不合法
+}
+
+func (l *countLogic) Deal(t *Table) error {
+	l.total, l.winner, l.history = 0, -1, nil
+	l.current = t.Rand.Intn(len(t.Seats))
+	t.Await(0, l.current)
+	return nil
+}
+
+func (l *countLogic) Action(t *Table, seat int, a *Action) error {
+	var n int
+	if a.Type != "add" || json.Unmarshal(a.Data, &n) != nil || n < 1 || n > 3 {
+		return biz.InvalidAction
+	}
+	l.total += n
+	l.history = append(l.history, n)
+	if l.total >= l.target {
+		l.winner = seat
+		t.End()
+		return nil
+	}
+	l.current = (seat + 1) % len(t.Seats)
+	t.Await(0, l.current)
+	return nil
+}
+
+func (l *countLogic) AutoPlay(*Table, int) *Action {
+	if l.invalid {
+		return add(4)
+	}
+	return add(1)
+}
+
+func (l *countLogic) Settle(t *Table) *Result {
+	scores := make([]int64, len(t.Seats))
+	for i := range scores {
+		if i == l.winner {
+			scores[i] = int64(len(t.Seats) - 1)
+		} else {
+			scores[i] = -1
+		}
+	}
+	return &Result{Scores: scores, Detail: l.history}
+}
+
+func (l *countLogic) View(*Table, int) any {
+	return l.total
+}
+
+func add(n int) *Action {
+	data, _ := json.Marshal(n)
+	return &Action{Type: "add", Data: data}
+}
+
+type recorder struct {
+	lock    sync.Mutex
+	routes  []string
+	results []*Result
+}
+
+func (r *recorder) Push(_ []string, route string, _ any) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.routes = append(r.routes, route)
+}
+
+func (r *recorder) count(route string) int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	n := 0
+	for _, v := range r.routes {
+		if v == route {
+			n++
+		}
+	}
+	return n
+}
+
+func newTestTable(t *testing.T, target int) (*Table, *countLogic, *ManualClock, *recorder) {
+	t.Helper()
+	clock := NewManualClock(time.Unix(0, 0))
+	rec := &recorder{}
+	logic := &countLogic{target: target}
+	table := NewTable("100000", nil, logic, Options{
+		Clock:        clock,
+		Notifier:     rec,
+		TurnTimeout:  10 * time.Second,
+		TrusteeDelay: time.Second,
+		OnFinish: func(_ *Table, result *Result) {
+			rec.results = append(rec.results, result)
+		},
+	})
+	t.Cleanup(table.Close)
+	return table, logic, clock, rec
+}
+
+// current 在对局协程中读取当前座位
+func current(t *testing.T, table *Table, logic *countLogic) int {
+	t.Helper()
+	seat := -1
+	if err := table.Do(func() error {
+		seat = logic.current
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return seat
+}
+
+func TestTable_Action(t *testing.T) {
+	table, logic, _, rec := newTestTable(t, 5)
+	uids := []string{"u1", "u2", "u3"}
+	if err := table.Action("u1", add(1)); err != biz.GameNotPlaying {
+		t.Fatalf("action before start err = %v", err)
+	}
+	if err := table.Start(1, uids); err != nil {
+		t.Fatal(err)
+	}
+	if table.State() != StatePlaying {
+		t.Fatalf("state = %d, want playing", table.State())
+	}
+	if err := table.Start(1, uids); err != biz.RequestDataError {
+		t.Fatalf("start twice err = %v", err)
+	}
+	seat := current(t, table, logic)
+	other := uids[(seat+1)%len(uids)]
+
+	tests := []struct {
+		name string
+		uid  string
+		a    *Action
+		err  error
+	}{
+		{"not in room", "u9", add(1), biz.NotInRoom},
+		{"not your turn", other, add(1), biz.NotYourTurn},
+		{"invalid type", uids[seat], &Action{Type: "pass"}, biz.InvalidAction},
+		{"invalid data", uids[seat], add(4), biz.InvalidAction},
+		{"ok", uids[seat], add(3), nil},
+		{"turn passed", uids[seat], add(1), biz.NotYourTurn},
+		{"next", other, add(2), nil},
+		{"finished", uids[(seat+2)%len(uids)], add(1), biz.GameNotPlaying},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := table.Action(tt.uid, tt.a); err != tt.err {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+		})
+	}
+	if table.State() != StateFinished || rec.count(PushResult) != 1 || len(rec.results) != 1 {
+		t.Fatalf("state = %d, results = %d", table.State(), len(rec.results))
+	}
+	result := rec.results[0]
+	if result.Hand != 1 || result.Seed != 1 || result.Scores[(seat+1)%3] != 2 {
+		t.Fatalf("result = %+v", result)
+	}
+	// 结束后可以开始下一局
+	if err := table.Start(2, uids); err != nil || table.State() != StatePlaying {
+		t.Fatalf("restart err = %v, state = %d", err, table.State())
+	}
+}
+
+func TestTable_Timeout(t *testing.T) {
+	table, logic, clock, rec := newTestTable(t, 100)
+	uids := []string{"u1", "u2"}
+	if err := table.Start(7, uids); err != nil {
+		t.Fatal(err)
+	}
+	seat := current(t, table, logic)
+	clock.Advance(9 * time.Second)
+	if current(t, table, logic) != seat {
+		t.Fatal("auto play before timeout")
+	}
+	// 超时后自动操作并进入托管
+	clock.Advance(time.Second)
+	next := current(t, table, logic)
+	if next == seat {
+		t.Fatal("no auto play after timeout")
+	}
+	err := table.Do(func() error {
+		if s := table.Seats[seat]; !s.Trustee || s.Timeouts != 1 {
+			t.Errorf("seat = %+v, want trustee", s)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 另一个座位及时操作，托管的座位在托管延迟后自动操作
+	if err = table.Action(uids[next], add(2)); err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(time.Second)
+	if current(t, table, logic) != next {
+		t.Fatal("trustee seat not auto played")
+	}
+	// 玩家主动操作后取消托管
+	clock.Advance(10 * time.Second)
+	if current(t, table, logic) != seat {
+		t.Fatal("next seat not auto played")
+	}
+	if err = table.Action(uids[seat], add(1)); err != nil {
+		t.Fatal(err)
+	}
+	err = table.Do(func() error {
+		if table.Seats[seat].Trustee || !table.Seats[next].Trustee {
+			t.Errorf("trustee = %v, %v", table.Seats[seat].Trustee, table.Seats[next].Trustee)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = table.SetTrustee(uids[next], false); err != nil {
+		t.Fatal(err)
+	}
+	if rec.count(PushTurn) != 6 {
+		t.Fatalf("turn pushes = %d, want 6", rec.count(PushTurn))
+	}
+}
+
+// 自动操作不合法时本局作废，可以开始下一局
+func TestTable_AbortOnInvalidAutoPlay(t *testing.T) {
+	table, logic, clock, rec := newTestTable(t, 10)
+	logic.invalid = true
+	if err := table.Start(1, []string{"u1", "u2"}); err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(10 * time.Second)
+	if table.State() != StateFinished || rec.count(PushAbort) != 1 || len(rec.results) != 0 {
+		t.Fatalf("state = %d, aborts = %d, results = %d", table.State(), rec.count(PushAbort), len(rec.results))
+	}
+	if err := table.Action("u1", add(1)); err != biz.GameNotPlaying {
+		t.Fatalf("action after abort err = %v", err)
+	}
+	if err := table.Start(2, []string{"u1", "u2"}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTable_Deterministic(t *testing.T) {
+	uids := []string{"u1", "u2", "u3", "u4"}
+	play := func(seed int64) []int64 {
+		table, _, clock, rec := newTestTable(t, 20)
+		if err := table.Start(seed, uids); err != nil {
+			t.Fatal(err)
+		}
+		// 全部超时自动操作，直到对局结束
+		for i := 0; i < 30 && table.State() == StatePlaying; i++ {
+			clock.Advance(10 * time.Second)
+		}
+		if len(rec.results) != 1 {
+			t.Fatalf("seed %d not finished", seed)
+		}
+		return rec.results[0].Scores
+	}
+	for _, seed := range []int64{1, 2, 42} {
+		a, b := play(seed), play(seed)
+		for i := range a {
+			if a[i] != b[i] {
+				t.Fatalf("seed %d scores %v != %v", seed, a, b)
+			}
+		}
+	}
+}
+
+func TestTable_Close(t *testing.T) {
+	table, _, clock, _ := newTestTable(t, 10)
+	if err := table.Start(1, []string{"u1", "u2"}); err != nil {
+		t.Fatal(err)
+	}
+	table.Close()
+	table.Close()
+	if err := table.Action("u1", add(1)); err != ErrClosed {
+		t.Fatalf("action after close err = %v", err)
+	}
+	// 关闭后计时器的回调直接返回
+	clock.Advance(time.Minute)
+	if _, err := table.View("u1"); err != ErrClosed {
+		t.Fatalf("view after close err = %v", err)
+	}
+}
+
+func TestTable_Panic(t *testing.T) {
+	table, _, _, _ := newTestTable(t, 10)
+	if err := table.Do(func() error { panic("boom") }); err != biz.Fail {
+		t.Fatalf("panic task err = %v", err)
+	}
+	// panic后对局协程继续处理任务
+	if err := table.Start(1, []string{"u1", "u2"}); err != nil {
+		t.Fatal(err)
+	}
+}
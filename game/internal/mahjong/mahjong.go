@@ -0,0 +1,503 @@
+package mahjong
+
+import (
+	"common/biz"
+	"encoding/json"
+	"game/internal/engine"
+)
+
+// GameType 麻将在配置中的游戏类型
+const GameType = 2
+
+const (
+	minPlayers = 2
+	maxPlayers = 4
+	handSize   = 13
+)
+
+// 操作类型
+const (
+	ActionDiscard = "discard" // 出牌，data: {"tile": 0}
+	ActionHu      = "hu"      // 胡，自摸或者胡别人打出的牌
+	ActionGang    = "gang"    // 杠，自己的回合暗杠、加杠时data: {"tile": 0}，别人出牌后明杠不需要data
+	ActionPeng    = "peng"    // 碰
+	ActionChi     = "chi"     // 吃，data: {"tiles": [手牌中的两张]}
+	ActionPass    = "pass"    // 别人出牌后放弃吃、碰、杠、胡
+)
+
+// 推送的路由
+const (
+	PushDeal    = "onMjDeal"    // 发牌，只发给自己的手牌
+	PushDraw    = "onMjDraw"    // 摸牌，只发给自己
+	PushDiscard = "onMjDiscard" // 出牌
+	PushOptions = "onMjOptions" // 别人出牌后可以进行的操作
+	PushMeld    = "onMjMeld"    // 吃、碰、杠
+)
+
+type stage int
+
+const (
+	stagePlay    stage = iota // 当前座位摸牌后出牌、暗杠、自摸
+	stageRespond              // 等待其他座位对打出的牌吃、碰、杠、胡
+)
+
+// 别人出牌后的操作优先级，相同时离出牌的座位近的优先
+var priority = map[string]int{ActionChi: 1, ActionPeng: 2, ActionGang: 2, ActionHu: 3}
+
+type player struct {
+	hand     []Tile
+	melds    []Meld
+	discards []Tile
+}
+
+type response struct {
+	kind  string
+	tiles []Tile // 吃时使用的两张手牌
+}
+
+// Game 一局麻将，庄家按局数轮流
+type Game struct {
+	v Variant
+	n int
+
+	wall      []Tile
+	players   []*player
+	dealer    int
+	current   int
+	stage     stage
+	drawn     Tile // 当前座位刚摸的牌，吃、碰后出牌时为-1
+	afterGang bool
+
+	discard   Tile
+	discarder int
+	options   [][]string // 按座位，对打出的牌可以进行的操作
+	responses []*response
+
+	winner   int // 流局时为-1
+	loser    int // 点炮的座位，自摸时为-1
+	selfDraw bool
+	fan      int
+	fans     []Fan
+}
+
+// New 按房间规则创建麻将，支持2-4人
+func New(rule map[string]int, n int) (engine.GameLogic, error) {
+	if n < minPlayers || n > maxPlayers {
+		return nil, biz.RequestDataError
+	}
+	v, err := variantOf(rule)
+	if err != nil {
+		return nil, err
+	}
+	return &Game{v: v, n: n}, nil
+}
+
+type tileData struct {
+	Tile  Tile   `json:"tile"`
+	Tiles []Tile `json:"tiles"`
+}
+
+// Deal 洗牌后每人13张，庄家摸第14张
+func (g *Game) Deal(t *engine.Table) error {
+	g.wall = g.v.wall()
+	t.Rand.Shuffle(len(g.wall), func(i, j int) { g.wall[i], g.wall[j] = g.wall[j], g.wall[i] })
+	g.players = make([]*player, g.n)
+	for i := range g.players {
+		hand := append([]Tile(nil), g.wall[:handSize]...)
+		g.wall = g.wall[handSize:]
+		sortTiles(hand)
+		g.players[i] = &player{hand: hand}
+	}
+	g.dealer = (t.Hand - 1) % g.n
+	g.winner, g.loser, g.selfDraw, g.fan, g.fans = -1, -1, false, 0, nil
+	g.afterGang = false
+	for i, p := range g.players {
+		t.Send(i, PushDeal, map[string]any{"hand": p.hand, "dealer": g.dealer})
+	}
+	g.draw(t, g.dealer, false)
+	return nil
+}
+
+func (g *Game) Action(t *engine.Table, seat int, a *engine.Action) error {
+	var data tileData
+	if len(a.Data) > 0 && json.Unmarshal(a.Data, &data) != nil {
+		return biz.InvalidAction
+	}
+	if g.stage == stageRespond {
+		return g.respond(t, seat, a.Type, data.Tiles)
+	}
+	switch a.Type {
+	case ActionDiscard:
+		return g.doDiscard(t, seat, data.Tile)
+	case ActionHu:
+		return g.selfWin(t, seat)
+	case ActionGang:
+		return g.selfGang(t, seat, data.Tile)
+	}
+	return biz.InvalidAction
+}
+
+// draw 摸牌，杠后从牌墙末尾补牌，牌墙摸完时流局
+func (g *Game) draw(t *engine.Table, seat int, afterGang bool) {
+	if len(g.wall) == 0 {
+		g.winner = -1
+		t.End()
+		return
+	}
+	var tile Tile
+	if afterGang {
+		tile, g.wall = g.wall[len(g.wall)-1], g.wall[:len(g.wall)-1]
+	} else {
+		tile, g.wall = g.wall[0], g.wall[1:]
+	}
+	p := g.players[seat]
+	p.hand = append(p.hand, tile)
+	sortTiles(p.hand)
+	g.stage, g.current, g.drawn, g.afterGang = stagePlay, seat, tile, afterGang
+	t.Send(seat, PushDraw, map[string]any{"tile": tile, "wall": len(g.wall)})
+	t.Await(0, seat)
+}
+
+// doDiscard 出牌后其他座位可以吃、碰、杠、胡时等待他们选择，否则下家摸牌
+func (g *Game) doDiscard(t *engine.Table, seat int, tile Tile) error {
+	p := g.players[seat]
+	hand, ok := take(p.hand, tile, 1)
+	if !ok {
+		return biz.InvalidAction
+	}
+	p.hand = hand
+	p.discards = append(p.discards, tile)
+	g.drawn, g.afterGang = -1, false
+	g.discard, g.discarder = tile, seat
+	t.Broadcast(PushDiscard, map[string]any{"seat": seat, "tile": tile})
+
+	g.options = make([][]string, g.n)
+	g.responses = make([]*response, g.n)
+	var seats []int
+	for i := range g.players {
+		if i == seat {
+			continue
+		}
+		if g.options[i] = g.claims(i, tile); len(g.options[i]) > 0 {
+			seats = append(seats, i)
+			t.Send(i, PushOptions, map[string]any{"tile": tile, "options": g.options[i]})
+		}
+	}
+	if len(seats) == 0 {
+		g.draw(t, g.next(seat), false)
+		return nil
+	}
+	g.stage = stageRespond
+	t.Await(0, seats...)
+	return nil
+}
+
+// claims 座位对打出的牌可以进行的操作
+func (g *Game) claims(seat int, tile Tile) []string {
+	p := g.players[seat]
+	var options []string
+	if !g.v.SelfDrawOnly && g.v.CanWin(append(append([]Tile(nil), p.hand...), tile), p.melds) {
+		options = append(options, ActionHu)
+	}
+	n := countOf(p.hand)[tile]
+	if n >= 2 {
+		options = append(options, ActionPeng)
+	}
+	if n == 3 && len(g.wall) > 0 {
+		options = append(options, ActionGang)
+	}
+	if g.v.Chi && seat == g.next(g.discarder) && len(chiOptions(p.hand, tile)) > 0 {
+		options = append(options, ActionChi)
+	}
+	return options
+}
+
+// selfWin 自摸，只能在摸牌后
+func (g *Game) selfWin(t *engine.Table, seat int) error {
+	p := g.players[seat]
+	if g.drawn < 0 || !g.v.CanWin(p.hand, p.melds) {
+		return biz.InvalidAction
+	}
+	g.win(t, seat, -1)
+	return nil
+}
+
+// selfGang 暗杠手中的4张，或者把碰过的牌加杠，杠后补牌
+func (g *Game) selfGang(t *engine.Table, seat int, tile Tile) error {
+	p := g.players[seat]
+	if !tile.Valid() || len(g.wall) == 0 {
+		return biz.InvalidAction
+	}
+	var meld *Meld
+	if hand, ok := take(p.hand, tile, 4); ok {
+		p.hand = hand
+		p.melds = append(p.melds, Meld{Kind: MeldConcealedGang, Tiles: []Tile{tile, tile, tile, tile}, From: seat})
+		meld = &p.melds[len(p.melds)-1]
+	} else {
+		for i := range p.melds {
+			m := &p.melds[i]
+			if m.Kind != MeldPeng || m.Tiles[0] != tile {
+				continue
+			}
+			if hand, ok = take(p.hand, tile, 1); ok {
+				p.hand = hand
+				m.Kind, m.Tiles = MeldGang, append(m.Tiles, tile)
+				meld = m
+			}
+			break
+		}
+	}
+	if meld == nil {
+		return biz.InvalidAction
+	}
+	// 暗杠不公开牌面
+	shown := *meld
+	if shown.Kind == MeldConcealedGang {
+		shown.Tiles = nil
+	}
+	t.Broadcast(PushMeld, map[string]any{"seat": seat, "meld": shown})
+	g.draw(t, seat, true)
+	return nil
+}
+
+// respond 别人出牌后的选择，所有可以操作的座位都选择后按优先级处理
+func (g *Game) respond(t *engine.Table, seat int, kind string, tiles []Tile) error {
+	if kind != ActionPass && !contains(g.options[seat], kind) {
+		return biz.InvalidAction
+	}
+	r := &response{kind: kind}
+	if kind == ActionChi {
+		if !validChi(g.players[seat].hand, g.discard, tiles) {
+			return biz.InvalidAction
+		}
+		r.tiles = tiles
+	}
+	g.responses[seat] = r
+	t.Release(seat)
+	for i := range g.players {
+		if len(g.options[i]) > 0 && g.responses[i] == nil {
+			return nil
+		}
+	}
+	g.resolve(t)
+	return nil
+}
+
+func (g *Game) resolve(t *engine.Table) {
+	best := -1
+	for i := 1; i < g.n; i++ {
+		seat := (g.discarder + i) % g.n
+		r := g.responses[seat]
+		if r == nil || r.kind == ActionPass {
+			continue
+		}
+		if best < 0 || priority[r.kind] > priority[g.responses[best].kind] {
+			best = seat
+		}
+	}
+	if best < 0 {
+		g.draw(t, g.next(g.discarder), false)
+		return
+	}
+	r := g.responses[best]
+	tile := g.discard
+	p := g.players[best]
+	if r.kind == ActionHu {
+		p.hand = append(p.hand, tile)
+		sortTiles(p.hand)
+		g.win(t, best, g.discarder)
+		return
+	}
+	// 被吃、碰、杠的牌从出牌的座位的牌河中移除
+	from := g.players[g.discarder]
+	from.discards = from.discards[:len(from.discards)-1]
+	var meld Meld
+	switch r.kind {
+	case ActionPeng:
+		p.hand, _ = take(p.hand, tile, 2)
+		meld = Meld{Kind: MeldPeng, Tiles: []Tile{tile, tile, tile}, From: g.discarder}
+	case ActionGang:
+		p.hand, _ = take(p.hand, tile, 3)
+		meld = Meld{Kind: MeldGang, Tiles: []Tile{tile, tile, tile, tile}, From: g.discarder}
+	case ActionChi:
+		p.hand, _ = take(p.hand, r.tiles[0], 1)
+		p.hand, _ = take(p.hand, r.tiles[1], 1)
+		tiles := []Tile{r.tiles[0], r.tiles[1], tile}
+		sortTiles(tiles)
+		meld = Meld{Kind: MeldChi, Tiles: tiles, From: g.discarder}
+	}
+	p.melds = append(p.melds, meld)
+	t.Broadcast(PushMeld, map[string]any{"seat": best, "meld": meld})
+	if meld.Kind == MeldGang {
+		g.draw(t, best, true)
+		return
+	}
+	g.stage, g.current, g.drawn = stagePlay, best, -1
+	t.Await(0, best)
+}
+
+// win 胡牌后计算番数并结束，loser为-1时是自摸
+func (g *Game) win(t *engine.Table, seat, loser int) {
+	p := g.players[seat]
+	g.winner, g.loser, g.selfDraw = seat, loser, loser < 0
+	g.fan, g.fans = g.v.Fans(Win{Hand: p.hand, Melds: p.melds, SelfDraw: g.selfDraw, AfterGang: g.selfDraw && g.afterGang})
+	t.End()
+}
+
+func (g *Game) next(seat int) int {
+	return (seat + 1) % g.n
+}
+
+// AutoPlay 能自摸时胡，否则打出刚摸的牌，吃、碰后打出最后一张；别人出牌后放弃
+func (g *Game) AutoPlay(_ *engine.Table, seat int) *engine.Action {
+	if g.stage == stageRespond {
+		return &engine.Action{Type: ActionPass}
+	}
+	p := g.players[seat]
+	if g.drawn >= 0 && g.v.CanWin(p.hand, p.melds) {
+		return &engine.Action{Type: ActionHu}
+	}
+	tile := g.drawn
+	if tile < 0 {
+		tile = p.hand[len(p.hand)-1]
+	}
+	data, _ := json.Marshal(tileData{Tile: tile})
+	return &engine.Action{Type: ActionDiscard, Data: data}
+}
+
+// Settle 自摸时其他座位各输番数分，点炮时点炮的座位输番数分，流局不计分
+func (g *Game) Settle(*engine.Table) *engine.Result {
+	scores := make([]int64, g.n)
+	if g.winner >= 0 {
+		fan := int64(g.fan)
+		for i := range scores {
+			switch {
+			case i == g.winner:
+			case g.selfDraw:
+				scores[i] = -fan
+				scores[g.winner] += fan
+			case i == g.loser:
+				scores[i] = -fan
+				scores[g.winner] += fan
+			}
+		}
+	}
+	hands := make([][]Tile, g.n)
+	melds := make([][]Meld, g.n)
+	for i, p := range g.players {
+		hands[i], melds[i] = p.hand, p.melds
+	}
+	return &engine.Result{
+		Scores: scores,
+		Detail: map[string]any{
+			"winner":   g.winner,
+			"loser":    g.loser,
+			"selfDraw": g.selfDraw,
+			"fan":      g.fan,
+			"fans":     g.fans,
+			"dealer":   g.dealer,
+			"hands":    hands,
+			"melds":    melds,
+		},
+	}
+}
+
+// View 自己的手牌、所有人的副露和牌河，暗杠不公开牌面
+func (g *Game) View(_ *engine.Table, seat int) any {
+	counts := make([]int, g.n)
+	melds := make([][]Meld, g.n)
+	discards := make([][]Tile, g.n)
+	for i, p := range g.players {
+		counts[i] = len(p.hand)
+		discards[i] = p.discards
+		for _, m := range p.melds {
+			if m.Kind == MeldConcealedGang && i != seat {
+				m.Tiles = nil
+			}
+			melds[i] = append(melds[i], m)
+		}
+	}
+	view := map[string]any{
+		"stage":    g.stage,
+		"hand":     g.players[seat].hand,
+		"counts":   counts,
+		"melds":    melds,
+		"discards": discards,
+		"dealer":   g.dealer,
+		"current":  g.current,
+		"wall":     len(g.wall),
+	}
+	if g.stage == stageRespond {
+		view["discard"] = g.discard
+		view["discarder"] = g.discarder
+		if g.responses[seat] == nil {
+			view["options"] = g.options[seat]
+		}
+	}
+	return view
+}
+
+// take 从手牌中移除n张tile，不够时返回false，手牌不变
+func take(hand []Tile, tile Tile, n int) ([]Tile, bool) {
+	if !tile.Valid() || countOf(hand)[tile] < n {
+		return hand, false
+	}
+	rest := make([]Tile, 0, len(hand)-n)
+	for _, v := range hand {
+		if v == tile && n > 0 {
+			n--
+			continue
+		}
+		rest = append(rest, v)
+	}
+	return rest, true
+}
+
+// chiOptions 手牌中可以和tile组成顺子的两张牌
+func chiOptions(hand []Tile, tile Tile) [][2]Tile {
+	if tile.Honor() {
+		return nil
+	}
+	c := countOf(hand)
+	pos := int(tile) % suitSize
+	var list [][2]Tile
+	for start := pos - 2; start <= pos; start++ {
+		if start < 0 || start+2 >= suitSize {
+			continue
+		}
+		base := tile - Tile(pos) + Tile(start)
+		var pair [2]Tile
+		k := 0
+		for i := Tile(0); i < 3; i++ {
+			if base+i != tile {
+				pair[k] = base + i
+				k++
+			}
+		}
+		if c[pair[0]] > 0 && c[pair[1]] > 0 {
+			list = append(list, pair)
+		}
+	}
+	return list
+}
+
+func validChi(hand []Tile, tile Tile, tiles []Tile) bool {
+	if len(tiles) != 2 {
+		return false
+	}
+	for _, pair := range chiOptions(hand, tile) {
+		if (pair[0] == tiles[0] && pair[1] == tiles[1]) || (pair[0] == tiles[1] && pair[1] == tiles[0]) {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(list []string, v string) bool {
+	for _, s := range list {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
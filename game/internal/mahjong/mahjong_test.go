@@ -0,0 +1,264 @@
+package mahjong
+
+import (
+	"common/biz"
+	"encoding/json"
+	"game/internal/engine"
+	"testing"
+	"time"
+)
+
+var testUids = []string{"u0", "u1", "u2"}
+
+func newTestTable(t *testing.T, region int) (*engine.Table, *Game, *engine.ManualClock, *[]*engine.Result) {
+	t.Helper()
+	logic, err := New(map[string]int{optionRegion: region}, len(testUids))
+	if err != nil {
+		t.Fatal(err)
+	}
+	clock := engine.NewManualClock(time.Unix(0, 0))
+	var results []*engine.Result
+	table := engine.NewTable("100000", nil, logic, engine.Options{
+		Clock: clock,
+		OnFinish: func(_ *engine.Table, result *engine.Result) {
+			results = append(results, result)
+		},
+	})
+	t.Cleanup(table.Close)
+	return table, logic.(*Game), clock, &results
+}
+
+// setup 开局后设置确定的手牌和牌墙，座位0刚摸了drawn
+func setup(t *testing.T, table *engine.Table, g *Game, drawn string, hands []string, wall string) {
+	t.Helper()
+	if err := table.Start(1, testUids); err != nil {
+		t.Fatal(err)
+	}
+	_ = table.Do(func() error {
+		for i, p := range g.players {
+			p.hand, p.melds, p.discards = tiles(hands[i]), nil, nil
+			sortTiles(p.hand)
+		}
+		g.wall = tiles(wall)
+		g.stage, g.current, g.drawn = stagePlay, 0, tiles(drawn)[0]
+		table.Await(0, 0)
+		return nil
+	})
+}
+
+func act(kind string, data any) *engine.Action {
+	a := &engine.Action{Type: kind}
+	if data != nil {
+		a.Data, _ = json.Marshal(data)
+	}
+	return a
+}
+
+func discard(s string) *engine.Action {
+	return act(ActionDiscard, tileData{Tile: tiles(s)[0]})
+}
+
+type step struct {
+	name string
+	uid  string
+	a    *engine.Action
+	err  error
+}
+
+func runSteps(t *testing.T, table *engine.Table, steps []step) {
+	t.Helper()
+	for _, s := range steps {
+		if err := table.Action(s.uid, s.a); err != s.err {
+			t.Fatalf("%s: err = %v, want %v", s.name, err, s.err)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name string
+		rule map[string]int
+		n    int
+		err  error
+	}{
+		{"two players", nil, 2, nil},
+		{"four players", map[string]int{optionRegion: RegionSichuan}, 4, nil},
+		{"one player", nil, 1, biz.RequestDataError},
+		{"five players", nil, 5, biz.RequestDataError},
+		{"unknown region", map[string]int{optionRegion: 9}, 4, biz.RequestDataError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New(tt.rule, tt.n); err != tt.err {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestTake(t *testing.T) {
+	hand := tiles("1123m")
+	if got, ok := take(hand, tiles("1m")[0], 2); !ok || len(got) != 2 {
+		t.Fatalf("take 2 = %v, %v", got, ok)
+	}
+	if got, ok := take(hand, tiles("2m")[0], 2); ok || len(got) != len(hand) {
+		t.Fatalf("take missing = %v, %v", got, ok)
+	}
+	if len(hand) != 4 || hand[0] != tiles("1m")[0] {
+		t.Fatalf("hand changed = %v", hand)
+	}
+}
+
+// 座位1可以吃、座位2可以碰，碰优先
+func TestGame_Claim(t *testing.T) {
+	table, g, _, _ := newTestTable(t, RegionGeneral)
+	setup(t, table, g, "5m", []string{"5m123s456p789p11z99s", "46m147s258p369p12z", "55m147s258p369p34z"}, "1m2m3m")
+	runSteps(t, table, []step{
+		{"not in hand", "u0", discard("9m"), biz.InvalidAction},
+		{"not your turn", "u1", discard("4m"), biz.NotYourTurn},
+		{"self win", "u0", act(ActionHu, nil), biz.InvalidAction},
+		{"gang without four", "u0", act(ActionGang, tileData{Tile: tiles("5m")[0]}), biz.InvalidAction},
+		{"discard", "u0", discard("5m"), nil},
+		{"peng without pair", "u1", act(ActionPeng, nil), biz.InvalidAction},
+		{"invalid chi", "u1", act(ActionChi, tileData{Tiles: tiles("4m7s")}), biz.InvalidAction},
+		{"chi", "u1", act(ActionChi, tileData{Tiles: tiles("46m")}), nil},
+		{"already responded", "u1", act(ActionPass, nil), biz.NotYourTurn},
+		{"peng", "u2", act(ActionPeng, nil), nil},
+		{"hu after peng", "u2", act(ActionHu, nil), biz.InvalidAction},
+		{"discard after peng", "u2", discard("3z"), nil},
+	})
+	var p0, p1, p2 player
+	var current int
+	var drawn Tile
+	var wall []Tile
+	_ = table.Do(func() error {
+		p0, p1, p2 = *g.players[0], *g.players[1], *g.players[2]
+		current, drawn, wall = g.current, g.drawn, g.wall
+		return nil
+	})
+	if len(p2.melds) != 1 || p2.melds[0].Kind != MeldPeng || p2.melds[0].From != 0 || len(p2.hand) != 10 {
+		t.Fatalf("seat 2 = %+v", p2)
+	}
+	if len(p1.melds) != 0 || len(p1.hand) != 13 {
+		t.Fatalf("seat 1 = %+v", p1)
+	}
+	// 被碰的牌从牌河移除，没人要3z时下家座位0摸牌
+	if len(p0.discards) != 0 || current != 0 || drawn != tiles("1m")[0] || len(wall) != 2 {
+		t.Fatalf("current = %d, drawn = %d, wall = %v", current, drawn, wall)
+	}
+}
+
+// 座位1点炮胡优先于座位2碰，广东麻将只能自摸，座位2直接碰
+func TestGame_DiscardWin(t *testing.T) {
+	hands := []string{"5m123s456p789p11z99s", "123m456s789p11z46m", "55m147s258p369p34z"}
+	tests := []struct {
+		name   string
+		region int
+		scores []int64
+	}{
+		{"general", RegionGeneral, []int64{-1, 1, 0}},
+		{"guangdong", RegionGuangdong, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, g, _, results := newTestTable(t, tt.region)
+			setup(t, table, g, "5m", hands, "1m2m3m")
+			if err := table.Action("u0", discard("5m")); err != nil {
+				t.Fatal(err)
+			}
+			if err := table.Action("u2", act(ActionPeng, nil)); err != nil {
+				t.Fatal(err)
+			}
+			err := table.Action("u1", act(ActionHu, nil))
+			if tt.scores == nil {
+				if err != biz.NotYourTurn || len(*results) != 0 {
+					t.Fatalf("err = %v, results = %d", err, len(*results))
+				}
+				return
+			}
+			if err != nil || len(*results) != 1 {
+				t.Fatalf("err = %v, results = %d", err, len(*results))
+			}
+			result := (*results)[0]
+			detail := result.Detail.(map[string]any)
+			if detail["winner"] != 1 || detail["loser"] != 0 || detail["selfDraw"] != false {
+				t.Fatalf("detail = %+v", detail)
+			}
+			for i, s := range tt.scores {
+				if result.Scores[i] != s {
+					t.Fatalf("scores = %v, want %v", result.Scores, tt.scores)
+				}
+			}
+		})
+	}
+}
+
+// 暗杠后从牌墙末尾补牌自摸，杠上开花
+func TestGame_GangWin(t *testing.T) {
+	table, g, _, results := newTestTable(t, RegionGeneral)
+	setup(t, table, g, "1m", []string{"1111m234s567p99p55z", "123m456s789p11z46m", "55m147s258p369p34z"}, "9m9m5z")
+	if err := table.Action("u0", act(ActionGang, tileData{Tile: tiles("1m")[0]})); err != nil {
+		t.Fatal(err)
+	}
+	// 其他座位看不到暗杠的牌
+	view, err := table.View("u1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if melds := view.(map[string]any)["melds"].([][]Meld); melds[0][0].Tiles != nil {
+		t.Fatalf("concealed gang = %+v", melds[0][0])
+	}
+	if err := table.Action("u0", act(ActionHu, nil)); err != nil {
+		t.Fatal(err)
+	}
+	if len(*results) != 1 {
+		t.Fatalf("results = %d", len(*results))
+	}
+	// 平胡、自摸、杠上开花、杠各1番
+	result := (*results)[0]
+	if result.Scores[0] != 8 || result.Scores[1] != -4 || result.Scores[2] != -4 {
+		t.Fatalf("scores = %v", result.Scores)
+	}
+	if fan := result.Detail.(map[string]any)["fan"]; fan != 4 {
+		t.Fatalf("fan = %v", fan)
+	}
+}
+
+// 全部托管时只会摸切和自摸，相同的种子得到相同的结果
+func TestGame_AutoPlay(t *testing.T) {
+	run := func(seed int64, region int) *engine.Result {
+		table, _, clock, results := newTestTable(t, region)
+		if err := table.Start(seed, testUids); err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 1000 && len(*results) == 0; i++ {
+			clock.Advance(15 * time.Second)
+		}
+		if len(*results) != 1 {
+			t.Fatalf("seed %d not finished", seed)
+		}
+		return (*results)[0]
+	}
+	for _, region := range []int{RegionGeneral, RegionSichuan, RegionGuangdong} {
+		for _, seed := range []int64{1, 7, 2024} {
+			result := run(seed, region)
+			var sum int64
+			for _, s := range result.Scores {
+				sum += s
+			}
+			if sum != 0 {
+				t.Fatalf("region %d seed %d scores = %v", region, seed, result.Scores)
+			}
+			again := run(seed, region)
+			detail, detail2 := result.Detail.(map[string]any), again.Detail.(map[string]any)
+			if detail["winner"] != detail2["winner"] || detail["fan"] != detail2["fan"] {
+				t.Fatalf("region %d seed %d not deterministic", region, seed)
+			}
+			for i := range result.Scores {
+				if result.Scores[i] != again.Scores[i] {
+					t.Fatalf("region %d seed %d scores = %v, %v", region, seed, result.Scores, again.Scores)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package mahjong
+
+import "common/biz"
+
+// 创建房间时的规则选项
+const (
+	optionRegion = "region" // 地区玩法
+	optionMaxFan = "maxfan" // 番数封顶，0为不封顶
+)
+
+// 地区玩法
+const (
+	RegionGeneral   = 0 // 通用：136张，可以吃，可以七对，可以点炮胡
+	RegionSichuan   = 1 // 四川：108张没有字牌，不能吃，缺一门才能胡
+	RegionGuangdong = 2 // 广东：136张，不能吃，只能自摸
+)
+
+// Variant 地区玩法对应的规则
+type Variant struct {
+	Honors       bool // 带字牌
+	Chi          bool // 可以吃上家的牌
+	SevenPairs   bool // 可以胡七对
+	SelfDrawOnly bool // 只能自摸，不能点炮胡
+	MissingSuit  bool // 胡牌时手牌和副露最多两种花色
+	MaxFan       int
+}
+
+func variantOf(rule map[string]int) (Variant, error) {
+	var v Variant
+	switch rule[optionRegion] {
+	case RegionGeneral:
+		v = Variant{Honors: true, Chi: true, SevenPairs: true}
+	case RegionSichuan:
+		v = Variant{SevenPairs: true, MissingSuit: true}
+	case RegionGuangdong:
+		v = Variant{Honors: true, SevenPairs: true, SelfDrawOnly: true}
+	default:
+		return Variant{}, biz.RequestDataError
+	}
+	v.MaxFan = rule[optionMaxFan]
+	if v.MaxFan < 0 {
+		return Variant{}, biz.RequestDataError
+	}
+	return v, nil
+}
+
+// wall 洗牌前的牌墙
+func (v Variant) wall() []Tile {
+	kinds := honorStart
+	if v.Honors {
+		kinds = tileKinds
+	}
+	wall := make([]Tile, 0, kinds*copies)
+	for t := 0; t < kinds; t++ {
+		for i := 0; i < copies; i++ {
+			wall = append(wall, Tile(t))
+		}
+	}
+	return wall
+}
+
+// CanWin hand为手牌加上胡的那张牌，副露已经从手牌中移除
+func (v Variant) CanWin(hand []Tile, melds []Meld) bool {
+	if v.MissingSuit && len(suits(hand, melds)) > 2 {
+		return false
+	}
+	c := countOf(hand)
+	if standard(c) {
+		return true
+	}
+	return v.SevenPairs && len(melds) == 0 && len(hand) == 14 && sevenPairs(c)
+}
+
+// Fan 番种
+type Fan struct {
+	Name string `json:"name"`
+	Fan  int    `json:"fan"`
+}
+
+// Win 胡牌时的牌型
+type Win struct {
+	Hand      []Tile // 包括胡的那张牌
+	Melds     []Meld
+	SelfDraw  bool
+	AfterGang bool // 杠后摸牌胡
+}
+
+// Fans 计算番数，返回封顶后的总番数和番种明细
+// 平胡1番，七对、碰碰胡2番，清一色3番，混一色1番，字一色4番，自摸、杠上开花、每个杠各1番
+func (v Variant) Fans(w Win) (int, []Fan) {
+	fans := []Fan{{"平胡", 1}}
+	c := countOf(w.Hand)
+	chi := false
+	gangs := 0
+	for _, m := range w.Melds {
+		chi = chi || m.Kind == MeldChi
+		if m.gang() {
+			gangs++
+		}
+	}
+	switch {
+	case v.SevenPairs && len(w.Melds) == 0 && sevenPairs(c):
+		fans = append(fans, Fan{"七对", 2})
+	case !chi && allTriplets(c):
+		fans = append(fans, Fan{"碰碰胡", 2})
+	}
+	set := suits(w.Hand, w.Melds)
+	honors := false
+	for _, t := range w.Hand {
+		honors = honors || t.Honor()
+	}
+	for _, m := range w.Melds {
+		honors = honors || m.Tiles[0].Honor()
+	}
+	switch {
+	case len(set) == 0:
+		fans = append(fans, Fan{"字一色", 4})
+	case len(set) == 1 && !honors:
+		fans = append(fans, Fan{"清一色", 3})
+	case len(set) == 1:
+		fans = append(fans, Fan{"混一色", 1})
+	}
+	if w.SelfDraw {
+		fans = append(fans, Fan{"自摸", 1})
+	}
+	if w.AfterGang {
+		fans = append(fans, Fan{"杠上开花", 1})
+	}
+	if gangs > 0 {
+		fans = append(fans, Fan{"杠", gangs})
+	}
+	total := 0
+	for _, f := range fans {
+		total += f.Fan
+	}
+	if v.MaxFan > 0 && total > v.MaxFan {
+		total = v.MaxFan
+	}
+	return total, fans
+}
+
+// suits 手牌和副露中的花色，不包括字牌
+func suits(hand []Tile, melds []Meld) map[int]bool {
+	set := make(map[int]bool)
+	for _, t := range hand {
+		if !t.Honor() {
+			set[t.Suit()] = true
+		}
+	}
+	for _, m := range melds {
+		if !m.Tiles[0].Honor() {
+			set[m.Tiles[0].Suit()] = true
+		}
+	}
+	return set
+}
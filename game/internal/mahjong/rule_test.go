@@ -0,0 +1,164 @@
+package mahjong
+
+import "testing"
+
+// tiles 按简写生成牌，如"123m456s789p11z"，m万s条p筒z字（1-7为东南西北中发白）
+func tiles(s string) []Tile {
+	var list []Tile
+	var digits []int
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			digits = append(digits, int(r-'0'))
+		case r == ' ':
+		default:
+			base := map[rune]int{'m': 0, 's': suitSize, 'p': 2 * suitSize, 'z': honorStart}[r]
+			for _, d := range digits {
+				list = append(list, Tile(base+d-1))
+			}
+			digits = nil
+		}
+	}
+	return list
+}
+
+var (
+	general   = Variant{Honors: true, Chi: true, SevenPairs: true}
+	sichuan   = Variant{SevenPairs: true, MissingSuit: true}
+	noPairs   = Variant{Honors: true, Chi: true}
+	pengMeld  = Meld{Kind: MeldPeng, Tiles: tiles("111m"), From: 1}
+	chiMeld   = Meld{Kind: MeldChi, Tiles: tiles("123m"), From: 1}
+	gangMeld  = Meld{Kind: MeldConcealedGang, Tiles: tiles("1111m"), From: 0}
+	honorPeng = Meld{Kind: MeldPeng, Tiles: tiles("666z"), From: 1}
+)
+
+func TestVariantOf(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    map[string]int
+		want    Variant
+		wantErr bool
+	}{
+		{"general", map[string]int{optionRegion: 0, optionMaxFan: 8}, Variant{Honors: true, Chi: true, SevenPairs: true, MaxFan: 8}, false},
+		{"sichuan", map[string]int{optionRegion: 1}, sichuan, false},
+		{"guangdong", map[string]int{optionRegion: 2, optionMaxFan: 16}, Variant{Honors: true, SevenPairs: true, SelfDrawOnly: true, MaxFan: 16}, false},
+		{"unknown region", map[string]int{optionRegion: 3}, Variant{}, true},
+		{"negative max fan", map[string]int{optionMaxFan: -1}, Variant{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := variantOf(tt.rule)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Fatalf("variantOf = %+v, %v, want %+v", got, err, tt.want)
+			}
+		})
+	}
+	if n := len(general.wall()); n != 136 {
+		t.Fatalf("general wall = %d", n)
+	}
+	if n := len(sichuan.wall()); n != 108 {
+		t.Fatalf("sichuan wall = %d", n)
+	}
+}
+
+func TestCanWin(t *testing.T) {
+	tests := []struct {
+		name  string
+		v     Variant
+		hand  string
+		melds []Meld
+		want  bool
+	}{
+		{"sequences", general, "123m456m789m123s11p", nil, true},
+		{"triplets", general, "111m222m333s444p55z", nil, true},
+		{"mixed", general, "111m234m345s666p77z", nil, true},
+		{"overlapping sequences", general, "112233m456s789p55p", nil, true},
+		{"pair inside sequences", general, "11122233344m567s", nil, true},
+		{"nine gates", general, "1112345678999m5m", nil, true},
+		{"not win", general, "123m456m789m124s11p", nil, false},
+		{"no pair", general, "123m456m789m123s12p", nil, false},
+		{"honor sequence", general, "123z456m789m111s22p", nil, false},
+		{"no wrap around", general, "891m456m789s123p11p", nil, false},
+		{"wrong count", general, "123m456m789m123s1p", nil, false},
+		{"with melds", general, "123m11p", []Meld{pengMeld, chiMeld, gangMeld}, true},
+		{"with melds not win", general, "124m11p", []Meld{pengMeld, chiMeld, gangMeld}, false},
+		{"pair only", general, "55z", []Meld{pengMeld, chiMeld, gangMeld, honorPeng}, true},
+		{"seven pairs", general, "1133557799m1122s", nil, true},
+		{"seven pairs with four", general, "1111335577m1199s", nil, true},
+		{"seven pairs honors", general, "11223344556677z", nil, true},
+		{"seven pairs disabled", noPairs, "1133557799m1122s", nil, false},
+		{"six pairs", general, "1133557799m1123s", nil, false},
+		{"missing suit three suits", sichuan, "123m456m789s123p11p", nil, false},
+		{"missing suit two suits", sichuan, "123m456m789m123s11s", nil, true},
+		{"missing suit melds", sichuan, "123s11s", []Meld{pengMeld, chiMeld, {Kind: MeldPeng, Tiles: tiles("999p")}}, false},
+		{"missing suit seven pairs", sichuan, "1133557799m1122s", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.v.CanWin(tiles(tt.hand), tt.melds); got != tt.want {
+				t.Fatalf("CanWin(%s) = %v, want %v", tt.hand, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFans(t *testing.T) {
+	capped := general
+	capped.MaxFan = 4
+	tests := []struct {
+		name  string
+		v     Variant
+		win   Win
+		fan   int
+		names []string
+	}{
+		{"basic", general, Win{Hand: tiles("123m456m789m123s11p")}, 1, []string{"平胡"}},
+		{"self draw", general, Win{Hand: tiles("123m456m789m123s11p"), SelfDraw: true}, 2, []string{"平胡", "自摸"}},
+		{"seven pairs", general, Win{Hand: tiles("1133557799m1122s")}, 3, []string{"平胡", "七对"}},
+		{"all triplets", general, Win{Hand: tiles("222s333p55z"), Melds: []Meld{pengMeld}}, 3, []string{"平胡", "碰碰胡"}},
+		{"chi is not all triplets", general, Win{Hand: tiles("222s333p55z"), Melds: []Meld{chiMeld}}, 1, []string{"平胡"}},
+		{"one suit", general, Win{Hand: tiles("123m456m789m234m55m")}, 4, []string{"平胡", "清一色"}},
+		{"one suit seven pairs", general, Win{Hand: tiles("1133557799m1122m")}, 6, []string{"平胡", "七对", "清一色"}},
+		{"one suit with honors", general, Win{Hand: tiles("123m456m789m111z22z")}, 2, []string{"平胡", "混一色"}},
+		{"honor melds", general, Win{Hand: tiles("123m456m789m22z"), Melds: []Meld{honorPeng}}, 2, []string{"平胡", "混一色"}},
+		{"all honors", general, Win{Hand: tiles("111z222z333z444z55z")}, 7, []string{"平胡", "碰碰胡", "字一色"}},
+		{"gang after gang", general, Win{Hand: tiles("234s567p99p555z"), Melds: []Meld{gangMeld}, SelfDraw: true, AfterGang: true}, 4, []string{"平胡", "自摸", "杠上开花", "杠"}},
+		{"capped", capped, Win{Hand: tiles("111z222z333z444z55z")}, 4, []string{"平胡", "碰碰胡", "字一色"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fan, fans := tt.v.Fans(tt.win)
+			if fan != tt.fan || len(fans) != len(tt.names) {
+				t.Fatalf("Fans = %d, %v, want %d, %v", fan, fans, tt.fan, tt.names)
+			}
+			for i, f := range fans {
+				if f.Name != tt.names[i] {
+					t.Fatalf("fans = %v, want %v", fans, tt.names)
+				}
+			}
+		})
+	}
+}
+
+func TestChiOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		hand string
+		tile string
+		want int
+	}{
+		{"all three", "3467m", "5m", 3},
+		{"edge low", "23m", "1m", 1},
+		{"edge high", "78m", "9m", 1},
+		{"other suit", "46s", "5m", 0},
+		{"honor", "12z", "3z", 0},
+		{"no wrap", "89m", "1s", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := chiOptions(tiles(tt.hand), tiles(tt.tile)[0]); len(got) != tt.want {
+				t.Fatalf("chiOptions = %v, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package mahjong
+
+import "sort"
+
+// Tile 牌面，0-8万，9-17条，18-26筒，27-33东南西北中发白，每种4张
+type Tile int
+
+const (
+	suitSize   = 9
+	honorStart = 27 // 字牌
+	tileKinds  = 34
+	copies     = 4
+)
+
+func (t Tile) Valid() bool {
+	return t >= 0 && t < tileKinds
+}
+
+// Suit 花色，0万1条2筒，字牌为3
+func (t Tile) Suit() int {
+	return int(t) / suitSize
+}
+
+func (t Tile) Honor() bool {
+	return t >= honorStart
+}
+
+// Counts 每种牌的张数
+type Counts [tileKinds]int
+
+func countOf(tiles []Tile) Counts {
+	var c Counts
+	for _, t := range tiles {
+		c[t]++
+	}
+	return c
+}
+
+func sortTiles(tiles []Tile) {
+	sort.Slice(tiles, func(i, j int) bool { return tiles[i] < tiles[j] })
+}
+
+// 副露的类型
+const (
+	MeldChi           = 1 // 吃
+	MeldPeng          = 2 // 碰
+	MeldGang          = 3 // 明杠，包括加杠
+	MeldConcealedGang = 4 // 暗杠
+)
+
+// Meld 副露，from为被吃、碰、杠的座位，暗杠为自己
+type Meld struct {
+	Kind  int    `json:"kind"`
+	Tiles []Tile `json:"tiles"`
+	From  int    `json:"from"`
+}
+
+func (m Meld) gang() bool {
+	return m.Kind == MeldGang || m.Kind == MeldConcealedGang
+}
+
+// standard 基本胡型：一个对子加若干顺子、刻子
+func standard(c Counts) bool {
+	n := 0
+	for _, v := range c {
+		n += v
+	}
+	if n%3 != 2 {
+		return false
+	}
+	for i := range c {
+		if c[i] < 2 {
+			continue
+		}
+		c[i] -= 2
+		if decompose(c) {
+			return true
+		}
+		c[i] += 2
+	}
+	return false
+}
+
+// decompose 所有的牌都能组成顺子或刻子，从最小的牌开始，它只能是刻子或者顺子的第一张
+func decompose(c Counts) bool {
+	i := 0
+	for i < tileKinds && c[i] == 0 {
+		i++
+	}
+	if i == tileKinds {
+		return true
+	}
+	if c[i] >= 3 {
+		c[i] -= 3
+		if decompose(c) {
+			return true
+		}
+		c[i] += 3
+	}
+	if !Tile(i).Honor() && i%suitSize <= suitSize-3 && c[i+1] > 0 && c[i+2] > 0 {
+		c[i]--
+		c[i+1]--
+		c[i+2]--
+		return decompose(c)
+	}
+	return false
+}
+
+// sevenPairs 七对，只能是门前清的14张，4张相同的牌算两对
+func sevenPairs(c Counts) bool {
+	pairs := 0
+	for _, v := range c {
+		if v%2 != 0 {
+			return false
+		}
+		pairs += v / 2
+	}
+	return pairs == 7
+}
+
+// allTriplets 碰碰胡：手牌除一个对子外全部是刻子
+func allTriplets(c Counts) bool {
+	pair := false
+	for _, v := range c {
+		switch v {
+		case 0, 3:
+		case 2:
+			if pair {
+				return false
+			}
+			pair = true
+		default:
+			return false
+		}
+	}
+	return pair
+}
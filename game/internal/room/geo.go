@@ -0,0 +1,94 @@
+package room
+
+import (
+	"common/biz"
+	"common/config"
+	"math"
+)
+
+// 规则选项中的定位检查开关，为1时进入房间需要上报定位，并且不能离其他玩家太近
+const optionGps = "gps"
+
+// 地球平均半径，单位米
+const earthRadius = 6371000
+
+// Location 玩家上报的定位，经纬度
+type Location struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// Valid 经纬度是否在有效范围内
+func (l *Location) Valid() bool {
+	return l.Latitude >= -90 && l.Latitude <= 90 && l.Longitude >= -180 && l.Longitude <= 180
+}
+
+// Client 进入房间的玩家的连接信息，由connector从会话中填充
+type Client struct {
+	Uid      string
+	Ip       string
+	Location *Location // 未上报定位时为nil
+}
+
+// Distance 两个玩家之间的距离，distance单位米，任意一方没有定位时为-1
+type Distance struct {
+	Uid1     string `json:"uid1"`
+	Uid2     string `json:"uid2"`
+	Distance int64  `json:"distance"`
+	SameIp   bool   `json:"sameIp"` // 开启同ip检查时标记同一ip的玩家
+}
+
+// haversine 两个定位之间的球面距离，单位米
+func haversine(a, b *Location) float64 {
+	lat1, lat2 := a.Latitude*math.Pi/180, b.Latitude*math.Pi/180
+	dLat := lat2 - lat1
+	dLng := (b.Longitude - a.Longitude) * math.Pi / 180
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadius * math.Asin(math.Sqrt(math.Min(1, h)))
+}
+
+// checkLocation 开启定位检查的房间，进入时需要有定位，并且与已经坐下的玩家距离不小于配置的半径
+func checkLocation(room *Room, c Client) error {
+	if room.Rule[optionGps] != 1 {
+		return nil
+	}
+	if c.Location == nil {
+		return biz.CanNotEnterNotLocation
+	}
+	radius := float64(config.Current().Room.GpsRadius)
+	for _, p := range room.seats {
+		if p == nil || p.Uid == c.Uid || p.Location == nil {
+			continue
+		}
+		if haversine(p.Location, c.Location) < radius {
+			return biz.CanNotEnterTooNear
+		}
+	}
+	return nil
+}
+
+// distances 房间内玩家两两之间的距离，没有开启定位检查和同ip检查时为空，需要持有room.lock
+func (r *Room) distances() []*Distance {
+	gps, sameIp := r.Rule[optionGps] == 1, config.Current().Room.SameIpCheck
+	if !gps && !sameIp {
+		return nil
+	}
+	var list []*Distance
+	for i, a := range r.seats {
+		if a == nil {
+			continue
+		}
+		for _, b := range r.seats[i+1:] {
+			if b == nil {
+				continue
+			}
+			d := &Distance{Uid1: a.Uid, Uid2: b.Uid, Distance: -1}
+			if a.Location != nil && b.Location != nil {
+				d.Distance = int64(math.Round(haversine(a.Location, b.Location)))
+			}
+			d.SameIp = sameIp && a.Ip != "" && a.Ip == b.Ip
+			list = append(list, d)
+		}
+	}
+	return list
+}
@@ -0,0 +1,151 @@
+package room
+
+import (
+	"common/biz"
+	"common/config"
+	"context"
+	"core/session"
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestHaversine(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b Location
+		want float64 // 米
+		diff float64
+	}{
+		{"same point", Location{39.9042, 116.4074}, Location{39.9042, 116.4074}, 0, 0.001},
+		{"beijing shanghai", Location{39.9042, 116.4074}, Location{31.2304, 121.4737}, 1067000, 2000},
+		{"100m north", Location{30, 120}, Location{30.0008993, 120}, 100, 0.5},
+		{"antipodes", Location{0, 0}, Location{0, 180}, math.Pi * earthRadius, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := haversine(&tt.a, &tt.b); math.Abs(got-tt.want) > tt.diff {
+				t.Fatalf("distance = %f, want %f", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckLocation(t *testing.T) {
+	newTestManager(t)
+	room := newRoom("100000", 2, 0, "u1", map[string]int{"players": 4, optionGps: 1}, 4)
+	room.sit(Client{Uid: "u1", Location: &Location{30, 120}})
+	tests := []struct {
+		name     string
+		location *Location
+		err      error
+	}{
+		{"no location", nil, biz.CanNotEnterNotLocation},
+		{"too near", &Location{30.0005, 120}, biz.CanNotEnterTooNear},
+		{"far enough", &Location{30.001, 120}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkLocation(room, Client{Uid: "u2", Location: tt.location}); err != tt.err {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+		})
+	}
+	room.Rule[optionGps] = 0
+	if err := checkLocation(room, Client{Uid: "u2"}); err != nil {
+		t.Fatalf("gps disabled err = %v", err)
+	}
+}
+
+func TestRoom_Distances(t *testing.T) {
+	newTestManager(t)
+	room := newRoom("100000", 2, 0, "u1", map[string]int{"players": 3, optionGps: 0}, 3)
+	room.sit(Client{Uid: "u1", Ip: "1.1.1.1", Location: &Location{30, 120}})
+	room.sit(Client{Uid: "u2", Ip: "1.1.1.1", Location: &Location{30.0008993, 120}})
+	room.sit(Client{Uid: "u3", Ip: "2.2.2.2"})
+	if d := room.distances(); d != nil {
+		t.Fatalf("distances without checks = %v", d)
+	}
+	config.Current().Room.SameIpCheck = true
+	want := []Distance{
+		{Uid1: "u1", Uid2: "u2", Distance: 100, SameIp: true},
+		{Uid1: "u1", Uid2: "u3", Distance: -1},
+		{Uid1: "u2", Uid2: "u3", Distance: -1},
+	}
+	got := room.distances()
+	if len(got) != len(want) {
+		t.Fatalf("distances = %v", got)
+	}
+	for i := range want {
+		if *got[i] != want[i] {
+			t.Fatalf("distances[%d] = %+v, want %+v", i, *got[i], want[i])
+		}
+	}
+}
+
+func TestManager_GpsRoom(t *testing.T) {
+	ctx := context.Background()
+	m, manager := newTestManager(t, "u1", "u2", "u3")
+	rule := map[string]int32{"players": 3, optionGps: 1}
+	if _, err := m.Create(ctx, Client{Uid: "u1"}, 2, 0, rule); err != biz.CanNotEnterNotLocation {
+		t.Fatalf("create without location err = %v", err)
+	}
+	info, err := m.Create(ctx, Client{Uid: "u1", Location: &Location{30, 120}}, 2, 0, rule)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = m.Join(ctx, Client{Uid: "u2", Location: &Location{30.0001, 120}}, info.RoomId); err != biz.CanNotEnterTooNear {
+		t.Fatalf("join too near err = %v", err)
+	}
+	if info, err = m.Join(ctx, Client{Uid: "u2", Location: &Location{30.01, 120}}, info.RoomId); err != nil {
+		t.Fatal(err)
+	}
+	if len(info.Distances) != 1 || info.Distances[0].Distance < 1000 {
+		t.Fatalf("distances = %+v", info.Distances)
+	}
+	roomId := info.RoomId
+	if _, err = m.Join(ctx, Client{Uid: "u3", Location: &Location{30.02, 120}}, roomId); err != nil {
+		t.Fatal(err)
+	}
+
+	// 重连时新的定位同样检查距离，不符合时保留原来的定位
+	if _, err = m.Join(ctx, Client{Uid: "u2", Location: &Location{30.0001, 120}}, roomId); err != biz.CanNotEnterTooNear {
+		t.Fatalf("reconnect too near err = %v", err)
+	}
+	if l := m.get(roomId).player("u2").Location; *l != (Location{30.01, 120}) {
+		t.Fatalf("u2 location = %+v", l)
+	}
+	if info, err = m.Join(ctx, Client{Uid: "u2", Location: &Location{30.03, 120}}, roomId); err != nil {
+		t.Fatal(err)
+	}
+	if len(info.Distances) != 3 {
+		t.Fatalf("distances = %+v", info.Distances)
+	}
+
+	// 离开后推送剩余玩家的距离矩阵
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	pushed := make(chan *session.PushMessage, 10)
+	go session.SubscribePush(subCtx, manager.Redis, func(msg *session.PushMessage) {
+		if msg.Route == PushDistance {
+			pushed <- msg
+		}
+	})
+	time.Sleep(50 * time.Millisecond)
+	if err = m.Leave(ctx, "u3", roomId); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case msg := <-pushed:
+		var distances []*Distance
+		if err = json.Unmarshal(msg.Data, &distances); err != nil {
+			t.Fatal(err)
+		}
+		if len(distances) != 1 || len(msg.Uids) != 2 {
+			t.Fatalf("pushed distances = %+v to %v", distances, msg.Uids)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("distances not pushed after leave")
+	}
+}
@@ -0,0 +1,945 @@
+package room
+
+import (
+	"common/biz"
+	"common/config"
+	"common/database"
+	"common/logs"
+	"common/metrics"
+	"common/msError"
+	"context"
+	"core/models/entity"
+	"core/repo"
+	"core/session"
+	"crypto/rand"
+	"game/internal/engine"
+	"math"
+	"math/big"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	roomIdMin   = 100000 // 6位房间号
+	roomIdMax   = 999999
+	roomIdRetry = 10 // 生成房间号重复时的重试次数
+)
+
+// 匹配时最多尝试的其他节点上的等待房间数
+const matchRemoteLimit = 10
+
+// Forwarder 把加入房间的请求转发到房间所在的其他game节点，返回的错误转换为业务错误
+type Forwarder interface {
+	Join(ctx context.Context, node string, c Client, roomId string) (*Info, error)
+}
+
+// Manager 本节点上的所有房间
+// 房间号到节点的映射保存在redis中，用户当前所在的房间保存在用户数据中，保证一个用户同时只能在一个房间
+type Manager struct {
+	node        string // 本节点的grpc地址，connector通过它转发房间内的请求
+	redis       *database.RedisManager
+	users       repo.UserRepository
+	profiles    *repo.ProfileCache
+	members     repo.UnionMemberRepository
+	rooms       repo.RoomRepository
+	ledgers     repo.LedgerRepository
+	settlements repo.SettlementRepository
+	settled     *database.Idempotency // 每一局只结算一次，重试时直接返回第一次的结果
+	tx          database.Transactor
+	forwarder   Forwarder // 为空时只匹配本节点的房间
+
+	lock  sync.RWMutex
+	table map[string]*Room
+}
+
+func NewManager(node string, manager *repo.Manager) *Manager {
+	return &Manager{
+		node:        node,
+		redis:       manager.Redis,
+		users:       manager.Users,
+		profiles:    manager.Profiles,
+		members:     manager.UnionMembers,
+		rooms:       manager.Rooms,
+		ledgers:     manager.Ledgers,
+		settlements: manager.Settlements,
+		settled:     manager.Redis.NewIdempotency("settle", settleIdempotencyTTL),
+		tx:          manager.Tx,
+		table:       make(map[string]*Room),
+	}
+}
+
+// SetForwarder 设置转发到其他节点的客户端，用于匹配其他节点上等待中的房间
+func (m *Manager) SetForwarder(f Forwarder) {
+	m.forwarder = f
+}
+
+// Create 创建房间，创建者坐到第一个座位，联盟房间要求是联盟成员并且不超过联盟的房间数量上限
+func (m *Manager) Create(ctx context.Context, c Client, gameType int, unionId int64, options map[string]int32) (*Info, error) {
+	uid := c.Uid
+	if uid == "" {
+		return nil, biz.RequestDataError
+	}
+	if err := session.CheckMaintenance(ctx, m.redis, uid, c.Ip); err != nil {
+		return nil, err
+	}
+	rule, maxPlayers, err := parseRule(gameType, options)
+	if err != nil {
+		return nil, err
+	}
+	var info *Info
+	create := func(ctx context.Context) error {
+		return m.lockUser(ctx, uid, func(ctx context.Context) error {
+			info, err = m.open(ctx, newRoom("", gameType, unionId, uid, rule, maxPlayers), c)
+			return err
+		})
+	}
+	if unionId == 0 {
+		err = create(ctx)
+		return info, err
+	}
+	if err = m.checkMember(ctx, unionId, uid); err != nil {
+		return nil, err
+	}
+	// 持有联盟的锁检查房间数量并创建，避免多个节点同时创建超过上限
+	err = m.lockUnion(ctx, unionId, func(ctx context.Context) error {
+		if err := m.checkUnionLimit(ctx, unionId); err != nil {
+			return err
+		}
+		return create(ctx)
+	})
+	return info, err
+}
+
+// Match 金币场匹配，level为0时按金币自动选择场次
+// 优先加入本节点同场次等待中的房间，其次是其他节点上等待中的房间，都没有时创建
+func (m *Manager) Match(ctx context.Context, c Client, gameType, level int) (*Info, error) {
+	uid := c.Uid
+	if uid == "" {
+		return nil, biz.RequestDataError
+	}
+	if err := session.CheckMaintenance(ctx, m.redis, uid, c.Ip); err != nil {
+		return nil, err
+	}
+	user, err := m.users.FindByUid(ctx, uid)
+	if err != nil {
+		return nil, m.enterError(ctx, uid, err)
+	}
+	tier, err := chooseTier(gameType, level, user.Gold)
+	if err != nil {
+		return nil, err
+	}
+	var info *Info
+	err = m.lockUser(ctx, uid, func(ctx context.Context) error {
+		info, err = m.matchLocal(ctx, c, user, gameType, tier.Level)
+		return err
+	})
+	if err != nil || info != nil {
+		return info, err
+	}
+	// 其他节点加入房间时会持有用户的锁，转发时不能持有
+	if info = m.matchRemote(ctx, c, gameType, tier.Level); info != nil {
+		return info, nil
+	}
+	err = m.lockUser(ctx, uid, func(ctx context.Context) error {
+		rule, maxPlayers, err := parseRule(gameType, nil)
+		if err != nil {
+			return err
+		}
+		room := newRoom("", gameType, 0, uid, rule, maxPlayers)
+		room.Tier = tier.Level
+		info, err = m.open(ctx, room, c)
+		return err
+	})
+	return info, err
+}
+
+// matchLocal 加入本节点指定场次的房间，没有可以加入的房间时返回nil，需要持有用户的锁
+func (m *Manager) matchLocal(ctx context.Context, c Client, user *entity.User, gameType, level int) (*Info, error) {
+	uid := c.Uid
+	// 已经在本节点的房间中时直接返回（断线重连）
+	if room := m.get(user.RoomId); room != nil {
+		room.lock.Lock()
+		if !room.dismissed && room.player(uid) != nil {
+			info := room.snapshot()
+			room.lock.Unlock()
+			m.view(ctx, room, uid)
+			return m.withProfiles(ctx, info), nil
+		}
+		room.lock.Unlock()
+	}
+	for _, room := range m.waiting(gameType, level) {
+		room.lock.Lock()
+		info, joined, err := m.sitDown(ctx, room, c)
+		room.lock.Unlock()
+		if skipRoom(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return m.joined(ctx, info, uid, joined), nil
+	}
+	return nil, nil
+}
+
+// matchRemote 加入其他节点上指定场次等待中的房间，没有可以加入的房间或转发失败时返回nil，由本节点创建房间
+func (m *Manager) matchRemote(ctx context.Context, c Client, gameType, level int) *Info {
+	if m.forwarder == nil {
+		return nil
+	}
+	roomIds, err := session.WaitingRooms(ctx, m.redis, gameType, level, matchRemoteLimit)
+	if err != nil {
+		logs.ErrorCtx(ctx, "get waiting rooms err: %v", err)
+		return nil
+	}
+	for _, roomId := range roomIds {
+		node, err := session.RoomNode(ctx, m.redis, roomId)
+		if err != nil {
+			logs.ErrorCtx(ctx, "get room %s node err: %v", roomId, err)
+			return nil
+		}
+		if node == "" {
+			// 节点宕机，房间已经失效
+			if err = session.RemoveWaitingRoom(ctx, m.redis, gameType, level, roomId); err != nil {
+				logs.ErrorCtx(ctx, "remove waiting room %s err: %v", roomId, err)
+			}
+			continue
+		}
+		if node == m.node {
+			continue
+		}
+		info, err := m.forwarder.Join(ctx, node, c, roomId)
+		if err == nil {
+			return info
+		}
+		if !skipRoom(err) {
+			logs.WarnCtx(ctx, "%s match room %s on %s err: %v", c.Uid, roomId, node, err)
+			return nil
+		}
+	}
+	return nil
+}
+
+// skipRoom 满员、解散或者离其他玩家太近时匹配下一个房间
+func skipRoom(err error) bool {
+	e, ok := err.(*msError.Error)
+	if !ok {
+		return false
+	}
+	switch e.Code {
+	case biz.RoomPlayerCountFull.Code, biz.RoomNotExist.Code, biz.CanNotEnterTooNear.Code:
+		return true
+	}
+	return false
+}
+
+// Join 加入房间，已经在房间中时直接返回房间信息（断线重连）
+func (m *Manager) Join(ctx context.Context, c Client, roomId string) (*Info, error) {
+	uid := c.Uid
+	if uid == "" {
+		return nil, biz.RequestDataError
+	}
+	room := m.get(roomId)
+	if room == nil {
+		return nil, biz.RoomNotExist
+	}
+	var info *Info
+	var joined bool
+	err := m.lockUser(ctx, uid, func(ctx context.Context) error {
+		var err error
+		room.lock.Lock()
+		info, joined, err = m.sitDown(ctx, room, c)
+		room.lock.Unlock()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	info = m.joined(ctx, info, uid, joined)
+	if !joined {
+		m.view(ctx, room, uid)
+	}
+	return info, nil
+}
+
+// Leave 离开房间，游戏中不能离开，最后一个玩家离开后解散房间
+func (m *Manager) Leave(ctx context.Context, uid, roomId string) error {
+	room := m.get(roomId)
+	if room == nil {
+		return biz.RoomNotExist
+	}
+	room.lock.Lock()
+	defer room.lock.Unlock()
+	if room.dismissed || room.player(uid) == nil {
+		return biz.NotInRoom
+	}
+	if room.state == StatePlaying {
+		return biz.CanNotLeaveRoom
+	}
+	if err := m.exit(ctx, uid, roomId); err != nil {
+		logs.ErrorCtx(ctx, "%s leave room %s err: %v", uid, roomId, err)
+		return biz.SqlError
+	}
+	m.stand(ctx, room, uid)
+	logs.InfoCtx(ctx, "%s leave room %s", uid, roomId)
+	return nil
+}
+
+// Ready 准备、取消准备，游戏中不能修改，所有玩家准备后开始一局
+// 维护期间不能准备，不再开始新的一局，进行中的对局不受影响
+func (m *Manager) Ready(ctx context.Context, uid, roomId string, ready bool) error {
+	room := m.get(roomId)
+	if room == nil {
+		return biz.RoomNotExist
+	}
+	if ready {
+		if err := session.CheckMaintenance(ctx, m.redis, uid, ""); err != nil {
+			return err
+		}
+	}
+	room.lock.Lock()
+	p := room.player(uid)
+	if room.dismissed || p == nil {
+		room.lock.Unlock()
+		return biz.NotInRoom
+	}
+	if room.state != StateWaiting {
+		room.lock.Unlock()
+		return biz.RequestDataError
+	}
+	p.Ready = ready
+	var golds map[string]int64
+	if ready && room.allReady() && room.Tier != 0 {
+		// 金币场开始前读取所有玩家的金币，访问数据库时不持有房间的锁
+		uids := room.uids("")
+		room.lock.Unlock()
+		golds = m.golds(ctx, uids)
+		room.lock.Lock()
+		// 释放锁期间房间可能已经解散、开始，或者有玩家离开、取消准备
+		if p = room.player(uid); room.dismissed || p == nil {
+			room.lock.Unlock()
+			return biz.NotInRoom
+		}
+	}
+	start := ready && room.state == StateWaiting && room.allReady()
+	if start && room.game == nil {
+		if err := m.newGame(ctx, room); err != nil {
+			p.Ready = false
+			room.lock.Unlock()
+			return err
+		}
+	}
+	m.push(ctx, room.uids(uid), PushPlayerReady, map[string]any{"uid": uid, "ready": ready})
+	var moved []Client
+	var game *engine.Table
+	if start {
+		moved = m.start(ctx, room, golds)
+		if room.state == StatePlaying {
+			game = room.game
+		}
+	}
+	gameType := room.GameType
+	uids := room.uids("")
+	room.lock.Unlock()
+
+	// 对局结束时会锁住房间，在释放房间的锁之后开始对局
+	if game != nil {
+		m.deal(ctx, room, game, uids)
+	}
+	// 退出房间和移到其他房间需要访问数据库、锁住目标房间，在释放当前房间的锁之后进行
+	for _, v := range moved {
+		if err := m.exit(ctx, v.Uid, roomId); err != nil {
+			logs.ErrorCtx(ctx, "%s exit room %s err: %v", v.Uid, roomId, err)
+		}
+		m.move(ctx, v, gameType)
+	}
+	return nil
+}
+
+// Action 玩家在对局中的操作，由对局按顺序处理
+func (m *Manager) Action(ctx context.Context, uid, roomId string, a *engine.Action) error {
+	room := m.get(roomId)
+	if room == nil {
+		return biz.RoomNotExist
+	}
+	room.lock.Lock()
+	if room.dismissed || room.player(uid) == nil {
+		room.lock.Unlock()
+		return biz.NotInRoom
+	}
+	game := room.game
+	room.lock.Unlock()
+	if game == nil {
+		return biz.GameNotPlaying
+	}
+	err := game.Action(uid, a)
+	if err == engine.ErrClosed {
+		return biz.RoomNotExist
+	}
+	if err != nil {
+		logs.InfoCtx(ctx, "%s room %s action %s err: %v", uid, roomId, a.Type, err)
+	}
+	return err
+}
+
+// newGame 创建房间的对局，游戏类型没有注册玩法时不能开始，需要持有room.lock
+func (m *Manager) newGame(ctx context.Context, room *Room) error {
+	logic, err := engine.NewLogic(room.GameType, room.Rule, room.MaxPlayers)
+	if err != nil {
+		logs.ErrorCtx(ctx, "room %s new game %d logic err: %v", room.Id, room.GameType, err)
+		return err
+	}
+	var timeout time.Duration
+	if turn := config.Current().Room.TurnTimeout; turn > 0 {
+		timeout = time.Duration(turn) * time.Second
+	}
+	room.game = engine.NewTable(room.Id, room.Rule, logic, engine.Options{
+		Notifier: engine.NotifierFunc(func(uids []string, route string, data any) {
+			m.push(context.Background(), uids, route, data)
+		}),
+		TurnTimeout: timeout,
+		OnFinish: func(_ *engine.Table, result *engine.Result) {
+			m.finish(room, result)
+		},
+		OnAbort: func(t *engine.Table) {
+			m.abort(room, t.Hand)
+		},
+	})
+	return nil
+}
+
+// deal 使用随机种子开始一局，种子记录在日志中用于复现对局
+func (m *Manager) deal(ctx context.Context, room *Room, game *engine.Table, uids []string) {
+	n, err := rand.Int(rand.Reader, big.NewInt(math.MaxInt64))
+	if err == nil {
+		err = game.Start(n.Int64(), uids)
+	}
+	if err == nil {
+		logs.InfoCtx(ctx, "room %s deal, seed: %d", room.Id, n.Int64())
+		return
+	}
+	if err == engine.ErrClosed {
+		return
+	}
+	logs.ErrorCtx(ctx, "room %s deal err: %v", room.Id, err)
+	room.lock.Lock()
+	room.unready()
+	room.lock.Unlock()
+	m.push(ctx, uids, PushRoomStop, map[string]any{"roomId": room.Id})
+}
+
+// finish 一局结束后结算并回到等待准备，在对局的协程中调用
+func (m *Manager) finish(room *Room, result *engine.Result) {
+	m.settle(room, result)
+	room.lock.Lock()
+	defer room.lock.Unlock()
+	if room.dismissed {
+		return
+	}
+	room.unready()
+	logs.Info("room %s hand %d finished, seed: %d, scores: %v", room.Id, result.Hand, result.Seed, result.Scores)
+}
+
+// abort 本局作废后回到等待准备，不结算，在对局的协程中调用
+func (m *Manager) abort(room *Room, hand int) {
+	room.lock.Lock()
+	defer room.lock.Unlock()
+	if room.dismissed {
+		return
+	}
+	room.unready()
+	logs.Warn("room %s hand %d aborted", room.Id, hand)
+}
+
+// view 断线重连时推送对局状态
+func (m *Manager) view(ctx context.Context, room *Room, uid string) {
+	room.lock.Lock()
+	game := room.game
+	room.lock.Unlock()
+	if game == nil {
+		return
+	}
+	view, err := game.View(uid)
+	if err != nil || view == nil {
+		return
+	}
+	m.push(ctx, []string{uid}, engine.PushView, view)
+}
+
+// golds 批量读取玩家的金币，读取失败时返回nil
+func (m *Manager) golds(ctx context.Context, uids []string) map[string]int64 {
+	users, err := m.users.FindByUids(ctx, uids)
+	if err != nil {
+		logs.ErrorCtx(ctx, "find users %v err: %v", uids, err)
+		return nil
+	}
+	golds := make(map[string]int64, len(users))
+	for _, v := range users {
+		golds[v.Uid] = v.Gold
+	}
+	return golds
+}
+
+// start 开始一局前按golds重新检查金币场玩家的金币，不符合场次的玩家离开座位，返回被移出的玩家，需要持有room.lock
+// 被移出的玩家由调用方在释放房间的锁之后退出房间并重新匹配
+func (m *Manager) start(ctx context.Context, room *Room, golds map[string]int64) []Client {
+	if room.Tier != 0 {
+		tier, ok := findTier(room.GameType, room.Tier)
+		var moved []Client
+		for _, p := range room.seats {
+			gold, found := golds[p.Uid]
+			if !found {
+				// 无法确认金币时不开始，等待玩家重新准备
+				logs.ErrorCtx(ctx, "room %s gold of %s not found", room.Id, p.Uid)
+				p.Ready = false
+				return nil
+			}
+			// 场次被删除（配置重新加载）时所有玩家都需要重新匹配
+			if !ok || checkGold(tier, gold) != nil {
+				moved = append(moved, Client{Uid: p.Uid, Ip: p.Ip, Location: p.Location})
+			}
+		}
+		for _, c := range moved {
+			m.stand(ctx, room, c.Uid)
+		}
+		if len(moved) > 0 {
+			logs.InfoCtx(ctx, "room %s move out %d players, gold not match tier %d", room.Id, len(moved), room.Tier)
+			return moved
+		}
+	}
+	room.state = StatePlaying
+	info := room.snapshot()
+	m.push(ctx, room.uids(""), PushRoomStart, map[string]any{"roomId": room.Id, "baseBet": info.BaseBet})
+	logs.InfoCtx(ctx, "room %s start, players: %v", room.Id, room.uids(""))
+	return nil
+}
+
+// move 被移出的玩家按当前金币重新匹配，没有可以进入的场次时通知玩家
+func (m *Manager) move(ctx context.Context, c Client, gameType int) {
+	uid := c.Uid
+	info, err := m.Match(ctx, c, gameType, 0)
+	if err != nil {
+		code, msg := biz.Fail.Code, biz.Fail.Error()
+		if e, ok := err.(*msError.Error); ok {
+			code, msg = e.Code, e.Error()
+		}
+		m.push(ctx, []string{uid}, PushPlayerKick, map[string]any{"code": code, "msg": msg})
+		return
+	}
+	m.push(ctx, []string{uid}, PushPlayerMoved, info)
+}
+
+// open 绑定房间号并创建房间，创建者坐到第一个座位
+func (m *Manager) open(ctx context.Context, room *Room, c Client) (*Info, error) {
+	if err := checkLocation(room, c); err != nil {
+		return nil, err
+	}
+	roomId, err := m.bind(ctx)
+	if err != nil {
+		return nil, err
+	}
+	room.Id = roomId
+	room.CreateTime = time.Now().UnixMilli()
+	uid := room.CreatorUid
+	err = m.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := m.enter(ctx, uid, roomId); err != nil {
+			return err
+		}
+		return m.rooms.Create(ctx, &entity.Room{
+			RoomId:     roomId,
+			GameType:   room.GameType,
+			UnionId:    room.UnionId,
+			Tier:       room.Tier,
+			CreatorUid: uid,
+			Node:       m.node,
+			Rule:       toAny(room.Rule),
+			CreateTime: room.CreateTime,
+		})
+	})
+	if err != nil {
+		m.unbind(roomId)
+		return nil, m.enterError(ctx, uid, err)
+	}
+	room.sit(c)
+	m.syncWaiting(ctx, room)
+	m.lock.Lock()
+	m.table[roomId] = room
+	metrics.ActiveRooms.WithLabelValues(m.node).Set(float64(len(m.table)))
+	m.lock.Unlock()
+	logs.InfoCtx(ctx, "%s create room %s, gameType: %d, unionId: %d, tier: %d", uid, roomId, room.GameType, room.UnionId, room.Tier)
+	return m.withProfiles(ctx, room.snapshot()), nil
+}
+
+// sitDown 坐到房间的空座位，已经在房间中时joined为false，需要持有room.lock
+func (m *Manager) sitDown(ctx context.Context, room *Room, c Client) (*Info, bool, error) {
+	uid := c.Uid
+	if room.dismissed {
+		return nil, false, biz.RoomNotExist
+	}
+	if p := room.player(uid); p != nil {
+		// 重连后使用新的连接信息，新的定位同样需要和其他玩家保持距离，否则不能回到房间，只能离开
+		if c.Location != nil {
+			if err := checkLocation(room, c); err != nil {
+				return nil, false, err
+			}
+		}
+		changed := p.Ip != c.Ip || (c.Location != nil && (p.Location == nil || *p.Location != *c.Location))
+		p.Ip = c.Ip
+		if c.Location != nil {
+			p.Location = c.Location
+		}
+		if distances := room.distances(); changed && len(distances) > 0 {
+			m.push(ctx, room.uids(uid), PushDistance, distances)
+		}
+		return room.snapshot(), false, nil
+	}
+	if room.count() >= room.MaxPlayers {
+		return nil, false, biz.RoomPlayerCountFull
+	}
+	// 维护期间不能加入新的房间，已经在房间中的玩家可以重连
+	if err := session.CheckMaintenance(ctx, m.redis, uid, c.Ip); err != nil {
+		return nil, false, err
+	}
+	if room.UnionId != 0 {
+		if err := m.checkMember(ctx, room.UnionId, uid); err != nil {
+			return nil, false, err
+		}
+	}
+	if room.Tier != 0 {
+		if err := m.checkTier(ctx, room, uid); err != nil {
+			return nil, false, err
+		}
+	}
+	if err := checkLocation(room, c); err != nil {
+		return nil, false, err
+	}
+	if err := m.enter(ctx, uid, room.Id); err != nil {
+		return nil, false, m.enterError(ctx, uid, err)
+	}
+	p := room.sit(c)
+	m.syncWaiting(ctx, room)
+	logs.InfoCtx(ctx, "%s join room %s, seat: %d", uid, room.Id, p.Seat)
+	return room.snapshot(), true, nil
+}
+
+// joined 填充玩家信息，新加入时通知房间里的其他玩家
+func (m *Manager) joined(ctx context.Context, info *Info, uid string, joined bool) *Info {
+	info = m.withProfiles(ctx, info)
+	if !joined {
+		return info
+	}
+	others := make([]string, 0, len(info.Players))
+	var self *PlayerInfo
+	for _, v := range info.Players {
+		if v.Uid == uid {
+			self = v
+		} else {
+			others = append(others, v.Uid)
+		}
+	}
+	m.push(ctx, others, PushPlayerJoin, self)
+	if len(info.Distances) > 0 {
+		m.push(ctx, others, PushDistance, info.Distances)
+	}
+	return info
+}
+
+// stand 玩家离开座位，通知其他玩家，没有玩家时解散房间，需要持有room.lock
+func (m *Manager) stand(ctx context.Context, room *Room, uid string) {
+	room.stand(uid)
+	if room.count() == 0 {
+		m.dismiss(ctx, room)
+		return
+	}
+	m.syncWaiting(ctx, room)
+	m.push(ctx, room.uids(""), PushPlayerLeave, map[string]string{"uid": uid})
+	if distances := room.distances(); len(distances) > 0 {
+		m.push(ctx, room.uids(""), PushDistance, distances)
+	}
+}
+
+// syncWaiting 更新共享的等待房间索引，未满员的金币场房间可以被其他节点匹配到，需要持有room.lock
+func (m *Manager) syncWaiting(ctx context.Context, room *Room) {
+	if room.Tier == 0 {
+		return
+	}
+	var err error
+	if !room.dismissed && room.count() < room.MaxPlayers {
+		err = session.AddWaitingRoom(ctx, m.redis, room.GameType, room.Tier, room.Id)
+	} else {
+		err = session.RemoveWaitingRoom(ctx, m.redis, room.GameType, room.Tier, room.Id)
+	}
+	if err != nil {
+		logs.ErrorCtx(ctx, "sync waiting room %s err: %v", room.Id, err)
+	}
+}
+
+// checkTier 进入金币场房间时金币需要在场次的限制之内
+func (m *Manager) checkTier(ctx context.Context, room *Room, uid string) error {
+	tier, ok := findTier(room.GameType, room.Tier)
+	if !ok {
+		return biz.RoomNotExist
+	}
+	user, err := m.users.FindByUid(ctx, uid)
+	if err != nil {
+		return m.enterError(ctx, uid, err)
+	}
+	return checkGold(tier, user.Gold)
+}
+
+// waiting 本节点上指定场次的房间
+func (m *Manager) waiting(gameType, level int) []*Room {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	var rooms []*Room
+	for _, room := range m.table {
+		if room.GameType == gameType && room.Tier == level {
+			rooms = append(rooms, room)
+		}
+	}
+	return rooms
+}
+
+// Run 定时为本节点的房间续期、重试失败的结算，直到ctx结束
+func (m *Manager) Run(ctx context.Context) {
+	ticker := time.NewTicker(session.RoomRenewInterval)
+	defer ticker.Stop()
+	retry := time.NewTicker(settleRetryInterval)
+	defer retry.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := session.RenewRooms(ctx, m.redis, m.roomIds()); err != nil {
+				logs.Error("renew rooms err: %v", err)
+			}
+		case <-retry.C:
+			m.retrySettlements(ctx)
+		}
+	}
+}
+
+// Close 节点停止时解散所有房间，房间内的玩家回到大厅
+func (m *Manager) Close(ctx context.Context) {
+	m.lock.RLock()
+	rooms := make([]*Room, 0, len(m.table))
+	for _, room := range m.table {
+		rooms = append(rooms, room)
+	}
+	m.lock.RUnlock()
+	for _, room := range rooms {
+		room.lock.Lock()
+		if !room.dismissed {
+			for _, uid := range room.uids("") {
+				if err := m.exit(ctx, uid, room.Id); err != nil {
+					logs.Error("%s exit room %s err: %v", uid, room.Id, err)
+				}
+			}
+			m.dismiss(ctx, room)
+		}
+		room.lock.Unlock()
+	}
+}
+
+func (m *Manager) get(roomId string) *Room {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.table[roomId]
+}
+
+func (m *Manager) roomIds() []string {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	ids := make([]string, 0, len(m.table))
+	for id := range m.table {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// dismiss 解散房间，需要持有room.lock
+func (m *Manager) dismiss(ctx context.Context, room *Room) {
+	room.dismissed = true
+	if room.game != nil {
+		room.game.Close()
+	}
+	m.syncWaiting(ctx, room)
+	m.lock.Lock()
+	delete(m.table, room.Id)
+	metrics.ActiveRooms.WithLabelValues(m.node).Set(float64(len(m.table)))
+	m.lock.Unlock()
+	if err := m.rooms.Delete(ctx, room.Id); err != nil && err != repo.ErrNotFound {
+		logs.ErrorCtx(ctx, "delete room %s err: %v", room.Id, err)
+	}
+	m.unbind(room.Id)
+	logs.InfoCtx(ctx, "room %s dismissed", room.Id)
+}
+
+// bind 生成未被使用的房间号并绑定到本节点
+func (m *Manager) bind(ctx context.Context) (string, error) {
+	for i := 0; i < roomIdRetry; i++ {
+		n, err := rand.Int(rand.Reader, big.NewInt(roomIdMax-roomIdMin+1))
+		if err != nil {
+			return "", err
+		}
+		roomId := strconv.FormatInt(n.Int64()+roomIdMin, 10)
+		ok, err := session.BindRoom(ctx, m.redis, roomId, m.node)
+		if err != nil {
+			logs.ErrorCtx(ctx, "bind room %s err: %v", roomId, err)
+			return "", biz.Fail
+		}
+		if !ok {
+			continue
+		}
+		// 映射不存在说明房间号没有被使用，清理宕机节点遗留的房间记录
+		if err = m.rooms.Delete(ctx, roomId); err != nil && err != repo.ErrNotFound {
+			m.unbind(roomId)
+			logs.ErrorCtx(ctx, "delete stale room %s err: %v", roomId, err)
+			return "", biz.SqlError
+		}
+		return roomId, nil
+	}
+	logs.WarnCtx(ctx, "generate room id failed after %d retries", roomIdRetry)
+	return "", biz.Fail
+}
+
+func (m *Manager) unbind(roomId string) {
+	// 请求的ctx可能已经取消，解绑失败会导致房间号在过期前不可用
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := session.UnbindRoom(ctx, m.redis, roomId, m.node); err != nil {
+		logs.Error("unbind room %s err: %v", roomId, err)
+	}
+}
+
+// enter 记录用户进入房间，用户所在的房间已经失效（节点宕机）时直接覆盖
+func (m *Manager) enter(ctx context.Context, uid, roomId string) error {
+	err := m.users.SetRoomId(ctx, uid, "", roomId)
+	if err != repo.ErrConflict {
+		return err
+	}
+	user, err := m.users.FindByUid(ctx, uid)
+	if err != nil {
+		return err
+	}
+	node, err := session.RoomNode(ctx, m.redis, user.RoomId)
+	if err != nil {
+		return err
+	}
+	if node != "" {
+		return repo.ErrConflict
+	}
+	logs.WarnCtx(ctx, "%s was in expired room %s", uid, user.RoomId)
+	return m.users.SetRoomId(ctx, uid, user.RoomId, roomId)
+}
+
+// exit 清除用户所在的房间，已经被覆盖时忽略
+func (m *Manager) exit(ctx context.Context, uid, roomId string) error {
+	err := m.users.SetRoomId(ctx, uid, roomId, "")
+	if err == repo.ErrConflict || err == repo.ErrNotFound {
+		return nil
+	}
+	return err
+}
+
+func (m *Manager) enterError(ctx context.Context, uid string, err error) error {
+	switch err {
+	case repo.ErrConflict:
+		return biz.UserInRoomDataLocked
+	case repo.ErrNotFound:
+		return biz.NotFindUser
+	}
+	logs.ErrorCtx(ctx, "%s enter room err: %v", uid, err)
+	return biz.SqlError
+}
+
+// lockUser 持有用户的锁进入房间，和其他节点上的进入房间、金币结算串行执行
+func (m *Manager) lockUser(ctx context.Context, uid string, fn func(ctx context.Context) error) error {
+	err := session.LockUsers(ctx, m.redis, []string{uid}, fn)
+	if _, ok := err.(*msError.Error); err != nil && !ok {
+		logs.ErrorCtx(ctx, "lock user %s err: %v", uid, err)
+		return biz.Fail
+	}
+	return err
+}
+
+// lockUnion 持有联盟的锁执行fn，锁的顺序为联盟、用户
+func (m *Manager) lockUnion(ctx context.Context, unionId int64, fn func(ctx context.Context) error) error {
+	err := session.LockUnion(ctx, m.redis, unionId, fn)
+	if _, ok := err.(*msError.Error); err != nil && !ok {
+		logs.ErrorCtx(ctx, "lock union %d err: %v", unionId, err)
+		return biz.Fail
+	}
+	return err
+}
+
+func (m *Manager) checkMember(ctx context.Context, unionId int64, uid string) error {
+	_, err := m.members.Find(ctx, unionId, uid)
+	if err == repo.ErrNotFound {
+		return biz.NotInUnion
+	}
+	if err != nil {
+		logs.ErrorCtx(ctx, "find union %d member %s err: %v", unionId, uid, err)
+		return biz.SqlError
+	}
+	return nil
+}
+
+func (m *Manager) checkUnionLimit(ctx context.Context, unionId int64) error {
+	limit := config.Current().Room.UnionRoomLimit
+	if limit <= 0 {
+		return nil
+	}
+	n, err := m.rooms.CountByUnion(ctx, unionId)
+	if err != nil {
+		logs.ErrorCtx(ctx, "count union %d rooms err: %v", unionId, err)
+		return biz.SqlError
+	}
+	if n >= limit {
+		return biz.RoomCountReachLimit
+	}
+	return nil
+}
+
+// withProfiles 填充玩家的昵称、头像，获取失败时只返回uid
+func (m *Manager) withProfiles(ctx context.Context, info *Info) *Info {
+	uids := make([]string, 0, len(info.Players))
+	for _, p := range info.Players {
+		uids = append(uids, p.Uid)
+	}
+	profiles, err := m.profiles.GetMany(ctx, uids)
+	if err != nil {
+		logs.WarnCtx(ctx, "get room %s player profiles err: %v", info.RoomId, err)
+		return info
+	}
+	byUid := make(map[string]*entity.Profile, len(profiles))
+	for _, v := range profiles {
+		byUid[v.Uid] = v
+	}
+	for _, p := range info.Players {
+		if v, ok := byUid[p.Uid]; ok {
+			p.Nickname = v.Nickname
+			p.Avatar = v.Avatar
+		}
+	}
+	return info
+}
+
+func (m *Manager) push(ctx context.Context, uids []string, route string, data any) {
+	if len(uids) == 0 {
+		return
+	}
+	if err := session.Push(ctx, m.redis, uids, route, data); err != nil {
+		logs.WarnCtx(ctx, "push %s to %v err: %v", route, uids, err)
+	}
+}
+
+func toAny(rule map[string]int) map[string]any {
+	m := make(map[string]any, len(rule))
+	for k, v := range rule {
+		m[k] = v
+	}
+	return m
+}
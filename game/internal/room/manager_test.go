@@ -0,0 +1,440 @@
+package room
+
+import (
+	"common/biz"
+	"common/config"
+	"common/metrics"
+	"context"
+	"core/models/entity"
+	"core/repo"
+	"core/repo/repotest"
+	"core/session"
+	"game/internal/engine"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+const testNode = "127.0.0.1:11700"
+
+var testTiers = []config.TierConf{
+	{Level: 1, MinGold: 100, MaxGold: 1000, BaseBet: 1},
+	{Level: 2, MinGold: 500, MaxGold: 5000, BaseBet: 10},
+	{Level: 3, MinGold: 3000, BaseBet: 100},
+}
+
+// testLogic 测试用的玩法：第一个座位操作end后结束
+type testLogic struct{}
+
+func (testLogic) Deal(t *engine.Table) error {
+	t.Await(0, 0)
+	return nil
+}
+
+func (testLogic) Action(t *engine.Table, _ int, a *engine.Action) error {
+	if a.Type != "end" {
+		return biz.InvalidAction
+	}
+	t.End()
+	return nil
+}
+
+func (testLogic) AutoPlay(*engine.Table, int) *engine.Action {
+	return &engine.Action{Type: "end"}
+}
+
+func (testLogic) Settle(t *engine.Table) *engine.Result {
+	return &engine.Result{Scores: make([]int64, len(t.Seats))}
+}
+
+func (testLogic) View(*engine.Table, int) any {
+	return nil
+}
+
+func newTestManager(t *testing.T, uids ...string) (*Manager, *repo.Manager) {
+	t.Helper()
+	// 游戏类型4没有注册玩法
+	for _, gameType := range []int{1, 2} {
+		engine.Register(gameType, func(map[string]int, int) (engine.GameLogic, error) {
+			return testLogic{}, nil
+		})
+	}
+	config.Set(&config.Config{
+		Games: []config.GameConf{
+			{GameType: 1, Enable: true, Options: map[string][]int{"players": {3}, "rounds": {6, 12}}, Tiers: testTiers},
+			{GameType: 2, Enable: true, Options: map[string][]int{"players": {4, 2, 3}, "gps": {0, 1}}},
+			{GameType: 3, Enable: false, Options: map[string][]int{"players": {2}}},
+			{GameType: 4, Enable: true, Options: map[string][]int{"players": {2}}},
+		},
+		Room: config.RoomConf{UnionRoomLimit: 1, GpsRadius: 100},
+	})
+	manager := repotest.New(t)
+	for _, uid := range uids {
+		if err := manager.Users.Create(context.Background(), &entity.User{Uid: uid, Nickname: "n" + uid}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return NewManager(testNode, manager), manager
+}
+
+func roomIdOf(t *testing.T, manager *repo.Manager, uid string) string {
+	t.Helper()
+	user, err := manager.Users.FindByUid(context.Background(), uid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return user.RoomId
+}
+
+func TestParseRule(t *testing.T) {
+	newTestManager(t)
+	tests := []struct {
+		name     string
+		gameType int
+		options  map[string]int32
+		rule     map[string]int
+		players  int
+		wantErr  bool
+	}{
+		{"default", 1, nil, map[string]int{"players": 3, "rounds": 6}, 3, false},
+		{"choose", 2, map[string]int32{"players": 2}, map[string]int{"players": 2, "gps": 0}, 2, false},
+		{"value not allowed", 1, map[string]int32{"rounds": 7}, nil, 0, true},
+		{"unknown option", 1, map[string]int32{"bomb": 1}, nil, 0, true},
+		{"disabled game", 3, nil, nil, 0, true},
+		{"unknown game", 9, nil, nil, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, players, err := parseRule(tt.gameType, tt.options)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if players != tt.players || len(rule) != len(tt.rule) {
+				t.Fatalf("rule = %v, players = %d, want %v, %d", rule, players, tt.rule, tt.players)
+			}
+			for k, v := range tt.rule {
+				if rule[k] != v {
+					t.Fatalf("rule[%s] = %d, want %d", k, rule[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestManager_CreateJoinLeave(t *testing.T) {
+	ctx := context.Background()
+	m, manager := newTestManager(t, "u1", "u2", "u3", "u4")
+	info, err := m.Create(ctx, Client{Uid: "u1"}, 1, 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	roomId := info.RoomId
+	if len(roomId) != 6 || info.MaxPlayers != 3 || len(info.Players) != 1 || info.Players[0].Nickname != "nu1" {
+		t.Fatalf("create info = %+v", info)
+	}
+	if node, _ := session.RoomNode(ctx, manager.Redis, roomId); node != testNode {
+		t.Fatalf("room node = %q, want %q", node, testNode)
+	}
+	if _, err = m.Create(ctx, Client{Uid: "u1"}, 1, 0, nil); err != biz.UserInRoomDataLocked {
+		t.Fatalf("create twice err = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		uid     string
+		roomId  string
+		err     error
+		players int
+	}{
+		{"not exist", "u2", "000000", biz.RoomNotExist, 0},
+		{"join", "u2", roomId, nil, 2},
+		{"reconnect", "u2", roomId, nil, 2},
+		{"join full", "u3", roomId, nil, 3},
+		{"full", "u4", roomId, biz.RoomPlayerCountFull, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := m.Join(ctx, Client{Uid: tt.uid}, tt.roomId)
+			if err != tt.err {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+			if err == nil && len(info.Players) != tt.players {
+				t.Fatalf("players = %d, want %d", len(info.Players), tt.players)
+			}
+		})
+	}
+	if got := roomIdOf(t, manager, "u3"); got != roomId {
+		t.Fatalf("u3 roomId = %q, want %q", got, roomId)
+	}
+
+	if err = m.Ready(ctx, "u4", roomId, true); err != biz.NotInRoom {
+		t.Fatalf("ready not in room err = %v", err)
+	}
+	if err = m.Leave(ctx, "u2", roomId); err != nil {
+		t.Fatal(err)
+	}
+	if got := roomIdOf(t, manager, "u2"); got != "" {
+		t.Fatalf("u2 roomId after leave = %q", got)
+	}
+	// 离开后座位空出，其他人可以加入
+	info, err = m.Join(ctx, Client{Uid: "u4"}, roomId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range info.Players {
+		if p.Uid == "u4" && p.Seat != 1 {
+			t.Fatalf("u4 seat = %d, want 1", p.Seat)
+		}
+	}
+
+	// 所有人离开后房间解散
+	for _, uid := range []string{"u1", "u3", "u4"} {
+		if err = m.Leave(ctx, uid, roomId); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err = m.Join(ctx, Client{Uid: "u2"}, roomId); err != biz.RoomNotExist {
+		t.Fatalf("join dismissed room err = %v", err)
+	}
+	if node, _ := session.RoomNode(ctx, manager.Redis, roomId); node != "" {
+		t.Fatalf("dismissed room node = %q", node)
+	}
+	if _, err = manager.Rooms.FindByRoomId(ctx, roomId); err != repo.ErrNotFound {
+		t.Fatalf("dismissed room record err = %v", err)
+	}
+}
+
+func TestManager_LeavePlaying(t *testing.T) {
+	ctx := context.Background()
+	m, _ := newTestManager(t, "u1")
+	info, err := m.Create(ctx, Client{Uid: "u1"}, 2, 0, map[string]int32{"players": 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.get(info.RoomId).state = StatePlaying
+	if err = m.Leave(ctx, "u1", info.RoomId); err != biz.CanNotLeaveRoom {
+		t.Fatalf("leave playing err = %v", err)
+	}
+	if err = m.Ready(ctx, "u1", info.RoomId, true); err != biz.RequestDataError {
+		t.Fatalf("ready playing err = %v", err)
+	}
+}
+
+func TestManager_ExpiredRoom(t *testing.T) {
+	ctx := context.Background()
+	m, manager := newTestManager(t, "u1")
+	// 用户所在的房间已经没有节点映射（节点宕机），可以直接创建新房间
+	if err := manager.Users.SetRoomId(ctx, "u1", "", "123456"); err != nil {
+		t.Fatal(err)
+	}
+	info, err := m.Create(ctx, Client{Uid: "u1"}, 1, 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := roomIdOf(t, manager, "u1"); got != info.RoomId {
+		t.Fatalf("roomId = %q, want %q", got, info.RoomId)
+	}
+}
+
+func TestManager_UnionRoom(t *testing.T) {
+	ctx := context.Background()
+	m, manager := newTestManager(t, "owner", "member", "stranger")
+	for _, uid := range []string{"owner", "member"} {
+		if err := manager.UnionMembers.Create(ctx, &entity.UnionMember{UnionId: 100001, Uid: uid, Role: entity.UnionRolePlayer}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := m.Create(ctx, Client{Uid: "stranger"}, 1, 100001, nil); err != biz.NotInUnion {
+		t.Fatalf("stranger create err = %v", err)
+	}
+	info, err := m.Create(ctx, Client{Uid: "owner"}, 1, 100001, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = m.Join(ctx, Client{Uid: "stranger"}, info.RoomId); err != biz.NotInUnion {
+		t.Fatalf("stranger join err = %v", err)
+	}
+	if _, err = m.Join(ctx, Client{Uid: "member"}, info.RoomId); err != nil {
+		t.Fatal(err)
+	}
+	if err = m.Leave(ctx, "member", info.RoomId); err != nil {
+		t.Fatal(err)
+	}
+	// 联盟房间数量达到上限
+	if _, err = m.Create(ctx, Client{Uid: "member"}, 1, 100001, nil); err != biz.RoomCountReachLimit {
+		t.Fatalf("create over limit err = %v", err)
+	}
+	if got := roomIdOf(t, manager, "member"); got != "" {
+		t.Fatalf("member roomId = %q", got)
+	}
+}
+
+// 多个成员同时创建联盟房间时不会超过上限
+func TestManager_UnionRoomLimitConcurrent(t *testing.T) {
+	ctx := context.Background()
+	uids := []string{"m1", "m2", "m3", "m4", "m5"}
+	m, manager := newTestManager(t, uids...)
+	for _, uid := range uids {
+		if err := manager.UnionMembers.Create(ctx, &entity.UnionMember{UnionId: 100001, Uid: uid, Role: entity.UnionRolePlayer}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	errs := make([]error, len(uids))
+	var wg sync.WaitGroup
+	for i, uid := range uids {
+		wg.Add(1)
+		go func(i int, uid string) {
+			defer wg.Done()
+			_, errs[i] = m.Create(ctx, Client{Uid: uid}, 1, 100001, nil)
+		}(i, uid)
+	}
+	wg.Wait()
+	created := 0
+	for _, err := range errs {
+		switch err {
+		case nil:
+			created++
+		case biz.RoomCountReachLimit:
+		default:
+			t.Fatalf("create err = %v", err)
+		}
+	}
+	if created != 1 {
+		t.Fatalf("created = %d, want 1", created)
+	}
+	if n := testutil.ToFloat64(metrics.ActiveRooms.WithLabelValues(testNode)); n != 1 {
+		t.Fatalf("active rooms = %v, want 1", n)
+	}
+}
+
+func TestManager_Maintenance(t *testing.T) {
+	ctx := context.Background()
+	m, manager := newTestManager(t, "u1", "u2", "u3", "admin")
+	info, err := m.Create(ctx, Client{Uid: "u1"}, 1, 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = session.SetMaintenance(ctx, manager.Redis, &session.Maintenance{
+		StartTime: time.Now().UnixMilli(),
+		Message:   "停服更新",
+		Uids:      []string{"admin"},
+		Ips:       []string{"10.0.0.1"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = m.Create(ctx, Client{Uid: "u2"}, 1, 0, nil); err != biz.ServerMaintenance {
+		t.Fatalf("create err = %v", err)
+	}
+	if _, err = m.Join(ctx, Client{Uid: "u2"}, info.RoomId); err != biz.ServerMaintenance {
+		t.Fatalf("join err = %v", err)
+	}
+	if err = m.Ready(ctx, "u1", info.RoomId, true); err != biz.ServerMaintenance {
+		t.Fatalf("ready err = %v", err)
+	}
+	// 已经在房间中的玩家可以重连，白名单可以加入
+	if _, err = m.Join(ctx, Client{Uid: "u1"}, info.RoomId); err != nil {
+		t.Fatalf("reconnect err = %v", err)
+	}
+	if _, err = m.Join(ctx, Client{Uid: "u2", Ip: "10.0.0.1"}, info.RoomId); err != nil {
+		t.Fatalf("whitelisted ip join err = %v", err)
+	}
+	if _, err = m.Join(ctx, Client{Uid: "admin"}, info.RoomId); err != nil {
+		t.Fatalf("whitelisted uid join err = %v", err)
+	}
+	if err = session.CancelMaintenance(ctx, manager.Redis); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = m.Create(ctx, Client{Uid: "u3"}, 1, 0, nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestManager_Action(t *testing.T) {
+	ctx := context.Background()
+	m, _ := newTestManager(t, "u1", "u2")
+	info, err := m.Create(ctx, Client{Uid: "u1"}, 2, 0, map[string]int32{"players": 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	roomId := info.RoomId
+	if _, err = m.Join(ctx, Client{Uid: "u2"}, roomId); err != nil {
+		t.Fatal(err)
+	}
+	end := &engine.Action{Type: "end"}
+	if err = m.Action(ctx, "u1", roomId, end); err != biz.GameNotPlaying {
+		t.Fatalf("action before start err = %v", err)
+	}
+	for _, uid := range []string{"u1", "u2"} {
+		if err = m.Ready(ctx, uid, roomId, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+	room := m.get(roomId)
+	if room.state != StatePlaying || room.game.State() != engine.StatePlaying {
+		t.Fatalf("room state = %d, game state = %d", room.state, room.game.State())
+	}
+
+	tests := []struct {
+		name   string
+		uid    string
+		roomId string
+		a      *engine.Action
+		err    error
+	}{
+		{"room not exist", "u1", "000000", end, biz.RoomNotExist},
+		{"not in room", "u3", roomId, end, biz.NotInRoom},
+		{"not your turn", "u2", roomId, end, biz.NotYourTurn},
+		{"invalid", "u1", roomId, &engine.Action{Type: "pass"}, biz.InvalidAction},
+		{"end", "u1", roomId, end, nil},
+		{"finished", "u1", roomId, end, biz.GameNotPlaying},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := m.Action(ctx, tt.uid, tt.roomId, tt.a); err != tt.err {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+		})
+	}
+	// 一局结束后回到等待准备，可以离开房间
+	room.lock.Lock()
+	state, ready := room.state, room.player("u2").Ready
+	room.lock.Unlock()
+	if state != StateWaiting || ready {
+		t.Fatalf("room state = %d, u2 ready = %v after finish", state, ready)
+	}
+	if err = m.Leave(ctx, "u2", roomId); err != nil {
+		t.Fatal(err)
+	}
+	if err = m.Leave(ctx, "u1", roomId); err != nil {
+		t.Fatal(err)
+	}
+	if err = room.game.Action("u1", end); err != engine.ErrClosed {
+		t.Fatalf("game after dismiss err = %v", err)
+	}
+}
+
+func TestManager_NoLogic(t *testing.T) {
+	ctx := context.Background()
+	m, _ := newTestManager(t, "u1", "u2")
+	info, err := m.Create(ctx, Client{Uid: "u1"}, 4, 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = m.Join(ctx, Client{Uid: "u2"}, info.RoomId); err != nil {
+		t.Fatal(err)
+	}
+	if err = m.Ready(ctx, "u1", info.RoomId, true); err != nil {
+		t.Fatal(err)
+	}
+	// 没有玩法时不能开始，最后准备的玩家回到未准备
+	if err = m.Ready(ctx, "u2", info.RoomId, true); err != biz.RequestDataError {
+		t.Fatalf("ready err = %v", err)
+	}
+	room := m.get(info.RoomId)
+	if room.state != StateWaiting || room.player("u2").Ready || room.game != nil {
+		t.Fatalf("room state = %d", room.state)
+	}
+}
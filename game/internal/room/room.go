@@ -0,0 +1,179 @@
+package room
+
+import (
+	"game/internal/engine"
+	"sync"
+)
+
+// 房间状态
+const (
+	StateWaiting = iota // 等待玩家加入、准备
+	StatePlaying        // 游戏中，不能离开房间
+)
+
+// 推送给房间内其他玩家的路由
+const (
+	PushPlayerJoin  = "onRoomJoin"
+	PushPlayerLeave = "onRoomLeave"
+	PushPlayerReady = "onRoomReady"
+	PushRoomStart   = "onRoomStart"    // 所有玩家准备后开始一局
+	PushRoomStop    = "onRoomStop"     // 开始后发牌失败，回到等待准备
+	PushPlayerMoved = "onRoomMoved"    // 金币不符合场次被移到其他场次的房间
+	PushPlayerKick  = "onRoomKick"     // 金币不符合场次并且没有可以进入的场次
+	PushDistance    = "onRoomDistance" // 玩家加入、离开或者重连后定位变化时的距离矩阵
+)
+
+// Player 房间中的玩家，seat为座位号，从0开始
+type Player struct {
+	Uid      string
+	Seat     int
+	Ready    bool
+	Ip       string
+	Location *Location
+}
+
+// PlayerInfo 客户端展示的玩家信息
+type PlayerInfo struct {
+	Uid      string `json:"uid"`
+	Nickname string `json:"nickname"`
+	Avatar   string `json:"avatar"`
+	Seat     int    `json:"seat"`
+	Ready    bool   `json:"ready"`
+}
+
+// Info 房间信息的快照
+type Info struct {
+	RoomId     string         `json:"roomId"`
+	GameType   int            `json:"gameType"`
+	UnionId    int64          `json:"unionId"`
+	CreatorUid string         `json:"creatorUid"`
+	Rule       map[string]int `json:"rule"`
+	MaxPlayers int            `json:"maxPlayers"`
+	Tier       int            `json:"tier"`
+	BaseBet    int64          `json:"baseBet"`
+	Players    []*PlayerInfo  `json:"players"`
+	Distances  []*Distance    `json:"distances,omitempty"`
+}
+
+// Room 房间只存在于创建它的game节点的内存中，所有字段由lock保护
+type Room struct {
+	Id         string
+	GameType   int
+	UnionId    int64
+	CreatorUid string
+	Rule       map[string]int
+	MaxPlayers int
+	Tier       int   // 金币场场次，开房间为0
+	CreateTime int64 // 房间号会被复用，和房间号一起区分不同的房间
+
+	lock      sync.Mutex
+	seats     []*Player // 按座位号，空座位为nil
+	state     int
+	dismissed bool          // 已解散，等待从管理器中移除
+	game      *engine.Table // 第一次开始时创建，房间解散时关闭
+}
+
+func newRoom(id string, gameType int, unionId int64, creator string, rule map[string]int, maxPlayers int) *Room {
+	return &Room{
+		Id:         id,
+		GameType:   gameType,
+		UnionId:    unionId,
+		CreatorUid: creator,
+		Rule:       rule,
+		MaxPlayers: maxPlayers,
+		seats:      make([]*Player, maxPlayers),
+	}
+}
+
+func (r *Room) player(uid string) *Player {
+	for _, p := range r.seats {
+		if p != nil && p.Uid == uid {
+			return p
+		}
+	}
+	return nil
+}
+
+// sit 坐到第一个空座位，没有空座位时返回nil
+func (r *Room) sit(c Client) *Player {
+	for i, p := range r.seats {
+		if p == nil {
+			r.seats[i] = &Player{Uid: c.Uid, Seat: i, Ip: c.Ip, Location: c.Location}
+			return r.seats[i]
+		}
+	}
+	return nil
+}
+
+func (r *Room) stand(uid string) {
+	for i, p := range r.seats {
+		if p != nil && p.Uid == uid {
+			r.seats[i] = nil
+		}
+	}
+}
+
+func (r *Room) count() int {
+	n := 0
+	for _, p := range r.seats {
+		if p != nil {
+			n++
+		}
+	}
+	return n
+}
+
+// allReady 坐满并且所有玩家都已准备
+func (r *Room) allReady() bool {
+	for _, p := range r.seats {
+		if p == nil || !p.Ready {
+			return false
+		}
+	}
+	return true
+}
+
+// unready 一局结束或者无法开始时，所有玩家回到未准备状态
+func (r *Room) unready() {
+	r.state = StateWaiting
+	for _, p := range r.seats {
+		if p != nil {
+			p.Ready = false
+		}
+	}
+}
+
+// uids 房间中的玩家，except不为空时排除该玩家
+func (r *Room) uids(except string) []string {
+	uids := make([]string, 0, len(r.seats))
+	for _, p := range r.seats {
+		if p != nil && p.Uid != except {
+			uids = append(uids, p.Uid)
+		}
+	}
+	return uids
+}
+
+// snapshot 需要持有lock
+func (r *Room) snapshot() *Info {
+	info := &Info{
+		RoomId:     r.Id,
+		GameType:   r.GameType,
+		UnionId:    r.UnionId,
+		CreatorUid: r.CreatorUid,
+		Rule:       r.Rule,
+		MaxPlayers: r.MaxPlayers,
+		Tier:       r.Tier,
+		Players:    make([]*PlayerInfo, 0, len(r.seats)),
+	}
+	if tier, ok := findTier(r.GameType, r.Tier); ok && r.Tier != 0 {
+		info.BaseBet = tier.BaseBet
+	}
+	for _, p := range r.seats {
+		if p != nil {
+			info.Players = append(info.Players, &PlayerInfo{Uid: p.Uid, Seat: p.Seat, Ready: p.Ready})
+		}
+	}
+	info.Distances = r.distances()
+	return info
+}
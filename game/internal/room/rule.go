@@ -0,0 +1,62 @@
+package room
+
+import (
+	"common/biz"
+	"common/config"
+)
+
+// 规则选项中的房间人数
+const optionPlayers = "players"
+
+// parseRule 按配置中游戏的规则选项校验客户端传入的规则，未传的选项使用默认值，返回规则和房间人数
+func parseRule(gameType int, options map[string]int32) (map[string]int, int, error) {
+	game := findGame(gameType)
+	if game == nil {
+		return nil, 0, biz.RequestDataError
+	}
+	for key := range options {
+		if _, ok := game.Options[key]; !ok {
+			return nil, 0, biz.RequestDataError
+		}
+	}
+	rule := make(map[string]int, len(game.Options))
+	for key, values := range game.Options {
+		if len(values) == 0 {
+			continue
+		}
+		v, ok := options[key]
+		if !ok {
+			rule[key] = values[0]
+			continue
+		}
+		if !contains(values, int(v)) {
+			return nil, 0, biz.RequestDataError
+		}
+		rule[key] = int(v)
+	}
+	players := rule[optionPlayers]
+	if players < 2 {
+		return nil, 0, biz.RequestDataError
+	}
+	return rule, players, nil
+}
+
+// findGame 启用的游戏配置，配置在使用时读取，重新加载后立即生效
+func findGame(gameType int) *config.GameConf {
+	games := config.Current().Games
+	for i := range games {
+		if games[i].GameType == gameType && games[i].Enable {
+			return &games[i]
+		}
+	}
+	return nil
+}
+
+func contains(values []int, v int) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,243 @@
+package room
+
+import (
+	"common/logs"
+	"context"
+	"core/models/entity"
+	"core/repo"
+	"core/session"
+	"encoding/json"
+	"fmt"
+	"game/internal/engine"
+	"time"
+)
+
+// PushSettle 结算后每个座位实际变动的金币或积分
+const PushSettle = "onRoomSettle"
+
+// 结算写库的超时时间，在对局的协程中执行
+const settleTimeout = 10 * time.Second
+
+const (
+	settleIdempotencyTTL = 7 * 24 * time.Hour // 结算结果的保存时间，超过后失败的结算不再重试
+	settleRetryInterval  = time.Minute        // 检查失败结算的间隔
+	settleRetryMaxDelay  = time.Hour          // 每次重试失败后推迟的时间逐渐增加，最多推迟1小时
+	settleRetryBatch     = 100                // 每次最多重试的结算数量
+)
+
+// settled 结算后每个座位实际变动的金币或积分以及变动后的余额，重复结算时返回第一次的结果
+type settled struct {
+	Changes  []int64 `json:"changes"`
+	Balances []int64 `json:"balances"`
+}
+
+// settle 按本局的输赢分结算：金币场乘以场次底分变动金币，联盟房间变动联盟积分，每个玩家记一条流水
+// 普通房间只记录输赢分，不变动金币，写库失败时保存下来由Run定时重试
+func (m *Manager) settle(room *Room, result *engine.Result) {
+	var base int64 = 1
+	if room.Tier != 0 {
+		tier, ok := findTier(room.GameType, room.Tier)
+		if !ok {
+			logs.Error("room %s hand %d settle err: tier %d not found", room.Id, result.Hand, room.Tier)
+			return
+		}
+		base = tier.BaseBet
+	} else if room.UnionId == 0 {
+		return
+	}
+	amounts := make([]int64, len(result.Scores))
+	for i, v := range result.Scores {
+		amounts[i] = v * base
+	}
+	now := time.Now().UnixMilli()
+	s := &entity.Settlement{
+		Key:        fmt.Sprintf("%s:%d:%d", room.Id, room.CreateTime, result.Hand),
+		RoomId:     room.Id,
+		Hand:       result.Hand,
+		UnionId:    room.UnionId,
+		Uids:       result.Uids,
+		Amounts:    amounts,
+		CreateTime: now,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), settleTimeout)
+	defer cancel()
+	res, err := m.apply(ctx, s)
+	if err != nil {
+		logs.Error("room %s hand %d settle %v err: %v", room.Id, result.Hand, amounts, err)
+		s.LastError, s.NextTime = err.Error(), now+settleRetryInterval.Milliseconds()
+		if err = m.settlements.Create(ctx, s); err != nil && err != repo.ErrDuplicate {
+			logs.Error("room %s hand %d save settlement err: %v", room.Id, result.Hand, err)
+		}
+		return
+	}
+	m.pushSettle(ctx, s, res)
+}
+
+// retrySettlements 重试到期的失败结算，多个节点同时重试时由幂等key保证只结算一次
+func (m *Manager) retrySettlements(ctx context.Context) {
+	list, err := m.settlements.ListDue(ctx, time.Now().UnixMilli(), settleRetryBatch)
+	if err != nil {
+		logs.Error("list settlements err: %v", err)
+		return
+	}
+	for _, s := range list {
+		res, err := m.apply(ctx, s)
+		if err != nil {
+			delay := time.Duration(s.Retries+1) * settleRetryInterval
+			if delay > settleRetryMaxDelay {
+				delay = settleRetryMaxDelay
+			}
+			logs.Error("room %s hand %d retry settle %d err: %v", s.RoomId, s.Hand, s.Retries+1, err)
+			if err = m.settlements.Retry(ctx, s.Key, time.Now().Add(delay).UnixMilli(), err.Error()); err != nil {
+				logs.Error("room %s hand %d update settlement err: %v", s.RoomId, s.Hand, err)
+			}
+			continue
+		}
+		if err = m.settlements.Delete(ctx, s.Key); err != nil {
+			logs.Error("room %s hand %d delete settlement err: %v", s.RoomId, s.Hand, err)
+		}
+		m.pushSettle(ctx, s, res)
+	}
+}
+
+// apply 持有玩家的锁在事务中读取余额并变动，和其他节点上对同一玩家金币、积分的变动串行执行
+// 同一局只会执行一次，已经结算过时返回第一次的结果
+func (m *Manager) apply(ctx context.Context, s *entity.Settlement) (*settled, error) {
+	data, err := m.settled.Do(ctx, s.Key, func(ctx context.Context) (string, error) {
+		res := &settled{}
+		err := m.lockPlayers(ctx, s.UnionId, s.Uids, func(ctx context.Context) error {
+			return m.tx.WithTransaction(ctx, func(ctx context.Context) error {
+				var err error
+				res.Balances, err = m.balances(ctx, s.UnionId, s.Uids)
+				if err != nil {
+					return err
+				}
+				res.Changes = payout(s.Amounts, res.Balances)
+				return m.transfer(ctx, s, res)
+			})
+		})
+		if err != nil {
+			return "", err
+		}
+		data, err := json.Marshal(res)
+		return string(data), err
+	})
+	if err != nil {
+		return nil, err
+	}
+	res := &settled{}
+	if err = json.Unmarshal([]byte(data), res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// transfer 变动金币或积分并记流水，更新res中的余额，需要在事务中执行
+func (m *Manager) transfer(ctx context.Context, s *entity.Settlement, res *settled) error {
+	remark := fmt.Sprintf("房间%s第%d局", s.RoomId, s.Hand)
+	now := time.Now().UnixMilli()
+	for i, uid := range s.Uids {
+		if res.Changes[i] == 0 {
+			continue
+		}
+		typ := entity.LedgerGameGold
+		var err error
+		if s.UnionId != 0 {
+			typ = entity.LedgerGameScore
+			res.Balances[i], err = m.members.IncrScore(ctx, s.UnionId, uid, res.Changes[i])
+		} else {
+			res.Balances[i], err = m.users.IncrGold(ctx, uid, res.Changes[i])
+		}
+		if err != nil {
+			return err
+		}
+		err = m.ledgers.Insert(ctx, &entity.Ledger{
+			Uid:        uid,
+			UnionId:    s.UnionId,
+			Type:       typ,
+			Amount:     res.Changes[i],
+			Balance:    res.Balances[i],
+			Remark:     remark,
+			CreateTime: now,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *Manager) pushSettle(ctx context.Context, s *entity.Settlement, res *settled) {
+	logs.Info("room %s hand %d settled, changes: %v", s.RoomId, s.Hand, res.Changes)
+	m.push(ctx, s.Uids, PushSettle, map[string]any{
+		"roomId":   s.RoomId,
+		"hand":     s.Hand,
+		"changes":  res.Changes,
+		"balances": res.Balances,
+	})
+}
+
+// lockPlayers 金币场锁住玩家，联盟房间锁住玩家的联盟成员信息
+func (m *Manager) lockPlayers(ctx context.Context, unionId int64, uids []string, fn func(ctx context.Context) error) error {
+	if unionId != 0 {
+		return session.LockMembers(ctx, m.redis, unionId, uids, fn)
+	}
+	return session.LockUsers(ctx, m.redis, uids, fn)
+}
+
+// balances 玩家当前的金币或联盟积分
+func (m *Manager) balances(ctx context.Context, unionId int64, uids []string) ([]int64, error) {
+	balances := make([]int64, len(uids))
+	for i, uid := range uids {
+		if unionId != 0 {
+			member, err := m.members.Find(ctx, unionId, uid)
+			if err != nil {
+				return nil, err
+			}
+			balances[i] = member.Score
+			continue
+		}
+		user, err := m.users.FindByUid(ctx, uid)
+		if err != nil {
+			return nil, err
+		}
+		balances[i] = user.Gold
+	}
+	return balances, nil
+}
+
+// payout 输家最多输掉全部余额，赢家按输赢分的比例分配输家实际支付的总额，保证总和为0
+// 按比例分配的余数给第一个赢家
+func payout(amounts, balances []int64) []int64 {
+	changes := make([]int64, len(amounts))
+	var paid, won int64
+	for i, v := range amounts {
+		if v > 0 {
+			won += v
+			continue
+		}
+		pay := -v
+		if pay > balances[i] {
+			pay = balances[i]
+		}
+		changes[i] = -pay
+		paid += pay
+	}
+	if won == 0 || paid == 0 {
+		return make([]int64, len(amounts))
+	}
+	first := -1
+	var given int64
+	for i, v := range amounts {
+		if v <= 0 {
+			continue
+		}
+		if first < 0 {
+			first = i
+		}
+		changes[i] = v * paid / won
+		given += changes[i]
+	}
+	changes[first] += paid - given
+	return changes
+}
@@ -0,0 +1,148 @@
+package room
+
+import (
+	"context"
+	"core/models/entity"
+	"game/internal/engine"
+	"testing"
+	"time"
+)
+
+func TestPayout(t *testing.T) {
+	tests := []struct {
+		name     string
+		amounts  []int64
+		balances []int64
+		want     []int64
+	}{
+		{"enough", []int64{20, -10, -10}, []int64{0, 100, 100}, []int64{20, -10, -10}},
+		{"loser capped", []int64{20, -10, -10}, []int64{0, 100, 5}, []int64{15, -10, -5}},
+		{"winners share", []int64{10, 10, -20}, []int64{0, 0, 5}, []int64{3, 2, -5}},
+		{"remainder to first winner", []int64{1, 1, -2}, []int64{0, 0, 1}, []int64{1, 0, -1}},
+		{"loser broke", []int64{-2, 1, 1}, []int64{0, 50, 50}, []int64{0, 0, 0}},
+		{"draw", []int64{0, 0, 0}, []int64{10, 10, 10}, []int64{0, 0, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := payout(tt.amounts, tt.balances)
+			var sum int64
+			for i := range got {
+				sum += got[i]
+				if got[i] != tt.want[i] {
+					t.Fatalf("payout = %v, want %v", got, tt.want)
+				}
+			}
+			if sum != 0 {
+				t.Fatalf("payout sum = %d", sum)
+			}
+		})
+	}
+}
+
+func TestManager_Settle(t *testing.T) {
+	ctx := context.Background()
+	m, manager := newTestManager(t, "u1", "u2", "u3")
+	for uid, gold := range map[string]int64{"u1": 1000, "u2": 1000, "u3": 5} {
+		if _, err := manager.Users.IncrGold(ctx, uid, gold); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// 中级场底分10，u3只能输掉全部的5金币
+	room := newRoom("123456", 1, 0, "u1", nil, 3)
+	room.Tier = 2
+	m.settle(room, &engine.Result{Hand: 1, Uids: []string{"u1", "u2", "u3"}, Scores: []int64{2, -1, -1}})
+	for uid, gold := range map[string]int64{"u1": 1015, "u2": 990, "u3": 0} {
+		user, err := manager.Users.FindByUid(ctx, uid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if user.Gold != gold {
+			t.Fatalf("%s gold = %d, want %d", uid, user.Gold, gold)
+		}
+	}
+	ledgers, err := manager.Ledgers.ListByUid(ctx, "u3", 0, 0, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ledgers) != 1 || ledgers[0].Type != entity.LedgerGameGold || ledgers[0].Amount != -5 || ledgers[0].Balance != 0 {
+		t.Fatalf("u3 ledgers = %+v", ledgers)
+	}
+
+	// 联盟房间变动联盟积分
+	for uid, score := range map[string]int64{"u1": 100, "u2": 0} {
+		err = manager.UnionMembers.Create(ctx, &entity.UnionMember{UnionId: 100001, Uid: uid, Role: entity.UnionRolePlayer, Score: score})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	room = newRoom("654321", 1, 100001, "u1", nil, 2)
+	m.settle(room, &engine.Result{Hand: 1, Uids: []string{"u1", "u2"}, Scores: []int64{-3, 3}})
+	member, err := manager.UnionMembers.Find(ctx, 100001, "u2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if member.Score != 3 {
+		t.Fatalf("u2 score = %d, want 3", member.Score)
+	}
+	ledgers, err = manager.Ledgers.ListByUid(ctx, "u2", 100001, 0, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ledgers) != 1 || ledgers[0].Type != entity.LedgerGameScore || ledgers[0].Amount != 3 {
+		t.Fatalf("u2 ledgers = %+v", ledgers)
+	}
+
+	// 普通房间不变动金币
+	room = newRoom("111111", 1, 0, "u1", nil, 2)
+	m.settle(room, &engine.Result{Hand: 1, Uids: []string{"u1", "u2"}, Scores: []int64{-3, 3}})
+	if ledgers, _ = manager.Ledgers.ListByUid(ctx, "u2", 0, 0, 10); len(ledgers) != 1 {
+		t.Fatalf("u2 gold ledgers = %d", len(ledgers))
+	}
+}
+
+// 写库失败的结算保存下来重试，同一局只结算一次
+func TestManager_SettleRetry(t *testing.T) {
+	ctx := context.Background()
+	m, manager := newTestManager(t, "u1", "u2")
+	if err := manager.UnionMembers.Create(ctx, &entity.UnionMember{UnionId: 100001, Uid: "u1", Role: entity.UnionRolePlayer, Score: 100}); err != nil {
+		t.Fatal(err)
+	}
+	// u2还不是联盟成员，结算失败
+	room := newRoom("654321", 1, 100001, "u1", nil, 2)
+	m.settle(room, &engine.Result{Hand: 1, Uids: []string{"u1", "u2"}, Scores: []int64{-3, 3}})
+	list, err := manager.Settlements.ListDue(ctx, time.Now().Add(time.Hour).UnixMilli(), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 1 || list[0].Key != "654321:0:1" || list[0].LastError == "" {
+		t.Fatalf("settlements = %+v", list)
+	}
+
+	if err = manager.UnionMembers.Create(ctx, &entity.UnionMember{UnionId: 100001, Uid: "u2", Role: entity.UnionRolePlayer}); err != nil {
+		t.Fatal(err)
+	}
+	if err = manager.Settlements.Retry(ctx, list[0].Key, 0, list[0].LastError); err != nil {
+		t.Fatal(err)
+	}
+	m.retrySettlements(ctx)
+	if list, _ = manager.Settlements.ListDue(ctx, time.Now().Add(time.Hour).UnixMilli(), 10); len(list) != 0 {
+		t.Fatalf("settlements after retry = %+v", list)
+	}
+	// 重复结算返回第一次的结果，不会再次变动积分
+	res, err := m.apply(ctx, &entity.Settlement{Key: "654321:0:1", RoomId: "654321", Hand: 1, UnionId: 100001, Uids: []string{"u1", "u2"}, Amounts: []int64{-3, 3}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Changes[1] != 3 || res.Balances[1] != 3 {
+		t.Fatalf("settled = %+v", res)
+	}
+	for uid, score := range map[string]int64{"u1": 97, "u2": 3} {
+		member, err := manager.UnionMembers.Find(ctx, 100001, uid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if member.Score != score {
+			t.Fatalf("%s score = %d, want %d", uid, member.Score, score)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package room
+
+import (
+	"common/biz"
+	"common/config"
+)
+
+// findTier 游戏的金币场场次，不存在时返回false
+func findTier(gameType, level int) (config.TierConf, bool) {
+	game := findGame(gameType)
+	if game == nil {
+		return config.TierConf{}, false
+	}
+	for _, tier := range game.Tiers {
+		if tier.Level == level {
+			return tier, true
+		}
+	}
+	return config.TierConf{}, false
+}
+
+// matchTier 金币可以进入的场次，有多个时选择最高的场次
+func matchTier(gameType int, gold int64) (config.TierConf, error) {
+	game := findGame(gameType)
+	if game == nil || len(game.Tiers) == 0 {
+		return config.TierConf{}, biz.RequestDataError
+	}
+	var (
+		best  config.TierConf
+		found bool
+		poor  = true // 金币低于所有场次的下限
+	)
+	for _, tier := range game.Tiers {
+		err := checkGold(tier, gold)
+		if err == nil && (!found || tier.Level > best.Level) {
+			best, found = tier, true
+		}
+		if err != biz.LeaveRoomGoldNotEnoughLimit {
+			poor = false
+		}
+	}
+	if found {
+		return best, nil
+	}
+	if poor {
+		return config.TierConf{}, biz.LeaveRoomGoldNotEnoughLimit
+	}
+	return config.TierConf{}, biz.LeaveRoomGoldExceedLimit
+}
+
+// checkGold 金币是否在场次的限制之内
+func checkGold(tier config.TierConf, gold int64) error {
+	if gold < tier.MinGold {
+		return biz.LeaveRoomGoldNotEnoughLimit
+	}
+	if tier.MaxGold > 0 && gold > tier.MaxGold {
+		return biz.LeaveRoomGoldExceedLimit
+	}
+	return nil
+}
+
+// chooseTier level为0时按金币自动选择场次，否则检查金币是否符合指定的场次
+func chooseTier(gameType, level int, gold int64) (config.TierConf, error) {
+	if level == 0 {
+		return matchTier(gameType, gold)
+	}
+	tier, ok := findTier(gameType, level)
+	if !ok {
+		return config.TierConf{}, biz.RequestDataError
+	}
+	return tier, checkGold(tier, gold)
+}
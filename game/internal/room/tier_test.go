@@ -0,0 +1,194 @@
+package room
+
+import (
+	"common/biz"
+	"context"
+	"core/session"
+	"testing"
+)
+
+func TestChooseTier(t *testing.T) {
+	newTestManager(t)
+	tests := []struct {
+		name  string
+		level int
+		gold  int64
+		want  int
+		err   error
+	}{
+		{"auto beginner", 0, 100, 1, nil},
+		{"auto highest", 0, 800, 2, nil},
+		{"auto no max", 0, 1000000, 3, nil},
+		{"auto not enough", 0, 99, 0, biz.LeaveRoomGoldNotEnoughLimit},
+		{"choose", 1, 800, 1, nil},
+		{"choose not enough", 3, 2999, 3, biz.LeaveRoomGoldNotEnoughLimit},
+		{"choose exceed", 1, 1001, 1, biz.LeaveRoomGoldExceedLimit},
+		{"unknown tier", 4, 1000, 0, biz.RequestDataError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tier, err := chooseTier(1, tt.level, tt.gold)
+			if err != tt.err {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+			if tier.Level != tt.want {
+				t.Fatalf("level = %d, want %d", tier.Level, tt.want)
+			}
+		})
+	}
+	if _, err := chooseTier(2, 0, 1000); err != biz.RequestDataError {
+		t.Fatalf("game without tiers err = %v", err)
+	}
+}
+
+func TestManager_Match(t *testing.T) {
+	ctx := context.Background()
+	m, manager := newTestManager(t, "u1", "u2", "u3", "u4", "poor")
+	golds := map[string]int64{"u1": 200, "u2": 300, "u3": 400, "u4": 1200}
+	for uid, gold := range golds {
+		if _, err := manager.Users.IncrGold(ctx, uid, gold); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := m.Match(ctx, Client{Uid: "poor"}, 1, 0); err != biz.LeaveRoomGoldNotEnoughLimit {
+		t.Fatalf("poor match err = %v", err)
+	}
+	info, err := m.Match(ctx, Client{Uid: "u1"}, 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Tier != 1 || info.BaseBet != 1 {
+		t.Fatalf("match info = %+v", info)
+	}
+	roomId := info.RoomId
+	// 同场次加入已有的房间，重复匹配返回所在的房间
+	for _, uid := range []string{"u2", "u2"} {
+		if info, err = m.Match(ctx, Client{Uid: uid}, 1, 0); err != nil || info.RoomId != roomId {
+			t.Fatalf("%s match room = %v, err = %v", uid, info, err)
+		}
+	}
+	// 其他场次不会进入初级场的房间，直接通过房间号加入时检查金币
+	if info, err = m.Match(ctx, Client{Uid: "u4"}, 1, 0); err != nil || info.RoomId == roomId || info.Tier != 2 {
+		t.Fatalf("u4 match room = %v, err = %v", info, err)
+	}
+	if err = m.Leave(ctx, "u4", info.RoomId); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = m.Join(ctx, Client{Uid: "u4"}, roomId); err != biz.LeaveRoomGoldExceedLimit {
+		t.Fatalf("u4 join beginner room err = %v", err)
+	}
+	if _, err = m.Join(ctx, Client{Uid: "u3"}, roomId); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestManager_StartMovesPlayers(t *testing.T) {
+	ctx := context.Background()
+	m, manager := newTestManager(t, "u1", "u2", "u3")
+	for _, uid := range []string{"u1", "u2", "u3"} {
+		if _, err := manager.Users.IncrGold(ctx, uid, 200); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var roomId string
+	for _, uid := range []string{"u1", "u2", "u3"} {
+		info, err := m.Match(ctx, Client{Uid: uid}, 1, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		roomId = info.RoomId
+	}
+	// 开始前u3的金币超过了初级场的上限，被移到中级场
+	if _, err := manager.Users.IncrGold(ctx, "u3", 1000); err != nil {
+		t.Fatal(err)
+	}
+	for _, uid := range []string{"u1", "u2", "u3"} {
+		if err := m.Ready(ctx, uid, roomId, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+	room := m.get(roomId)
+	if room.state != StateWaiting || room.count() != 2 || room.player("u3") != nil {
+		t.Fatalf("room state = %d, players = %v", room.state, room.uids(""))
+	}
+	moved := m.get(roomIdOf(t, manager, "u3"))
+	if moved == nil || moved.Tier != 2 {
+		t.Fatalf("u3 moved to %v", moved)
+	}
+
+	// 金币符合时开始
+	if _, err := manager.Users.IncrGold(ctx, "u3", -1000); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Leave(ctx, "u3", moved.Id); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Join(ctx, Client{Uid: "u3"}, roomId); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Ready(ctx, "u3", roomId, true); err != nil {
+		t.Fatal(err)
+	}
+	if room.state != StatePlaying {
+		t.Fatalf("room state = %d, want playing", room.state)
+	}
+}
+
+// testForwarder 按节点地址转发到同一进程中的其他管理器
+type testForwarder map[string]*Manager
+
+func (f testForwarder) Join(ctx context.Context, node string, c Client, roomId string) (*Info, error) {
+	return f[node].Join(ctx, c, roomId)
+}
+
+func TestManager_MatchRemote(t *testing.T) {
+	ctx := context.Background()
+	m1, manager := newTestManager(t, "u1", "u2", "u3", "u4")
+	for _, uid := range []string{"u1", "u2", "u3", "u4"} {
+		if _, err := manager.Users.IncrGold(ctx, uid, 200); err != nil {
+			t.Fatal(err)
+		}
+	}
+	m2 := NewManager("node2", manager)
+	forwarder := testForwarder{testNode: m1, "node2": m2}
+	m1.SetForwarder(forwarder)
+	m2.SetForwarder(forwarder)
+
+	info, err := m1.Match(ctx, Client{Uid: "u1"}, 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	roomId := info.RoomId
+	// 其他节点匹配到本节点等待中的房间
+	for _, uid := range []string{"u2", "u3"} {
+		if info, err = m2.Match(ctx, Client{Uid: uid}, 1, 0); err != nil || info.RoomId != roomId || info.Tier != 1 {
+			t.Fatalf("%s match room = %+v, err = %v", uid, info, err)
+		}
+	}
+	if len(m2.roomIds()) != 0 {
+		t.Fatalf("node2 rooms = %v", m2.roomIds())
+	}
+	// 房间满员后从等待索引中移除，再匹配时创建新的房间
+	waiting, err := session.WaitingRooms(ctx, manager.Redis, 1, 1, 10)
+	if err != nil || len(waiting) != 0 {
+		t.Fatalf("waiting rooms = %v, err = %v", waiting, err)
+	}
+	if info, err = m2.Match(ctx, Client{Uid: "u4"}, 1, 0); err != nil || info.RoomId == roomId || m2.get(info.RoomId) == nil {
+		t.Fatalf("u4 match room = %+v, err = %v", info, err)
+	}
+	// 有玩家离开后重新可以匹配到，解散后移除
+	if err = m1.Leave(ctx, "u3", roomId); err != nil {
+		t.Fatal(err)
+	}
+	if waiting, _ = session.WaitingRooms(ctx, manager.Redis, 1, 1, 10); len(waiting) != 2 {
+		t.Fatalf("waiting rooms = %v", waiting)
+	}
+	for _, uid := range []string{"u1", "u2"} {
+		if err = m1.Leave(ctx, uid, roomId); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if waiting, _ = session.WaitingRooms(ctx, manager.Redis, 1, 1, 10); len(waiting) != 1 || waiting[0] != info.RoomId {
+		t.Fatalf("waiting rooms = %v", waiting)
+	}
+}
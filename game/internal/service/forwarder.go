@@ -0,0 +1,66 @@
+package service
+
+import (
+	"common/msError"
+	"common/rpc"
+	"context"
+	"game/internal/room"
+	"game/pb"
+)
+
+// Forwarder 匹配到其他节点上的房间时，直连房间所在的节点加入房间
+type Forwarder struct{}
+
+func NewForwarder() *Forwarder {
+	return &Forwarder{}
+}
+
+// Join 在node上加入房间，grpc错误转换回业务错误，匹配时按错误码判断是否尝试下一个房间
+func (f *Forwarder) Join(ctx context.Context, node string, c room.Client, roomId string) (*room.Info, error) {
+	client, err := rpc.GameNode(node)
+	if err != nil {
+		return nil, err
+	}
+	req := &pb.JoinRoomParams{Uid: c.Uid, Ip: c.Ip, RoomId: roomId}
+	if c.Location != nil {
+		req.Location = &pb.Location{Latitude: c.Location.Latitude, Longitude: c.Location.Longitude}
+	}
+	res, err := client.JoinRoom(ctx, req)
+	if err != nil {
+		return nil, msError.ToError(err)
+	}
+	return fromRoomInfo(res.Room), nil
+}
+
+func fromRoomInfo(info *pb.RoomInfo) *room.Info {
+	rule := make(map[string]int, len(info.Rule))
+	for k, v := range info.Rule {
+		rule[k] = int(v)
+	}
+	players := make([]*room.PlayerInfo, 0, len(info.Players))
+	for _, p := range info.Players {
+		players = append(players, &room.PlayerInfo{
+			Uid:      p.Uid,
+			Nickname: p.Nickname,
+			Avatar:   p.Avatar,
+			Seat:     int(p.Seat),
+			Ready:    p.Ready,
+		})
+	}
+	var distances []*room.Distance
+	for _, d := range info.Distances {
+		distances = append(distances, &room.Distance{Uid1: d.Uid1, Uid2: d.Uid2, Distance: d.Distance, SameIp: d.SameIp})
+	}
+	return &room.Info{
+		RoomId:     info.RoomId,
+		GameType:   int(info.GameType),
+		UnionId:    info.UnionId,
+		CreatorUid: info.CreatorUid,
+		Rule:       rule,
+		MaxPlayers: int(info.MaxPlayers),
+		Tier:       int(info.Tier),
+		BaseBet:    info.BaseBet,
+		Players:    players,
+		Distances:  distances,
+	}
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"common/biz"
+	"common/msError"
+	"context"
+	"encoding/json"
+	"game/internal/engine"
+	"game/internal/room"
+	"game/pb"
+)
+
+// GameService 房间的创建、加入、离开和准备，房间只存在于创建它的节点上
+// 加入、离开、准备需要由connector按房间号转发到房间所在的节点
+type GameService struct {
+	pb.UnimplementedGameServiceServer
+	rooms *room.Manager
+}
+
+func NewGameService(rooms *room.Manager) *GameService {
+	return &GameService{rooms: rooms}
+}
+
+// CreateRoom 创建房间，rule中未传的选项使用配置中的默认值
+func (g *GameService) CreateRoom(ctx context.Context, req *pb.CreateRoomParams) (*pb.CreateRoomResponse, error) {
+	info, err := g.rooms.Create(ctx, client(req.Uid, req.Ip, req.Location), int(req.GameType), req.UnionId, req.Rule)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &pb.CreateRoomResponse{Room: toRoomInfo(info)}, nil
+}
+
+// JoinRoom 加入房间，已在房间中时返回房间信息用于断线重连
+func (g *GameService) JoinRoom(ctx context.Context, req *pb.JoinRoomParams) (*pb.JoinRoomResponse, error) {
+	info, err := g.rooms.Join(ctx, client(req.Uid, req.Ip, req.Location), req.RoomId)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &pb.JoinRoomResponse{Room: toRoomInfo(info)}, nil
+}
+
+// MatchRoom 金币场匹配，金币不符合场次时返回LeaveRoomGoldNotEnoughLimit或LeaveRoomGoldExceedLimit
+func (g *GameService) MatchRoom(ctx context.Context, req *pb.MatchRoomParams) (*pb.MatchRoomResponse, error) {
+	info, err := g.rooms.Match(ctx, client(req.Uid, req.Ip, req.Location), int(req.GameType), int(req.Tier))
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &pb.MatchRoomResponse{Room: toRoomInfo(info)}, nil
+}
+
+// LeaveRoom 离开房间
+func (g *GameService) LeaveRoom(ctx context.Context, req *pb.LeaveRoomParams) (*pb.LeaveRoomResponse, error) {
+	if err := g.rooms.Leave(ctx, req.Uid, req.RoomId); err != nil {
+		return nil, grpcError(err)
+	}
+	return &pb.LeaveRoomResponse{}, nil
+}
+
+// RoomReady 准备、取消准备
+func (g *GameService) RoomReady(ctx context.Context, req *pb.RoomReadyParams) (*pb.RoomReadyResponse, error) {
+	if err := g.rooms.Ready(ctx, req.Uid, req.RoomId, req.Ready); err != nil {
+		return nil, grpcError(err)
+	}
+	return &pb.RoomReadyResponse{}, nil
+}
+
+// RoomAction 对局中的操作
+func (g *GameService) RoomAction(ctx context.Context, req *pb.RoomActionParams) (*pb.RoomActionResponse, error) {
+	if req.Type == "" {
+		return nil, msError.GrpcError(biz.RequestDataError)
+	}
+	action := &engine.Action{Type: req.Type}
+	if req.Data != "" {
+		action.Data = json.RawMessage(req.Data)
+	}
+	if err := g.rooms.Action(ctx, req.Uid, req.RoomId, action); err != nil {
+		return nil, grpcError(err)
+	}
+	return &pb.RoomActionResponse{}, nil
+}
+
+// grpcError 房间管理器返回的都是业务错误
+func grpcError(err error) error {
+	if e, ok := err.(*msError.Error); ok {
+		return msError.GrpcError(e)
+	}
+	return msError.GrpcError(biz.Fail)
+}
+
+// client 进入房间的玩家，定位无效时视为没有定位
+func client(uid, ip string, location *pb.Location) room.Client {
+	c := room.Client{Uid: uid, Ip: ip}
+	if location != nil {
+		l := &room.Location{Latitude: location.Latitude, Longitude: location.Longitude}
+		if l.Valid() {
+			c.Location = l
+		}
+	}
+	return c
+}
+
+func toRoomInfo(info *room.Info) *pb.RoomInfo {
+	rule := make(map[string]int32, len(info.Rule))
+	for k, v := range info.Rule {
+		rule[k] = int32(v)
+	}
+	players := make([]*pb.RoomPlayer, 0, len(info.Players))
+	for _, p := range info.Players {
+		players = append(players, &pb.RoomPlayer{
+			Uid:      p.Uid,
+			Nickname: p.Nickname,
+			Avatar:   p.Avatar,
+			Seat:     int32(p.Seat),
+			Ready:    p.Ready,
+		})
+	}
+	distances := make([]*pb.RoomDistance, 0, len(info.Distances))
+	for _, d := range info.Distances {
+		distances = append(distances, &pb.RoomDistance{Uid1: d.Uid1, Uid2: d.Uid2, Distance: d.Distance, SameIp: d.SameIp})
+	}
+	return &pb.RoomInfo{
+		RoomId:     info.RoomId,
+		GameType:   int32(info.GameType),
+		UnionId:    info.UnionId,
+		CreatorUid: info.CreatorUid,
+		Rule:       rule,
+		MaxPlayers: int32(info.MaxPlayers),
+		Players:    players,
+		Tier:       int32(info.Tier),
+		BaseBet:    info.BaseBet,
+		Distances:  distances,
+	}
+}
@@ -0,0 +1,294 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.25.3
+// source: game.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	GameService_CreateRoom_FullMethodName = "/GameService/CreateRoom"
+	GameService_JoinRoom_FullMethodName   = "/GameService/JoinRoom"
+	GameService_MatchRoom_FullMethodName  = "/GameService/MatchRoom"
+	GameService_LeaveRoom_FullMethodName  = "/GameService/LeaveRoom"
+	GameService_RoomReady_FullMethodName  = "/GameService/RoomReady"
+	GameService_RoomAction_FullMethodName = "/GameService/RoomAction"
+)
+
+// GameServiceClient is the client API for GameService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type GameServiceClient interface {
+	CreateRoom(ctx context.Context, in *CreateRoomParams, opts ...grpc.CallOption) (*CreateRoomResponse, error)
+	JoinRoom(ctx context.Context, in *JoinRoomParams, opts ...grpc.CallOption) (*JoinRoomResponse, error)
+	MatchRoom(ctx context.Context, in *MatchRoomParams, opts ...grpc.CallOption) (*MatchRoomResponse, error)
+	LeaveRoom(ctx context.Context, in *LeaveRoomParams, opts ...grpc.CallOption) (*LeaveRoomResponse, error)
+	RoomReady(ctx context.Context, in *RoomReadyParams, opts ...grpc.CallOption) (*RoomReadyResponse, error)
+	RoomAction(ctx context.Context, in *RoomActionParams, opts ...grpc.CallOption) (*RoomActionResponse, error)
+}
+
+type gameServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGameServiceClient(cc grpc.ClientConnInterface) GameServiceClient {
+	return &gameServiceClient{cc}
+}
+
+func (c *gameServiceClient) CreateRoom(ctx context.Context, in *CreateRoomParams, opts ...grpc.CallOption) (*CreateRoomResponse, error) {
+	out := new(CreateRoomResponse)
+	err := c.cc.Invoke(ctx, GameService_CreateRoom_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameServiceClient) JoinRoom(ctx context.Context, in *JoinRoomParams, opts ...grpc.CallOption) (*JoinRoomResponse, error) {
+	out := new(JoinRoomResponse)
+	err := c.cc.Invoke(ctx, GameService_JoinRoom_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameServiceClient) MatchRoom(ctx context.Context, in *MatchRoomParams, opts ...grpc.CallOption) (*MatchRoomResponse, error) {
+	out := new(MatchRoomResponse)
+	err := c.cc.Invoke(ctx, GameService_MatchRoom_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameServiceClient) LeaveRoom(ctx context.Context, in *LeaveRoomParams, opts ...grpc.CallOption) (*LeaveRoomResponse, error) {
+	out := new(LeaveRoomResponse)
+	err := c.cc.Invoke(ctx, GameService_LeaveRoom_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameServiceClient) RoomReady(ctx context.Context, in *RoomReadyParams, opts ...grpc.CallOption) (*RoomReadyResponse, error) {
+	out := new(RoomReadyResponse)
+	err := c.cc.Invoke(ctx, GameService_RoomReady_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameServiceClient) RoomAction(ctx context.Context, in *RoomActionParams, opts ...grpc.CallOption) (*RoomActionResponse, error) {
+	out := new(RoomActionResponse)
+	err := c.cc.Invoke(ctx, GameService_RoomAction_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GameServiceServer is the server API for GameService service.
+// All implementations must embed UnimplementedGameServiceServer
+// for forward compatibility
+type GameServiceServer interface {
+	CreateRoom(context.Context, *CreateRoomParams) (*CreateRoomResponse, error)
+	JoinRoom(context.Context, *JoinRoomParams) (*JoinRoomResponse, error)
+	MatchRoom(context.Context, *MatchRoomParams) (*MatchRoomResponse, error)
+	LeaveRoom(context.Context, *LeaveRoomParams) (*LeaveRoomResponse, error)
+	RoomReady(context.Context, *RoomReadyParams) (*RoomReadyResponse, error)
+	RoomAction(context.Context, *RoomActionParams) (*RoomActionResponse, error)
+	mustEmbedUnimplementedGameServiceServer()
+}
+
+// UnimplementedGameServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedGameServiceServer struct {
+}
+
+func (UnimplementedGameServiceServer) CreateRoom(context.Context, *CreateRoomParams) (*CreateRoomResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRoom not implemented")
+}
+func (UnimplementedGameServiceServer) JoinRoom(context.Context, *JoinRoomParams) (*JoinRoomResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method JoinRoom not implemented")
+}
+func (UnimplementedGameServiceServer) MatchRoom(context.Context, *MatchRoomParams) (*MatchRoomResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MatchRoom not implemented")
+}
+func (UnimplementedGameServiceServer) LeaveRoom(context.Context, *LeaveRoomParams) (*LeaveRoomResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LeaveRoom not implemented")
+}
+func (UnimplementedGameServiceServer) RoomReady(context.Context, *RoomReadyParams) (*RoomReadyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RoomReady not implemented")
+}
+func (UnimplementedGameServiceServer) RoomAction(context.Context, *RoomActionParams) (*RoomActionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RoomAction not implemented")
+}
+func (UnimplementedGameServiceServer) mustEmbedUnimplementedGameServiceServer() {}
+
+// UnsafeGameServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GameServiceServer will
+// result in compilation errors.
+type UnsafeGameServiceServer interface {
+	mustEmbedUnimplementedGameServiceServer()
+}
+
+func RegisterGameServiceServer(s grpc.ServiceRegistrar, srv GameServiceServer) {
+	s.RegisterService(&GameService_ServiceDesc, srv)
+}
+
+func _GameService_CreateRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRoomParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).CreateRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameService_CreateRoom_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).CreateRoom(ctx, req.(*CreateRoomParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameService_JoinRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinRoomParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).JoinRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameService_JoinRoom_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).JoinRoom(ctx, req.(*JoinRoomParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameService_MatchRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MatchRoomParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).MatchRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameService_MatchRoom_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).MatchRoom(ctx, req.(*MatchRoomParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameService_LeaveRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaveRoomParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).LeaveRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameService_LeaveRoom_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).LeaveRoom(ctx, req.(*LeaveRoomParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameService_RoomReady_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoomReadyParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).RoomReady(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameService_RoomReady_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).RoomReady(ctx, req.(*RoomReadyParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameService_RoomAction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoomActionParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).RoomAction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameService_RoomAction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).RoomAction(ctx, req.(*RoomActionParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// GameService_ServiceDesc is the grpc.ServiceDesc for GameService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var GameService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "GameService",
+	HandlerType: (*GameServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateRoom",
+			Handler:    _GameService_CreateRoom_Handler,
+		},
+		{
+			MethodName: "JoinRoom",
+			Handler:    _GameService_JoinRoom_Handler,
+		},
+		{
+			MethodName: "MatchRoom",
+			Handler:    _GameService_MatchRoom_Handler,
+		},
+		{
+			MethodName: "LeaveRoom",
+			Handler:    _GameService_LeaveRoom_Handler,
+		},
+		{
+			MethodName: "RoomReady",
+			Handler:    _GameService_RoomReady_Handler,
+		},
+		{
+			MethodName: "RoomAction",
+			Handler:    _GameService_RoomAction_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "game.proto",
+}
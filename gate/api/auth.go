@@ -0,0 +1,48 @@
+package api
+
+import (
+	"common"
+	"common/biz"
+	"common/database"
+	"common/jwts"
+	"common/logs"
+	"errors"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// gin上下文中保存uid的key
+const uidKey = "uid"
+
+// Auth 校验请求头中的token：Authorization: Bearer <token>，通过后把uid放到上下文中
+// 退出登录、账号被冻结后会话被吊销，token随之失效
+func Auth(redis *database.RedisManager) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		token := strings.TrimPrefix(ctx.GetHeader("Authorization"), "Bearer ")
+		if token == "" {
+			common.Fail(ctx, biz.TokenInfoError)
+			ctx.Abort()
+			return
+		}
+		uid, err := jwts.Verify(ctx.Request.Context(), redis, token)
+		if errors.Is(err, jwts.ErrTokenInvalid) || errors.Is(err, jwts.ErrTokenRevoked) {
+			common.Fail(ctx, biz.TokenInfoError)
+			ctx.Abort()
+			return
+		}
+		if err != nil {
+			logs.Error("verify token err: %v", err)
+			common.Fail(ctx, biz.Fail)
+			ctx.Abort()
+			return
+		}
+		ctx.Set(uidKey, uid)
+		ctx.Next()
+	}
+}
+
+// Uid 获取Auth中间件校验通过的uid
+func Uid(ctx *gin.Context) string {
+	return ctx.GetString(uidKey)
+}
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"common"
+	"common/msError"
+	"common/rpc"
+	"hall/pb"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HallHandler 大厅数据，客户端未连接connector时通过http获取
+type HallHandler struct {
+}
+
+func NewHallHandler() *HallHandler {
+	return &HallHandler{}
+}
+
+// 游戏列表
+func (h *HallHandler) GameList(ctx *gin.Context) {
+	response, err := rpc.HallClient.GameList(ctx.Request.Context(), &pb.GameListParams{})
+	if err != nil {
+		common.Fail(ctx, msError.ToError(err))
+		return
+	}
+	common.Success(ctx, response.List)
+}
+
+// 公告
+func (h *HallHandler) Announcements(ctx *gin.Context) {
+	response, err := rpc.HallClient.Announcements(ctx.Request.Context(), &pb.AnnouncementsParams{})
+	if err != nil {
+		common.Fail(ctx, msError.ToError(err))
+		return
+	}
+	common.Success(ctx, response.List)
+}
+
+// 当前所在的房间
+func (h *HallHandler) CurrentRoom(ctx *gin.Context) {
+	response, err := rpc.HallClient.CurrentRoom(ctx.Request.Context(), &pb.CurrentRoomParams{Uid: Uid(ctx)})
+	if err != nil {
+		common.Fail(ctx, msError.ToError(err))
+		return
+	}
+	common.Success(ctx, response)
+}
+
+// 在线人数
+func (h *HallHandler) OnlineCount(ctx *gin.Context) {
+	response, err := rpc.HallClient.OnlineCount(ctx.Request.Context(), &pb.OnlineCountParams{})
+	if err != nil {
+		common.Fail(ctx, msError.ToError(err))
+		return
+	}
+	common.Success(ctx, response)
+}
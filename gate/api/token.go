@@ -0,0 +1,63 @@
+package api
+
+import (
+	"common"
+	"common/biz"
+	"common/database"
+	"common/jwts"
+	"common/logs"
+	"errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 客户端设备id的请求头，refresh token绑定设备
+const deviceHeader = "X-Device-Id"
+
+type TokenHandler struct {
+	redis *database.RedisManager
+}
+
+func NewTokenHandler(redis *database.RedisManager) *TokenHandler {
+	return &TokenHandler{redis: redis}
+}
+
+type refreshParams struct {
+	RefreshToken string `json:"refreshToken" binding:"required"`
+}
+
+// Refresh access token过期后用refresh token换取新的token，refresh token同时轮换
+func (t *TokenHandler) Refresh(ctx *gin.Context) {
+	var req refreshParams
+	device := ctx.GetHeader(deviceHeader)
+	if err := ctx.ShouldBindJSON(&req); err != nil || device == "" {
+		common.Fail(ctx, biz.RequestDataError)
+		return
+	}
+	token, err := jwts.Refresh(ctx.Request.Context(), t.redis, req.RefreshToken, device)
+	if errors.Is(err, jwts.ErrTokenReused) {
+		logs.Warn("refresh token reused, session revoked, device: %s, ip: %s", device, ctx.ClientIP())
+		common.Fail(ctx, biz.TokenInfoError)
+		return
+	}
+	if errors.Is(err, jwts.ErrTokenInvalid) {
+		common.Fail(ctx, biz.TokenInfoError)
+		return
+	}
+	if err != nil {
+		logs.Error("refresh token err: %v", err)
+		common.Fail(ctx, biz.Fail)
+		return
+	}
+	common.Success(ctx, token)
+}
+
+// Logout 退出登录，吊销该用户在所有设备上的会话
+func (t *TokenHandler) Logout(ctx *gin.Context) {
+	if err := jwts.Revoke(ctx.Request.Context(), t.redis, Uid(ctx)); err != nil {
+		logs.Error("logout revoke err: %v", err)
+		common.Fail(ctx, biz.Fail)
+		return
+	}
+	common.Success(ctx, nil)
+}
@@ -0,0 +1,205 @@
+package api
+
+import (
+	"common"
+	"common/biz"
+	"common/msError"
+	"common/rpc"
+	"hall/pb"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UnionHandler 联盟（牌友圈），操作人为当前登录的用户
+type UnionHandler struct {
+}
+
+func NewUnionHandler() *UnionHandler {
+	return &UnionHandler{}
+}
+
+// 创建联盟
+func (u *UnionHandler) Create(ctx *gin.Context) {
+	var req pb.CreateUnionParams
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		common.Fail(ctx, biz.RequestDataError)
+		return
+	}
+	req.Uid = Uid(ctx)
+	response, err := rpc.UnionClient.CreateUnion(ctx.Request.Context(), &req)
+	if err != nil {
+		common.Fail(ctx, msError.ToError(err))
+		return
+	}
+	common.Success(ctx, response.Union)
+}
+
+// 通过邀请码加入联盟
+func (u *UnionHandler) Join(ctx *gin.Context) {
+	var req pb.JoinUnionParams
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		common.Fail(ctx, biz.RequestDataError)
+		return
+	}
+	req.Uid = Uid(ctx)
+	response, err := rpc.UnionClient.JoinUnion(ctx.Request.Context(), &req)
+	if err != nil {
+		common.Fail(ctx, msError.ToError(err))
+		return
+	}
+	common.Success(ctx, response.Union)
+}
+
+// 退出联盟
+func (u *UnionHandler) Leave(ctx *gin.Context) {
+	var req pb.LeaveUnionParams
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		common.Fail(ctx, biz.RequestDataError)
+		return
+	}
+	req.Uid = Uid(ctx)
+	response, err := rpc.UnionClient.LeaveUnion(ctx.Request.Context(), &req)
+	if err != nil {
+		common.Fail(ctx, msError.ToError(err))
+		return
+	}
+	common.Success(ctx, response)
+}
+
+// 踢出成员
+func (u *UnionHandler) Kick(ctx *gin.Context) {
+	var req pb.KickUnionMemberParams
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		common.Fail(ctx, biz.RequestDataError)
+		return
+	}
+	req.Uid = Uid(ctx)
+	response, err := rpc.UnionClient.KickUnionMember(ctx.Request.Context(), &req)
+	if err != nil {
+		common.Fail(ctx, msError.ToError(err))
+		return
+	}
+	common.Success(ctx, response)
+}
+
+// 设置成员角色
+func (u *UnionHandler) SetRole(ctx *gin.Context) {
+	var req pb.SetUnionMemberRoleParams
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		common.Fail(ctx, biz.RequestDataError)
+		return
+	}
+	req.Uid = Uid(ctx)
+	response, err := rpc.UnionClient.SetUnionMemberRole(ctx.Request.Context(), &req)
+	if err != nil {
+		common.Fail(ctx, msError.ToError(err))
+		return
+	}
+	common.Success(ctx, response)
+}
+
+// 成员列表
+func (u *UnionHandler) Members(ctx *gin.Context) {
+	var req pb.UnionMembersParams
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		common.Fail(ctx, biz.RequestDataError)
+		return
+	}
+	req.Uid = Uid(ctx)
+	response, err := rpc.UnionClient.UnionMembers(ctx.Request.Context(), &req)
+	if err != nil {
+		common.Fail(ctx, msError.ToError(err))
+		return
+	}
+	common.Success(ctx, response)
+}
+
+// 加入的所有联盟
+func (u *UnionHandler) Mine(ctx *gin.Context) {
+	response, err := rpc.UnionClient.MyUnions(ctx.Request.Context(), &pb.MyUnionsParams{Uid: Uid(ctx)})
+	if err != nil {
+		common.Fail(ctx, msError.ToError(err))
+		return
+	}
+	common.Success(ctx, response.List)
+}
+
+// 修改联盟开关
+func (u *UnionHandler) SetSwitch(ctx *gin.Context) {
+	var req pb.SetUnionSwitchParams
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		common.Fail(ctx, biz.RequestDataError)
+		return
+	}
+	req.Uid = Uid(ctx)
+	response, err := rpc.UnionClient.SetUnionSwitch(ctx.Request.Context(), &req)
+	if err != nil {
+		common.Fail(ctx, msError.ToError(err))
+		return
+	}
+	common.Success(ctx, response)
+}
+
+// 赠送积分给直属成员
+func (u *UnionHandler) GiveScore(ctx *gin.Context) {
+	var req pb.GiveUnionScoreParams
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		common.Fail(ctx, biz.RequestDataError)
+		return
+	}
+	req.Uid = Uid(ctx)
+	response, err := rpc.UnionClient.GiveUnionScore(ctx.Request.Context(), &req)
+	if err != nil {
+		common.Fail(ctx, msError.ToError(err))
+		return
+	}
+	common.Success(ctx, response)
+}
+
+// 收回直属成员的积分
+func (u *UnionHandler) TakeScore(ctx *gin.Context) {
+	var req pb.TakeUnionScoreParams
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		common.Fail(ctx, biz.RequestDataError)
+		return
+	}
+	req.Uid = Uid(ctx)
+	response, err := rpc.UnionClient.TakeUnionScore(ctx.Request.Context(), &req)
+	if err != nil {
+		common.Fail(ctx, msError.ToError(err))
+		return
+	}
+	common.Success(ctx, response)
+}
+
+// 积分流水
+func (u *UnionHandler) ScoreRecords(ctx *gin.Context) {
+	var req pb.UnionScoreRecordsParams
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		common.Fail(ctx, biz.RequestDataError)
+		return
+	}
+	req.Uid = Uid(ctx)
+	response, err := rpc.UnionClient.UnionScoreRecords(ctx.Request.Context(), &req)
+	if err != nil {
+		common.Fail(ctx, msError.ToError(err))
+		return
+	}
+	common.Success(ctx, response.List)
+}
+
+// 积分日汇总
+func (u *UnionHandler) ScoreDaily(ctx *gin.Context) {
+	var req pb.UnionScoreDailyParams
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		common.Fail(ctx, biz.RequestDataError)
+		return
+	}
+	req.Uid = Uid(ctx)
+	response, err := rpc.UnionClient.UnionScoreDaily(ctx.Request.Context(), &req)
+	if err != nil {
+		common.Fail(ctx, msError.ToError(err))
+		return
+	}
+	common.Success(ctx, response.List)
+}
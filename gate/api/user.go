@@ -2,39 +2,138 @@ package api
 
 import (
 	"common"
+	"common/biz"
 	"common/config"
+	"common/database"
+	"common/jwts"
 	"common/logs"
+	"common/msError"
 	"common/rpc"
-	"context"
+	"core/session"
 	"user/pb"
 
 	"github.com/gin-gonic/gin"
 )
 
 type UserHandler struct {
+	redis *database.RedisManager
 }
 
-func NewUserHandler() *UserHandler {
-	return &UserHandler{}
+func NewUserHandler(redis *database.RedisManager) *UserHandler {
+	return &UserHandler{redis: redis}
 }
 
 // 用户注册
 func (u *UserHandler) Register(ctx *gin.Context) {
-	response, err := rpc.UserClient.Register(context.TODO(), &pb.RegisterParams{})
+	var req pb.RegisterParams
+	device := ctx.GetHeader(deviceHeader)
+	if err := ctx.ShouldBindJSON(&req); err != nil || device == "" {
+		common.Fail(ctx, biz.RequestDataError)
+		return
+	}
+	// 维护期间只有白名单中的ip可以注册
+	if err := session.CheckMaintenance(ctx.Request.Context(), u.redis, "", ctx.ClientIP()); err != nil {
+		common.Fail(ctx, biz.ServerMaintenance)
+		return
+	}
+	response, err := rpc.UserClient.Register(ctx.Request.Context(), &req)
 	if err != nil {
-
+		common.Fail(ctx, msError.ToError(err))
+		return
 	}
 
 	uid := response.Uid
 	logs.Info("uid:%s", uid)
+	result, err := u.loginResult(ctx, uid, device)
+	if err != nil {
+		common.Fail(ctx, biz.Fail)
+		return
+	}
+	common.Success(ctx, result)
+}
+
+// 用户登录
+func (u *UserHandler) Login(ctx *gin.Context) {
+	var req pb.LoginParams
+	device := ctx.GetHeader(deviceHeader)
+	if err := ctx.ShouldBindJSON(&req); err != nil || device == "" {
+		common.Fail(ctx, biz.RequestDataError)
+		return
+	}
+	response, err := rpc.UserClient.Login(ctx.Request.Context(), &req)
+	if err != nil {
+		common.Fail(ctx, msError.ToError(err))
+		return
+	}
+	// 校验密码之后才知道uid，维护期间只有白名单中的uid、ip可以登录
+	if err = session.CheckMaintenance(ctx.Request.Context(), u.redis, response.Uid, ctx.ClientIP()); err != nil {
+		common.Fail(ctx, biz.ServerMaintenance)
+		return
+	}
+	result, err := u.loginResult(ctx, response.Uid, device)
+	if err != nil {
+		common.Fail(ctx, biz.Fail)
+		return
+	}
+	common.Success(ctx, result)
+}
 
-	// gen token by uid
-	result := map[string]any{
-		"token": "",
+// 注册、登录成功后创建登录会话，返回给客户端token和connector地址
+func (u *UserHandler) loginResult(ctx *gin.Context, uid string, device string) (map[string]any, error) {
+	token, err := jwts.Issue(ctx.Request.Context(), u.redis, uid, device)
+	if err != nil {
+		logs.Error("issue token err: %v", err)
+		return nil, err
+	}
+	return map[string]any{
+		"token":        token.AccessToken,
+		"refreshToken": token.RefreshToken,
+		"expiresIn":    token.ExpiresIn,
 		"serverInfo": map[string]any{
-			"host": config.Conf.Services["connector"].ClientHost,
-			"port": config.Conf.Services["connector"].ClientPort,
+			"host": config.Current().Services["connector"].ClientHost,
+			"port": config.Current().Services["connector"].ClientPort,
 		},
+	}, nil
+}
+
+// 获取自己的用户信息
+func (u *UserHandler) GetUserInfo(ctx *gin.Context) {
+	response, err := rpc.UserClient.GetUserInfo(ctx.Request.Context(), &pb.GetUserInfoParams{Uid: Uid(ctx)})
+	if err != nil {
+		common.Fail(ctx, msError.ToError(err))
+		return
 	}
-	common.Success(ctx, result)
+	common.Success(ctx, response.Info)
+}
+
+// 修改用户信息，只修改请求中带了的字段
+func (u *UserHandler) UpdateUserInfo(ctx *gin.Context) {
+	var req pb.UpdateUserInfoParams
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		common.Fail(ctx, biz.RequestDataError)
+		return
+	}
+	req.Uid = Uid(ctx)
+	response, err := rpc.UserClient.UpdateUserInfo(ctx.Request.Context(), &req)
+	if err != nil {
+		common.Fail(ctx, msError.ToError(err))
+		return
+	}
+	common.Success(ctx, response.Info)
+}
+
+// 绑定手机号
+func (u *UserHandler) BindPhone(ctx *gin.Context) {
+	var req pb.BindPhoneParams
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		common.Fail(ctx, biz.RequestDataError)
+		return
+	}
+	req.Uid = Uid(ctx)
+	response, err := rpc.UserClient.BindPhone(ctx.Request.Context(), &req)
+	if err != nil {
+		common.Fail(ctx, msError.ToError(err))
+		return
+	}
+	common.Success(ctx, response)
 }
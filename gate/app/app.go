@@ -2,14 +2,15 @@ package app
 
 import (
 	"common/config"
+	"common/database"
+	"common/lifecycle"
 	"common/logs"
+	"common/metrics"
+	"common/rpc"
+	"common/tracing"
 	"context"
 	"fmt"
 	"gate/router"
-	"log"
-	"os"
-	"os/signal"
-	"syscall"
 	"time"
 )
 
@@ -17,49 +18,83 @@ import (
 func Run(ctx context.Context) error {
 
 	// 1.初始化日志库
-	logs.InitLog(config.Conf.AppName)
+	logs.InitLog(config.Current().AppName)
 
-	go func() {
-		// 启动gin，然后注册路由
-		r := router.RegisterRouter()
-		if err := r.Run(fmt.Sprintf(":%d", config.Conf.HttpPort));err != nil {
-			logs.Error("[gin] gate run error:%v", err)
-		}
+	// 2.初始化链路追踪
+	shutdownTrace, err := tracing.Init(config.Current().AppName, config.Current().Trace)
+	if err != nil {
+		return err
+	}
 
-	}()
+	// 3.初始化redis（校验token是否被吊销），初始化gin，然后注册路由
+	redis := database.NewRedis()
+	r := router.RegisterRouter(redis)
 
-	// 优雅启停，遇到 终止 退出 中断 挂断信号，则结束gRPC server的运行
-	stop := func() {
-		time.Sleep(3 * time.Second) // 休眠3S，停止必要的服务
-		logs.Info("==> stop app finish")
+	// 4.创建http服务，开启tls时加载证书
+	var certs *certReloader
+	if config.Current().Http.Tls.Enable {
+		certs, err = newCertReloader(config.Current().Http.Tls.CertFile, config.Current().Http.Tls.KeyFile)
+		if err != nil {
+			return err
+		}
+	}
+	server, err := newHttpServer(r, certs)
+	if err != nil {
+		return err
 	}
 
+	// 就绪检查：依赖的grpc服务和redis
+	metrics.RegisterChecker(
+		metrics.NewChecker("grpc", rpc.Check),
+		metrics.NewChecker("redis", redis.Ping),
+	)
+	metricServer, err := metrics.NewServer(fmt.Sprintf("0.0.0.0:%d", config.Current().MetricPort))
+	if err != nil {
+		return err
+	}
 
-	c := make(chan os.Signal, 1)
-	signal.Notify(c, syscall.SIGTERM, syscall.SIGQUIT, syscall.SIGINT, syscall.SIGHUP)
-	for {
-		select {
-		// 上下文事件完成
-		case <-ctx.Done():
-			stop()
+	// 5.按顺序启动各组件，停止时逆序：先等待处理中的http请求完成，再关闭grpc、redis连接
+	lc := lifecycle.New(config.Current().AppName)
+	lc.Append(lc.HttpServer("metrics", metricServer))
+	lc.Append(lifecycle.Hook{
+		Name:   "trace",
+		OnStop: shutdownTrace,
+	})
+	lc.Append(lifecycle.Hook{
+		Name: "redis",
+		OnStop: func(ctx context.Context) error {
+			redis.Close()
 			return nil
-		// 收到终止信号
-		case s := <-c:
-			log.Printf("==> get a signal %s", s.String())
-			switch s {
-			case syscall.SIGTERM, syscall.SIGQUIT, syscall.SIGINT:
-				stop()
-				logs.Fatal("==> user grpc server exit")
-				return nil
-			case syscall.SIGHUP:
-				logs.Fatal("==> hangup!!")
+		},
+	})
+	lc.Append(lifecycle.Hook{
+		Name: "rpc",
+		OnStop: func(ctx context.Context) error {
+			rpc.Close()
+			return nil
+		},
+	})
+	if certs != nil {
+		lc.Append(lifecycle.Hook{
+			Name: "tls",
+			OnStart: func(ctx context.Context) error {
+				go certs.Watch(time.Minute)
 				return nil
-			default:
+			},
+			OnStop: func(ctx context.Context) error {
+				certs.Close()
 				return nil
+			},
+		})
+	}
+	lc.Append(lc.HttpServer("http", server))
+	lc.OnReload(func() {
+		logs.SetLevel(config.Current().Log.Level)
+		if certs != nil {
+			if err := certs.Reload(); err != nil {
+				logs.Error("[tls] reload cert error: %v", err)
 			}
-
 		}
-	}
-
-
+	})
+	return lc.Run(ctx)
 }
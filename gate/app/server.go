@@ -0,0 +1,134 @@
+package app
+
+import (
+	"common/config"
+	"common/logs"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+// 未配置时的默认超时时间
+const (
+	defaultReadTimeout       = 10 * time.Second
+	defaultReadHeaderTimeout = 5 * time.Second
+	defaultWriteTimeout      = 15 * time.Second
+	defaultIdleTimeout       = 60 * time.Second
+)
+
+// newHttpServer 根据配置创建gate的http服务，开启tls时同时支持http2
+func newHttpServer(handler http.Handler, certs *certReloader) (*http.Server, error) {
+	conf := config.Current().Http
+	server := &http.Server{
+		Addr:              fmt.Sprintf(":%d", config.Current().HttpPort),
+		Handler:           handler,
+		ReadTimeout:       seconds(conf.ReadTimeout, defaultReadTimeout),
+		ReadHeaderTimeout: seconds(conf.ReadHeaderTimeout, defaultReadHeaderTimeout),
+		WriteTimeout:      seconds(conf.WriteTimeout, defaultWriteTimeout),
+		IdleTimeout:       seconds(conf.IdleTimeout, defaultIdleTimeout),
+	}
+	if certs != nil {
+		server.TLSConfig = &tls.Config{
+			MinVersion:     tls.VersionTLS12,
+			GetCertificate: certs.GetCertificate,
+		}
+	} else if conf.H2c {
+		// 明文http2，用于tls在负载均衡上终结的场景
+		server.Handler = h2c.NewHandler(handler, &http2.Server{})
+	}
+	if err := http2.ConfigureServer(server, &http2.Server{IdleTimeout: server.IdleTimeout}); err != nil {
+		return nil, err
+	}
+	return server, nil
+}
+
+func seconds(v int, def time.Duration) time.Duration {
+	if v <= 0 {
+		return def
+	}
+	return time.Duration(v) * time.Second
+}
+
+// certReloader 证书热加载，证书文件轮换后无需重启gate
+type certReloader struct {
+	certFile string
+	keyFile  string
+	lock     sync.RWMutex
+	cert     *tls.Certificate
+	modTime  time.Time
+	closeCh  chan struct{}
+}
+
+func newCertReloader(certFile, keyFile string) (*certReloader, error) {
+	c := &certReloader{
+		certFile: certFile,
+		keyFile:  keyFile,
+		closeCh:  make(chan struct{}),
+	}
+	if err := c.Reload(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Reload 重新加载证书，加载失败时继续使用旧证书
+func (c *certReloader) Reload() error {
+	cert, err := tls.LoadX509KeyPair(c.certFile, c.keyFile)
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(c.certFile)
+	if err != nil {
+		return err
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.cert = &cert
+	c.modTime = info.ModTime()
+	return nil
+}
+
+func (c *certReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.cert, nil
+}
+
+// Watch 定时检查证书文件的修改时间，有变化则重新加载
+func (c *certReloader) Watch(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.closeCh:
+			return
+		case <-ticker.C:
+			info, err := os.Stat(c.certFile)
+			if err != nil {
+				logs.Error("[tls] stat cert file error: %v", err)
+				continue
+			}
+			c.lock.RLock()
+			changed := !info.ModTime().Equal(c.modTime)
+			c.lock.RUnlock()
+			if !changed {
+				continue
+			}
+			if err := c.Reload(); err != nil {
+				logs.Error("[tls] reload cert error: %v", err)
+				continue
+			}
+			logs.Info("[tls] cert reloaded")
+		}
+	}
+}
+
+func (c *certReloader) Close() {
+	close(c.closeCh)
+}
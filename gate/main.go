@@ -2,10 +2,8 @@ package main
 
 import (
 	"common/config"
-	"common/metrics"
 	"context"
 	"flag"
-	"fmt"
 	"gate/app"
 	"log"
 )
@@ -17,14 +15,7 @@ func main() {
 	// 1.加载配置文件
 	flag.Parse()
 	config.InitConfig(*configFile)
-	// 2.启动监控协程
-	go func() {
-		err := metrics.Serve(fmt.Sprintf("0.0.0.0:%d", config.Conf.MetricPort));
-		if err != nil {
-			panic(err)
-		}
-	}()
-	// 3.启动user的服务端
+	// 2.启动gate的http服务和监控服务
 	err := app.Run(context.Background())
 	if err != nil {
 		log.Println(err)
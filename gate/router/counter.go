@@ -0,0 +1,139 @@
+package router
+
+import (
+	"common/database"
+	"common/logs"
+	"context"
+	"sync"
+	"time"
+)
+
+// 内存计数器的条数超过该值时清理一次过期的计数
+const memoryCounterSweepSize = 10000
+
+// counter 固定窗口计数器
+type counter interface {
+	// Incr 计数加1，返回窗口内的计数，窗口从第一次计数开始
+	Incr(ctx context.Context, key string, window time.Duration) (int64, error)
+	// Decr 撤销一次计数，计数不存在或已过期时不做处理
+	Decr(ctx context.Context, key string) error
+	Del(ctx context.Context, key string) error
+}
+
+// 计数加1，第一次计数时设置过期时间
+var incrScript = database.NewScript(`
+local n = redis.call("INCR", KEYS[1])
+if n == 1 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+return n`)
+
+// 计数存在时减1，不存在时不创建没有过期时间的计数
+var decrScript = database.NewScript(`
+if redis.call("EXISTS", KEYS[1]) == 1 then
+	return redis.call("DECR", KEYS[1])
+end
+return 0`)
+
+// redisCounter 计数保存在redis中，多个gate节点共享
+type redisCounter struct {
+	redis *database.RedisManager
+}
+
+func (c *redisCounter) Incr(ctx context.Context, key string, window time.Duration) (int64, error) {
+	res, err := c.redis.Eval(ctx, incrScript, []string{key}, window.Milliseconds())
+	if err != nil {
+		return 0, err
+	}
+	n, _ := res.(int64)
+	return n, nil
+}
+
+func (c *redisCounter) Decr(ctx context.Context, key string) error {
+	_, err := c.redis.Eval(ctx, decrScript, []string{key})
+	return err
+}
+
+func (c *redisCounter) Del(ctx context.Context, key string) error {
+	_, err := c.redis.Del(ctx, key)
+	return err
+}
+
+type memoryCount struct {
+	n        int64
+	expireAt time.Time
+}
+
+// memoryCounter 进程内计数，redis不可用时降级使用，此时限制只在单个gate节点内生效
+type memoryCounter struct {
+	lock   sync.Mutex
+	counts map[string]*memoryCount
+}
+
+func newMemoryCounter() *memoryCounter {
+	return &memoryCounter{counts: make(map[string]*memoryCount)}
+}
+
+func (c *memoryCounter) Incr(ctx context.Context, key string, window time.Duration) (int64, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	now := time.Now()
+	if len(c.counts) > memoryCounterSweepSize {
+		for k, v := range c.counts {
+			if now.After(v.expireAt) {
+				delete(c.counts, k)
+			}
+		}
+	}
+	v, ok := c.counts[key]
+	if !ok || now.After(v.expireAt) {
+		v = &memoryCount{expireAt: now.Add(window)}
+		c.counts[key] = v
+	}
+	v.n++
+	return v.n, nil
+}
+
+func (c *memoryCounter) Decr(ctx context.Context, key string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if v, ok := c.counts[key]; ok && time.Now().Before(v.expireAt) {
+		v.n--
+	}
+	return nil
+}
+
+func (c *memoryCounter) Del(ctx context.Context, key string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	delete(c.counts, key)
+	return nil
+}
+
+// fallbackCounter 优先使用redis，redis出错时降级到内存计数
+type fallbackCounter struct {
+	primary  counter
+	fallback counter
+}
+
+func (c *fallbackCounter) Incr(ctx context.Context, key string, window time.Duration) (int64, error) {
+	n, err := c.primary.Incr(ctx, key, window)
+	if err != nil {
+		logs.Warn("rate limit redis incr err, fallback to memory: %v", err)
+		return c.fallback.Incr(ctx, key, window)
+	}
+	return n, nil
+}
+
+func (c *fallbackCounter) Decr(ctx context.Context, key string) error {
+	if err := c.primary.Decr(ctx, key); err != nil {
+		logs.Warn("rate limit redis decr err, fallback to memory: %v", err)
+		return c.fallback.Decr(ctx, key)
+	}
+	return nil
+}
+
+func (c *fallbackCounter) Del(ctx context.Context, key string) error {
+	_ = c.fallback.Del(ctx, key)
+	return c.primary.Del(ctx, key)
+}
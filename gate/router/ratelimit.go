@@ -0,0 +1,191 @@
+package router
+
+import (
+	"bytes"
+	"common"
+	"common/biz"
+	"common/config"
+	"common/database"
+	"common/logs"
+	"context"
+	"encoding/json"
+	"gate/api"
+	"io"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 限流计数在redis中的key前缀
+const rateLimitKeyPrefix = "ratelimit:"
+
+// 读取请求体中账号字段时最多读取的字节数
+const maxPeekBodySize = 64 << 10
+
+// gin上下文中缓存请求体中账号的key，避免重复读取请求体
+const accountKey = "rateLimitAccount"
+
+// RateLimiter 接口限流：每个ip所有接口合计、每个接口按ip、每个接口按账号，以及登录密码错误锁定
+// 每次请求读取当前配置的RateLimit，重新加载配置后立即生效
+type RateLimiter struct {
+	counter counter
+}
+
+func NewRateLimiter(redis *database.RedisManager) *RateLimiter {
+	return &RateLimiter{
+		counter: &fallbackCounter{
+			primary:  &redisCounter{redis: redis},
+			fallback: newMemoryCounter(),
+		},
+	}
+}
+
+// Ip 按ip限流，需要在路由匹配之后执行（gin的全局中间件即可）
+func (l *RateLimiter) Ip() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		conf := config.Current().RateLimit
+		if !conf.Enable {
+			ctx.Next()
+			return
+		}
+		ip := ctx.ClientIP()
+		if !l.allow(ctx, "ip:"+ip, conf.Ip) {
+			l.reject(ctx, "ip", ip)
+			return
+		}
+		if route := routeConf(ctx.FullPath()); route != nil && !l.allow(ctx, "ip:"+ip+":"+route.Path, route.Ip) {
+			l.reject(ctx, "route ip", ip)
+			return
+		}
+		ctx.Next()
+	}
+}
+
+// Account 按账号限流，登录后的接口放在Auth之后按uid限流，登录、注册等接口按请求体中的账号限流
+func (l *RateLimiter) Account() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		conf := config.Current().RateLimit
+		route := routeConf(ctx.FullPath())
+		if !conf.Enable || route == nil || route.Account.Limit <= 0 {
+			ctx.Next()
+			return
+		}
+		account := api.Uid(ctx)
+		if account == "" {
+			account = peekAccount(ctx)
+		}
+		if account != "" && !l.allow(ctx, "account:"+account+":"+route.Path, route.Account) {
+			l.reject(ctx, "account", account)
+			return
+		}
+		ctx.Next()
+	}
+}
+
+// LoginLock 窗口内同一账号在同一ip上密码错误次数过多时锁定，登录成功后清除错误次数
+// 请求前先原子地占用一次错误次数，并发的请求不会同时通过检查，不是密码错误时再撤销
+// 按账号和ip锁定，避免其他人故意输错密码把账号锁住
+func (l *RateLimiter) LoginLock() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		rateLimit := config.Current().RateLimit
+		conf := rateLimit.LoginLock
+		if !rateLimit.Enable || conf.MaxFails <= 0 {
+			ctx.Next()
+			return
+		}
+		account := peekAccount(ctx)
+		if account == "" {
+			ctx.Next()
+			return
+		}
+		ip := ctx.ClientIP()
+		key := rateLimitKeyPrefix + "login:fail:" + account + ":" + ip
+		window := time.Duration(conf.Window) * time.Second
+		n, err := l.counter.Incr(ctx.Request.Context(), key, window)
+		if err != nil {
+			logs.Error("login lock incr err: %v", err)
+			ctx.Next()
+			return
+		}
+		if n > conf.MaxFails {
+			common.Fail(ctx, biz.LoginLocked)
+			ctx.Abort()
+			return
+		}
+
+		ctx.Next()
+
+		switch ctx.GetInt(common.CodeKey) {
+		case biz.AccountOrPasswordError.Code:
+			if n == conf.MaxFails {
+				logs.Warn("login locked, account: %s, ip: %s", account, ip)
+			}
+		case biz.OK:
+			_ = l.counter.Del(context.Background(), key)
+		default:
+			_ = l.counter.Decr(context.Background(), key)
+		}
+	}
+}
+
+// allow 计数加1，超过限制时返回false，计数出错时放行
+func (l *RateLimiter) allow(ctx *gin.Context, key string, rule config.LimitRule) bool {
+	if rule.Limit <= 0 || rule.Window <= 0 {
+		return true
+	}
+	n, err := l.counter.Incr(ctx.Request.Context(), rateLimitKeyPrefix+key, time.Duration(rule.Window)*time.Second)
+	if err != nil {
+		logs.Error("rate limit incr err: %v", err)
+		return true
+	}
+	return n <= rule.Limit
+}
+
+func (l *RateLimiter) reject(ctx *gin.Context, kind string, value string) {
+	logs.Warn("rate limited by %s, %s %s", kind, value, ctx.FullPath())
+	common.Fail(ctx, biz.TooManyRequests)
+	ctx.Abort()
+}
+
+// routeConf 接口的限流配置，未配置时返回nil
+func routeConf(path string) *config.RouteLimitConf {
+	routes := config.Current().RateLimit.Routes
+	for i := range routes {
+		if routes[i].Path == path {
+			return &routes[i]
+		}
+	}
+	return nil
+}
+
+// peekAccount 读取json请求体中的account或phone字段，读取后还原请求体，后续的handler可以正常绑定
+func peekAccount(ctx *gin.Context) string {
+	if v, ok := ctx.Get(accountKey); ok {
+		return v.(string)
+	}
+	var account string
+	if ctx.Request.Body != nil {
+		body, err := io.ReadAll(io.LimitReader(ctx.Request.Body, maxPeekBodySize))
+		if err != nil {
+			logs.Warn("rate limit read body err: %v", err)
+		}
+		ctx.Request.Body = readCloser{io.MultiReader(bytes.NewReader(body), ctx.Request.Body), ctx.Request.Body}
+		var fields struct {
+			Account string `json:"account"`
+			Phone   string `json:"phone"`
+		}
+		if json.Unmarshal(body, &fields) == nil {
+			account = fields.Account
+			if account == "" {
+				account = fields.Phone
+			}
+		}
+	}
+	ctx.Set(accountKey, account)
+	return account
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,171 @@
+package router
+
+import (
+	"common"
+	"common/biz"
+	"common/config"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// newTestEngine 使用内存计数的限流器，/login 密码为123456时登录成功
+func newTestEngine(conf config.RateLimitConf) *gin.Engine {
+	config.Set(&config.Config{RateLimit: conf})
+	gin.SetMode(gin.TestMode)
+	limiter := &RateLimiter{counter: newMemoryCounter()}
+	r := gin.New()
+	_ = r.SetTrustedProxies(nil)
+	r.Use(limiter.Ip())
+	r.POST("/login", limiter.Account(), limiter.LoginLock(), func(ctx *gin.Context) {
+		var req struct {
+			Account  string `json:"account"`
+			Password string `json:"password"`
+		}
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			common.Fail(ctx, biz.RequestDataError)
+			return
+		}
+		if req.Password != "123456" {
+			common.Fail(ctx, biz.AccountOrPasswordError)
+			return
+		}
+		common.Success(ctx, req.Account)
+	})
+	return r
+}
+
+func login(t *testing.T, r *gin.Engine, ip, account, password string) int {
+	t.Helper()
+	return loginForwarded(t, r, ip, "", account, password)
+}
+
+// loginForwarded 请求头带上X-Forwarded-For
+func loginForwarded(t *testing.T, r *gin.Engine, ip, forwarded, account, password string) int {
+	t.Helper()
+	body := `{"account":"` + account + `","password":"` + password + `"}`
+	req := httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(body))
+	req.RemoteAddr = ip + ":1234"
+	if forwarded != "" {
+		req.Header.Set("X-Forwarded-For", forwarded)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	var res common.Result
+	if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+		t.Fatal(err)
+	}
+	return res.Code
+}
+
+func TestRateLimiter_Ip(t *testing.T) {
+	r := newTestEngine(config.RateLimitConf{
+		Enable: true,
+		Routes: []config.RouteLimitConf{
+			{Path: "/login", Ip: config.LimitRule{Limit: 2, Window: 60}},
+		},
+	})
+	for i, want := range []int{biz.OK, biz.OK, biz.TooManyRequests.Code} {
+		if code := login(t, r, "1.1.1.1", "player", "123456"); code != want {
+			t.Fatalf("request %d code = %d, want %d", i, code, want)
+		}
+	}
+	// 不是可信代理时伪造X-Forwarded-For无效
+	if code := loginForwarded(t, r, "1.1.1.1", "3.3.3.3", "player", "123456"); code != biz.TooManyRequests.Code {
+		t.Fatalf("forwarded code = %d, want %d", code, biz.TooManyRequests.Code)
+	}
+	// 其他ip不受影响
+	if code := login(t, r, "2.2.2.2", "player", "123456"); code != biz.OK {
+		t.Fatalf("other ip code = %d", code)
+	}
+}
+
+func TestRateLimiter_Account(t *testing.T) {
+	r := newTestEngine(config.RateLimitConf{
+		Enable: true,
+		Routes: []config.RouteLimitConf{
+			{Path: "/login", Account: config.LimitRule{Limit: 1, Window: 60}},
+		},
+	})
+	if code := login(t, r, "1.1.1.1", "player", "123456"); code != biz.OK {
+		t.Fatalf("code = %d", code)
+	}
+	// 换ip也受账号的限制，请求体读取后handler仍然可以正常绑定
+	if code := login(t, r, "2.2.2.2", "player", "123456"); code != biz.TooManyRequests.Code {
+		t.Fatalf("code = %d, want %d", code, biz.TooManyRequests.Code)
+	}
+	if code := login(t, r, "2.2.2.2", "other", "123456"); code != biz.OK {
+		t.Fatalf("other account code = %d", code)
+	}
+}
+
+func TestRateLimiter_LoginLock(t *testing.T) {
+	r := newTestEngine(config.RateLimitConf{
+		Enable:    true,
+		LoginLock: config.LoginLockConf{MaxFails: 2, Window: 60},
+	})
+	// 登录成功清除错误次数
+	login(t, r, "1.1.1.1", "player", "000000")
+	login(t, r, "1.1.1.1", "player", "123456")
+	for i, want := range []int{biz.AccountOrPasswordError.Code, biz.AccountOrPasswordError.Code, biz.LoginLocked.Code} {
+		if code := login(t, r, "1.1.1.1", "player", "000000"); code != want {
+			t.Fatalf("request %d code = %d, want %d", i, code, want)
+		}
+	}
+	// 锁定后密码正确也无法登录
+	if code := login(t, r, "1.1.1.1", "player", "123456"); code != biz.LoginLocked.Code {
+		t.Fatalf("code = %d, want %d", code, biz.LoginLocked.Code)
+	}
+	// 同一账号在其他ip上不受影响
+	if code := login(t, r, "2.2.2.2", "player", "123456"); code != biz.OK {
+		t.Fatalf("other ip code = %d", code)
+	}
+}
+
+// 并发请求时最多只有maxFails次请求能尝试密码
+func TestRateLimiter_LoginLockConcurrent(t *testing.T) {
+	r := newTestEngine(config.RateLimitConf{
+		Enable:    true,
+		LoginLock: config.LoginLockConf{MaxFails: 3, Window: 60},
+	})
+	var wg sync.WaitGroup
+	var tried atomic.Int64
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if login(t, r, "1.1.1.1", "player", "000000") == biz.AccountOrPasswordError.Code {
+				tried.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	if n := tried.Load(); n != 3 {
+		t.Fatalf("tried = %d, want 3", n)
+	}
+}
+
+func TestMemoryCounter_Decr(t *testing.T) {
+	c := newMemoryCounter()
+	ctx := context.Background()
+	if err := c.Decr(ctx, "missing"); err != nil {
+		t.Fatal(err)
+	}
+	c.Incr(ctx, "key", time.Minute)
+	c.Incr(ctx, "key", time.Minute)
+	_ = c.Decr(ctx, "key")
+	if n, _ := c.Incr(ctx, "key", time.Minute); n != 2 {
+		t.Fatalf("n = %d, want 2", n)
+	}
+	if n, _ := c.Incr(ctx, "missing", time.Minute); n != 1 {
+		t.Fatalf("missing n = %d, want 1", n)
+	}
+}
@@ -2,26 +2,68 @@ package router
 
 import (
 	"common/config"
+	"common/database"
+	"common/logs"
+	"common/metrics"
 	"common/rpc"
+	"common/tracing"
 	"gate/api"
 	"github.com/gin-gonic/gin"
 )
 
 // RegisterRouter 注册路由
-func RegisterRouter() *gin.Engine{
-	if config.Conf.Log.Level == "DEBUG" {
+func RegisterRouter(redis *database.RedisManager) *gin.Engine{
+	if config.Current().Log.Level == "DEBUG" {
 		gin.SetMode(gin.DebugMode)
 	} else {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
-	// 初始化grpc client，gate作为grpc客户端去调用user-grpc服务
+	// 初始化grpc client，gate作为grpc客户端去调用user、hall的grpc服务
 	rpc.Init()
 
 	// 初始化gin引擎
 	r := gin.Default()
-	userHandler := api.NewUserHandler()
-	r.POST("/register", userHandler.Register)
+	// 只使用可信代理设置的X-Forwarded-For，避免客户端伪造ip绕过维护白名单和限流
+	if err := r.SetTrustedProxies(config.Current().Http.TrustedProxies); err != nil {
+		logs.Fatal("set trusted proxies err: %v", err)
+	}
+	limiter := NewRateLimiter(redis)
+	r.Use(tracing.GinMiddleware(), metrics.GinMiddleware(), limiter.Ip())
+	userHandler := api.NewUserHandler(redis)
+	tokenHandler := api.NewTokenHandler(redis)
+	r.POST("/register", limiter.Account(), userHandler.Register)
+	r.POST("/login", limiter.Account(), limiter.LoginLock(), userHandler.Login)
+	r.POST("/token/refresh", tokenHandler.Refresh)
+
+	// 需要登录的接口
+	user := r.Group("/user", api.Auth(redis), limiter.Account())
+	user.GET("/info", userHandler.GetUserInfo)
+	user.POST("/info", userHandler.UpdateUserInfo)
+	user.POST("/bindPhone", userHandler.BindPhone)
+	user.POST("/logout", tokenHandler.Logout)
+
+	hallHandler := api.NewHallHandler()
+	hall := r.Group("/hall", api.Auth(redis), limiter.Account())
+	hall.GET("/games", hallHandler.GameList)
+	hall.GET("/announcements", hallHandler.Announcements)
+	hall.GET("/room", hallHandler.CurrentRoom)
+	hall.GET("/online", hallHandler.OnlineCount)
+
+	unionHandler := api.NewUnionHandler()
+	union := r.Group("/union", api.Auth(redis), limiter.Account())
+	union.GET("/mine", unionHandler.Mine)
+	union.POST("/create", unionHandler.Create)
+	union.POST("/join", unionHandler.Join)
+	union.POST("/leave", unionHandler.Leave)
+	union.POST("/kick", unionHandler.Kick)
+	union.POST("/role", unionHandler.SetRole)
+	union.POST("/members", unionHandler.Members)
+	union.POST("/switch", unionHandler.SetSwitch)
+	union.POST("/score/give", unionHandler.GiveScore)
+	union.POST("/score/take", unionHandler.TakeScore)
+	union.POST("/score/records", unionHandler.ScoreRecords)
+	union.POST("/score/daily", unionHandler.ScoreDaily)
 
 	return r
 }
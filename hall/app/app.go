@@ -0,0 +1,104 @@
+package app
+
+import (
+	"common/config"
+	"common/discovery"
+	"common/lifecycle"
+	"common/logs"
+	"common/metrics"
+	"common/rpc"
+	"common/sensitive"
+	"common/tracing"
+	"context"
+	"core/repo"
+	"fmt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"hall/internal/service"
+	"hall/pb"
+)
+
+// Run 启动程序
+func Run(ctx context.Context) error {
+
+	// 1.初始化日志库
+	logs.InitLog(config.Current().AppName)
+
+	// 2.初始化链路追踪
+	shutdownTrace, err := tracing.Init(config.Current().AppName, config.Current().Trace)
+	if err != nil {
+		return err
+	}
+
+	// 加载敏感词，联盟名称需要过滤
+	sensitive.Load(config.Current().Sensitive)
+
+	// 3.初始化数据库管理
+	manager := repo.New()
+
+	// 4.获取etcd注册客户端实例
+	register := discovery.NewRegister()
+
+	// 5.创建gRPC服务端，注册 hall service、union service 和grpc标准健康检查服务
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(tracing.UnaryServerInterceptor(), metrics.UnaryServerInterceptor()))
+	healthServer := health.NewServer()
+	pb.RegisterHallServiceServer(server, service.NewHallService(manager))
+	pb.RegisterUnionServiceServer(server, service.NewUnionService(manager))
+	healthpb.RegisterHealthServer(server, healthServer)
+
+	// admin service 单独监听内网地址并校验令牌，不和业务服务一起注册到etcd
+	adminServer := grpc.NewServer(grpc.ChainUnaryInterceptor(tracing.UnaryServerInterceptor(), metrics.UnaryServerInterceptor(), rpc.AdminAuthInterceptor()))
+	pb.RegisterHallAdminServiceServer(adminServer, service.NewAdminService(manager))
+
+	// 就绪检查：数据库连接和etcd注册状态
+	metrics.RegisterChecker(
+		metrics.NewChecker("mongo", manager.Mongo.Ping),
+		metrics.NewChecker("redis", manager.Redis.Ping),
+		metrics.NewChecker("etcd", register.Check),
+	)
+	metricServer, err := metrics.NewServer(fmt.Sprintf("0.0.0.0:%d", config.Current().MetricPort))
+	if err != nil {
+		return err
+	}
+
+	// 6.按顺序启动各组件，停止时逆序：先从etcd注销，再等待grpc处理完存量请求，最后关闭数据库连接
+	lc := lifecycle.New(config.Current().AppName)
+	lc.Append(lc.HttpServer("metrics", metricServer))
+	lc.Append(lifecycle.Hook{
+		Name:   "trace",
+		OnStop: shutdownTrace,
+	})
+	lc.Append(lifecycle.Hook{
+		Name: "database",
+		OnStop: func(ctx context.Context) error {
+			manager.Close()
+			return nil
+		},
+	})
+	lc.Append(lc.GrpcServer("grpc", server, config.Current().Grpc.Addr))
+	if addr := config.Current().Grpc.AdminAddr; addr != "" {
+		lc.Append(lc.GrpcServer("admin", adminServer, addr))
+	}
+	lc.Append(lifecycle.Hook{
+		Name: "etcd",
+		OnStart: func(ctx context.Context) error {
+			// gRPC服务启动成功之后，再注册到etcd
+			if err := register.Register(config.Current().Etcd); err != nil {
+				return err
+			}
+			healthServer.Resume()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			healthServer.Shutdown() // 健康检查置为NOT_SERVING
+			register.Close()        // 从etcd注销，不再有新请求进来
+			return nil
+		},
+	})
+	lc.OnReload(func() {
+		logs.SetLevel(config.Current().Log.Level)
+		sensitive.Load(config.Current().Sensitive)
+	})
+	return lc.Run(ctx)
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"common/biz"
+	"common/database"
+	"common/logs"
+	"common/msError"
+	"context"
+	"core/models/entity"
+	"core/repo"
+	"hall/pb"
+	"time"
+)
+
+// AdminService 后台管理：发布、删除公告，给盟主充值积分
+type AdminService struct {
+	pb.UnimplementedHallAdminServiceServer
+	announcements repo.AnnouncementRepository
+	unions        repo.UnionRepository
+	members       repo.UnionMemberRepository
+	ledgers       repo.LedgerRepository
+	tx            database.Transactor
+}
+
+func NewAdminService(manager *repo.Manager) *AdminService {
+	return &AdminService{
+		announcements: manager.Announcements,
+		unions:        manager.Unions,
+		members:       manager.UnionMembers,
+		ledgers:       manager.Ledgers,
+		tx:            manager.Tx,
+	}
+}
+
+func (a *AdminService) PublishAnnouncement(ctx context.Context, req *pb.PublishAnnouncementParams) (*pb.PublishAnnouncementResponse, error) {
+	if req.Title == "" || req.Content == "" || (req.EndTime != 0 && req.EndTime <= req.StartTime) {
+		return nil, msError.GrpcError(biz.RequestDataError)
+	}
+	now := time.Now().UnixMilli()
+	announcement := &entity.Announcement{
+		Title:      req.Title,
+		Content:    req.Content,
+		Sort:       int(req.Sort),
+		StartTime:  req.StartTime,
+		EndTime:    req.EndTime,
+		CreateTime: now,
+	}
+	if announcement.StartTime == 0 {
+		announcement.StartTime = now
+	}
+	if err := a.announcements.Create(ctx, announcement); err != nil {
+		logs.ErrorCtx(ctx, "publish announcement err: %v", err)
+		return nil, msError.GrpcError(biz.SqlError)
+	}
+	return &pb.PublishAnnouncementResponse{Id: announcement.Id.Hex()}, nil
+}
+
+func (a *AdminService) DeleteAnnouncement(ctx context.Context, req *pb.DeleteAnnouncementParams) (*pb.DeleteAnnouncementResponse, error) {
+	err := a.announcements.Delete(ctx, req.Id)
+	if err == repo.ErrNotFound {
+		return nil, msError.GrpcError(biz.RequestDataError)
+	}
+	if err != nil {
+		logs.ErrorCtx(ctx, "delete announcement err: %v", err)
+		return nil, msError.GrpcError(biz.SqlError)
+	}
+	return &pb.DeleteAnnouncementResponse{}, nil
+}
+
+// RechargeUnionScore 给盟主充值积分，联盟内的积分都来源于此，不允许通过充值扣减积分
+func (a *AdminService) RechargeUnionScore(ctx context.Context, req *pb.RechargeUnionScoreParams) (*pb.RechargeUnionScoreResponse, error) {
+	if req.Amount <= 0 || req.Operator == "" {
+		return nil, msError.GrpcError(biz.RequestDataError)
+	}
+	union, err := a.unions.FindByUnionId(ctx, req.UnionId)
+	if err == repo.ErrNotFound {
+		return nil, msError.GrpcError(biz.UnionNotExist)
+	}
+	if err != nil {
+		logs.ErrorCtx(ctx, "recharge union score find union err: %v", err)
+		return nil, msError.GrpcError(biz.SqlError)
+	}
+	var score int64
+	err = a.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if score, err = a.members.IncrScore(ctx, union.UnionId, union.OwnerUid, req.Amount); err != nil {
+			return err
+		}
+		return a.ledgers.Insert(ctx, &entity.Ledger{
+			Uid:        union.OwnerUid,
+			UnionId:    union.UnionId,
+			Type:       entity.LedgerUnionRecharge,
+			Amount:     req.Amount,
+			Balance:    score,
+			RelatedUid: req.Operator,
+			Remark:     req.Remark,
+			CreateTime: time.Now().UnixMilli(),
+		})
+	})
+	if err != nil {
+		logs.ErrorCtx(ctx, "recharge union score err: %v", err)
+		return nil, msError.GrpcError(biz.SqlError)
+	}
+	logs.InfoCtx(ctx, "union %d recharged %d score by %s, remark: %s", req.UnionId, req.Amount, req.Operator, req.Remark)
+	return &pb.RechargeUnionScoreResponse{Score: score}, nil
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"common/biz"
+	"common/config"
+	"common/database"
+	"common/logs"
+	"common/msError"
+	"context"
+	"core/repo"
+	"core/session"
+	"hall/pb"
+	"sort"
+	"time"
+)
+
+// HallService 大厅数据：游戏列表、公告、玩家当前所在房间、在线人数
+type HallService struct {
+	pb.UnimplementedHallServiceServer
+	users         repo.UserRepository
+	rooms         repo.RoomRepository
+	announcements repo.AnnouncementRepository
+	redis         *database.RedisManager
+}
+
+func NewHallService(manager *repo.Manager) *HallService {
+	return &HallService{
+		users:         manager.Users,
+		rooms:         manager.Rooms,
+		announcements: manager.Announcements,
+		redis:         manager.Redis,
+	}
+}
+
+// GameList 开启的游戏，来自配置文件，重新加载配置后立即生效
+func (h *HallService) GameList(ctx context.Context, req *pb.GameListParams) (*pb.GameListResponse, error) {
+	games := config.Current().Games
+	list := make([]*pb.Game, 0, len(games))
+	for _, g := range games {
+		if !g.Enable {
+			continue
+		}
+		list = append(list, &pb.Game{
+			GameType: int32(g.GameType),
+			Name:     g.Name,
+			Icon:     g.Icon,
+			Sort:     int32(g.Sort),
+		})
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].Sort > list[j].Sort
+	})
+	return &pb.GameListResponse{List: list}, nil
+}
+
+// Announcements 展示中的公告
+func (h *HallService) Announcements(ctx context.Context, req *pb.AnnouncementsParams) (*pb.AnnouncementsResponse, error) {
+	announcements, err := h.announcements.ListActive(ctx, time.Now().UnixMilli())
+	if err != nil {
+		logs.ErrorCtx(ctx, "list announcements err: %v", err)
+		return nil, msError.GrpcError(biz.SqlError)
+	}
+	list := make([]*pb.Announcement, 0, len(announcements))
+	for _, v := range announcements {
+		list = append(list, &pb.Announcement{
+			Id:        v.Id.Hex(),
+			Title:     v.Title,
+			Content:   v.Content,
+			Sort:      int32(v.Sort),
+			StartTime: v.StartTime,
+			EndTime:   v.EndTime,
+		})
+	}
+	return &pb.AnnouncementsResponse{List: list}, nil
+}
+
+// CurrentRoom 玩家当前所在的房间，断线重连后客户端据此回到房间
+func (h *HallService) CurrentRoom(ctx context.Context, req *pb.CurrentRoomParams) (*pb.CurrentRoomResponse, error) {
+	if req.Uid == "" {
+		return nil, msError.GrpcError(biz.RequestDataError)
+	}
+	user, err := h.users.FindByUid(ctx, req.Uid)
+	if err == repo.ErrNotFound {
+		return nil, msError.GrpcError(biz.NotFindUser)
+	}
+	if err != nil {
+		logs.ErrorCtx(ctx, "current room find user err: %v", err)
+		return nil, msError.GrpcError(biz.SqlError)
+	}
+	if user.RoomId == "" {
+		return &pb.CurrentRoomResponse{}, nil
+	}
+	room, err := h.rooms.FindByRoomId(ctx, user.RoomId)
+	if err == repo.ErrNotFound {
+		// 房间已经解散
+		return &pb.CurrentRoomResponse{}, nil
+	}
+	if err != nil {
+		logs.ErrorCtx(ctx, "current room find room err: %v", err)
+		return nil, msError.GrpcError(biz.SqlError)
+	}
+	return &pb.CurrentRoomResponse{
+		RoomId:   room.RoomId,
+		GameType: int32(room.GameType),
+	}, nil
+}
+
+// OnlineCount 所有connector的在线人数
+func (h *HallService) OnlineCount(ctx context.Context, req *pb.OnlineCountParams) (*pb.OnlineCountResponse, error) {
+	total, err := session.OnlineCount(ctx, h.redis)
+	if err != nil {
+		logs.ErrorCtx(ctx, "online count err: %v", err)
+		return nil, msError.GrpcError(biz.Fail)
+	}
+	return &pb.OnlineCountResponse{Total: total}, nil
+}
@@ -0,0 +1,243 @@
+package service
+
+import (
+	"common/biz"
+	"common/logs"
+	"common/msError"
+	"context"
+	"core/models/entity"
+	"core/repo"
+	"core/session"
+	"hall/pb"
+	"time"
+)
+
+// 积分日汇总默认、最多查询的天数
+const (
+	defaultSummaryDays = 7
+	maxSummaryDays     = 31
+)
+
+// SetUnionSwitch 盟主、管理员修改联盟的禁止赠送积分、禁止代理邀请开关
+func (u *UnionService) SetUnionSwitch(ctx context.Context, req *pb.SetUnionSwitchParams) (*pb.SetUnionSwitchResponse, error) {
+	operator, err := u.findMember(ctx, req.UnionId, req.Uid)
+	if err != nil {
+		return nil, err
+	}
+	if operator.Role > entity.UnionRoleAdmin {
+		return nil, msError.GrpcError(biz.PermissionNotEnough)
+	}
+	union, err := u.findUnion(ctx, req.UnionId)
+	if err != nil {
+		return nil, err
+	}
+	if req.ForbidGiveScore != nil {
+		union.ForbidGiveScore = *req.ForbidGiveScore
+	}
+	if req.ForbidInviteScore != nil {
+		union.ForbidInviteScore = *req.ForbidInviteScore
+	}
+	if err = u.unions.Update(ctx, union); err != nil {
+		logs.ErrorCtx(ctx, "set union switch err: %v", err)
+		return nil, msError.GrpcError(biz.SqlError)
+	}
+	logs.InfoCtx(ctx, "union %d switch set by %s, forbidGiveScore: %v, forbidInviteScore: %v",
+		req.UnionId, req.Uid, union.ForbidGiveScore, union.ForbidInviteScore)
+	return &pb.SetUnionSwitchResponse{}, nil
+}
+
+// GiveUnionScore 上级赠送积分给直属成员，开启禁止赠送后只有盟主可以赠送
+func (u *UnionService) GiveUnionScore(ctx context.Context, req *pb.GiveUnionScoreParams) (*pb.GiveUnionScoreResponse, error) {
+	operator, member, err := u.findDirectMember(ctx, req.UnionId, req.Uid, req.MemberUid, req.Amount)
+	if err != nil {
+		return nil, err
+	}
+	union, err := u.findUnion(ctx, req.UnionId)
+	if err != nil {
+		return nil, err
+	}
+	if union.ForbidGiveScore && operator.Role != entity.UnionRoleOwner {
+		return nil, msError.GrpcError(biz.ForbidGiveScore)
+	}
+	score, memberScore, err := u.transfer(ctx, req.UnionId, operator.Uid, member.Uid, req.Amount, entity.LedgerUnionGive)
+	if err != nil {
+		return nil, err
+	}
+	logs.InfoCtx(ctx, "union %d %s give %d score to %s", req.UnionId, req.Uid, req.Amount, req.MemberUid)
+	return &pb.GiveUnionScoreResponse{Score: score, MemberScore: memberScore}, nil
+}
+
+// TakeUnionScore 上级收回直属成员的积分
+func (u *UnionService) TakeUnionScore(ctx context.Context, req *pb.TakeUnionScoreParams) (*pb.TakeUnionScoreResponse, error) {
+	operator, member, err := u.findDirectMember(ctx, req.UnionId, req.Uid, req.MemberUid, req.Amount)
+	if err != nil {
+		return nil, err
+	}
+	memberScore, score, err := u.transfer(ctx, req.UnionId, member.Uid, operator.Uid, req.Amount, entity.LedgerUnionTake)
+	if err != nil {
+		return nil, err
+	}
+	logs.InfoCtx(ctx, "union %d %s take %d score from %s", req.UnionId, req.Uid, req.Amount, req.MemberUid)
+	return &pb.TakeUnionScoreResponse{Score: score, MemberScore: memberScore}, nil
+}
+
+// UnionScoreRecords 积分流水，按时间倒序，可以查询自己和自己能管理的成员
+func (u *UnionService) UnionScoreRecords(ctx context.Context, req *pb.UnionScoreRecordsParams) (*pb.UnionScoreRecordsResponse, error) {
+	limit := req.Limit
+	if limit <= 0 || limit > maxPageSize {
+		limit = defaultPageSize
+	}
+	if req.Offset < 0 {
+		return nil, msError.GrpcError(biz.RequestDataError)
+	}
+	uid, err := u.scoreTarget(ctx, req.UnionId, req.Uid, req.MemberUid)
+	if err != nil {
+		return nil, err
+	}
+	ledgers, err := u.ledgers.ListByUid(ctx, uid, req.UnionId, req.Offset, limit)
+	if err != nil {
+		logs.ErrorCtx(ctx, "list union score records err: %v", err)
+		return nil, msError.GrpcError(biz.SqlError)
+	}
+	list := make([]*pb.UnionScoreRecord, 0, len(ledgers))
+	for _, v := range ledgers {
+		list = append(list, &pb.UnionScoreRecord{
+			Id:         v.Id.Hex(),
+			Type:       int32(v.Type),
+			Amount:     v.Amount,
+			Balance:    v.Balance,
+			RelatedUid: v.RelatedUid,
+			Remark:     v.Remark,
+			CreateTime: v.CreateTime,
+		})
+	}
+	return &pb.UnionScoreRecordsResponse{List: list}, nil
+}
+
+// UnionScoreDaily 最近几天每天的积分收支汇总，按服务器本地时间划分日期
+func (u *UnionService) UnionScoreDaily(ctx context.Context, req *pb.UnionScoreDailyParams) (*pb.UnionScoreDailyResponse, error) {
+	days := int(req.Days)
+	if days <= 0 {
+		days = defaultSummaryDays
+	}
+	if days > maxSummaryDays {
+		return nil, msError.GrpcError(biz.RequestDataError)
+	}
+	uid, err := u.scoreTarget(ctx, req.UnionId, req.Uid, req.MemberUid)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	start := time.Date(now.Year(), now.Month(), now.Day()-days+1, 0, 0, 0, 0, now.Location())
+	summaries, err := u.ledgers.DailySummary(ctx, uid, req.UnionId, start.UnixMilli(), now.UnixMilli()+1)
+	if err != nil {
+		logs.ErrorCtx(ctx, "union score daily summary err: %v", err)
+		return nil, msError.GrpcError(biz.SqlError)
+	}
+	list := make([]*pb.UnionScoreDaily, 0, len(summaries))
+	for _, v := range summaries {
+		list = append(list, &pb.UnionScoreDaily{
+			Day:     v.Day,
+			Income:  v.Income,
+			Expense: v.Expense,
+			Count:   v.Count,
+		})
+	}
+	return &pb.UnionScoreDailyResponse{List: list}, nil
+}
+
+// transfer 持有双方的锁，在事务中把from的amount积分转给to，双方各记一条流水，返回双方变动后的积分
+func (u *UnionService) transfer(ctx context.Context, unionId int64, from, to string, amount int64, typ int) (int64, int64, error) {
+	var fromScore, toScore int64
+	err := session.LockMembers(ctx, u.redis, unionId, []string{from, to}, func(ctx context.Context) error {
+		return u.tx.WithTransaction(ctx, func(ctx context.Context) error {
+			var err error
+			if fromScore, err = u.members.IncrScore(ctx, unionId, from, -amount); err != nil {
+				return err
+			}
+			if toScore, err = u.members.IncrScore(ctx, unionId, to, amount); err != nil {
+				return err
+			}
+			return u.insertLedgers(ctx, unionId, from, to, amount, fromScore, toScore, typ)
+		})
+	})
+	if err == biz.UserDataLocked {
+		return 0, 0, msError.GrpcError(biz.UserDataLocked)
+	}
+	if err == repo.ErrNotEnough {
+		return 0, 0, msError.GrpcError(biz.NotEnoughScore)
+	}
+	if err == repo.ErrNotFound {
+		// 转移过程中成员退出了联盟
+		return 0, 0, msError.GrpcError(biz.NotInUnion)
+	}
+	if err != nil {
+		logs.ErrorCtx(ctx, "union %d transfer score %s -> %s err: %v", unionId, from, to, err)
+		return 0, 0, msError.GrpcError(biz.SqlError)
+	}
+	return fromScore, toScore, nil
+}
+
+// insertLedgers 转出、转入双方的流水，需要在事务中调用
+func (u *UnionService) insertLedgers(ctx context.Context, unionId int64, from, to string, amount, fromScore, toScore int64, typ int) error {
+	now := time.Now().UnixMilli()
+	err := u.ledgers.Insert(ctx, &entity.Ledger{
+		Uid:        from,
+		UnionId:    unionId,
+		Type:       typ,
+		Amount:     -amount,
+		Balance:    fromScore,
+		RelatedUid: to,
+		CreateTime: now,
+	})
+	if err != nil {
+		return err
+	}
+	return u.ledgers.Insert(ctx, &entity.Ledger{
+		Uid:        to,
+		UnionId:    unionId,
+		Type:       typ,
+		Amount:     amount,
+		Balance:    toScore,
+		RelatedUid: from,
+		CreateTime: now,
+	})
+}
+
+// findDirectMember 积分只能在上级和直属成员之间转移
+func (u *UnionService) findDirectMember(ctx context.Context, unionId int64, uid, memberUid string, amount int64) (*entity.UnionMember, *entity.UnionMember, error) {
+	if amount <= 0 || memberUid == "" || memberUid == uid {
+		return nil, nil, msError.GrpcError(biz.RequestDataError)
+	}
+	operator, err := u.findMember(ctx, unionId, uid)
+	if err != nil {
+		return nil, nil, err
+	}
+	member, err := u.findMember(ctx, unionId, memberUid)
+	if err != nil {
+		return nil, nil, err
+	}
+	if member.SuperiorUid != operator.Uid {
+		return nil, nil, msError.GrpcError(biz.NotYourMember)
+	}
+	return operator, member, nil
+}
+
+// scoreTarget 查询积分的目标用户，memberUid为空时为自己，查询其他成员时需要有管理权限
+func (u *UnionService) scoreTarget(ctx context.Context, unionId int64, uid, memberUid string) (string, error) {
+	operator, err := u.findMember(ctx, unionId, uid)
+	if err != nil {
+		return "", err
+	}
+	if memberUid == "" || memberUid == uid {
+		return uid, nil
+	}
+	member, err := u.findMember(ctx, unionId, memberUid)
+	if err != nil {
+		return "", err
+	}
+	if err = checkManage(operator, member); err != nil {
+		return "", err
+	}
+	return memberUid, nil
+}
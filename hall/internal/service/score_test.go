@@ -0,0 +1,240 @@
+package service
+
+import (
+	"common/biz"
+	"context"
+	"core/models/entity"
+	"hall/pb"
+	"testing"
+	"time"
+)
+
+// recharge 后台给盟主充值积分
+func recharge(t *testing.T, u *UnionService, unionId int64, amount int64) {
+	t.Helper()
+	admin := &AdminService{unions: u.unions, members: u.members, ledgers: u.ledgers, tx: u.tx}
+	_, err := admin.RechargeUnionScore(context.Background(), &pb.RechargeUnionScoreParams{UnionId: unionId, Amount: amount, Operator: "admin"})
+	if err != nil {
+		t.Fatalf("recharge: %v", err)
+	}
+}
+
+func score(t *testing.T, u *UnionService, unionId int64, uid string) int64 {
+	t.Helper()
+	member, err := u.members.Find(context.Background(), unionId, uid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return member.Score
+}
+
+func TestAdminService_RechargeUnionScore(t *testing.T) {
+	u, unionId := newTestUnion(t)
+	admin := &AdminService{unions: u.unions, members: u.members, ledgers: u.ledgers, tx: u.tx}
+	tests := []struct {
+		name   string
+		amount int64
+		code   int
+	}{
+		{"zero", 0, biz.RequestDataError.Code},
+		{"negative", -10, biz.RequestDataError.Code},
+		{"recharge", 10, biz.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := admin.RechargeUnionScore(context.Background(), &pb.RechargeUnionScoreParams{UnionId: unionId, Amount: tt.amount, Operator: "admin"})
+			if code := errCode(err); code != tt.code {
+				t.Fatalf("code = %d, want %d, err: %v", code, tt.code, err)
+			}
+		})
+	}
+	if s := score(t, u, unionId, "owner"); s != 10 {
+		t.Fatalf("owner score = %d, want 10", s)
+	}
+}
+
+func TestUnionService_GiveUnionScore(t *testing.T) {
+	ctx := context.Background()
+	u, unionId := newTestUnion(t)
+	recharge(t, u, unionId, 1000)
+	tests := []struct {
+		name     string
+		operator string
+		member   string
+		amount   int64
+		code     int
+	}{
+		{"zero amount", "owner", "admin", 0, biz.RequestDataError.Code},
+		{"give self", "owner", "owner", 100, biz.RequestDataError.Code},
+		{"owner give admin", "owner", "admin", 500, biz.OK},
+		{"not direct member", "admin", "p1", 100, biz.NotYourMember.Code},
+		{"superior give up", "agent", "admin", 100, biz.NotYourMember.Code},
+		{"admin give agent", "admin", "agent", 200, biz.OK},
+		{"not enough", "agent", "p1", 300, biz.NotEnoughScore.Code},
+		{"agent give player", "agent", "p1", 100, biz.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := u.GiveUnionScore(ctx, &pb.GiveUnionScoreParams{Uid: tt.operator, UnionId: unionId, MemberUid: tt.member, Amount: tt.amount})
+			if code := errCode(err); code != tt.code {
+				t.Fatalf("code = %d, want %d, err: %v", code, tt.code, err)
+			}
+		})
+	}
+	want := map[string]int64{"owner": 500, "admin": 300, "agent": 100, "p1": 100, "p2": 0}
+	for uid, s := range want {
+		if got := score(t, u, unionId, uid); got != s {
+			t.Fatalf("%s score = %d, want %d", uid, got, s)
+		}
+	}
+
+	// 禁止赠送后只有盟主可以赠送
+	forbid := true
+	if _, err := u.SetUnionSwitch(ctx, &pb.SetUnionSwitchParams{Uid: "agent", UnionId: unionId, ForbidGiveScore: &forbid}); errCode(err) != biz.PermissionNotEnough.Code {
+		t.Fatalf("agent set switch err = %v", err)
+	}
+	if _, err := u.SetUnionSwitch(ctx, &pb.SetUnionSwitchParams{Uid: "admin", UnionId: unionId, ForbidGiveScore: &forbid}); err != nil {
+		t.Fatal(err)
+	}
+	_, err := u.GiveUnionScore(ctx, &pb.GiveUnionScoreParams{Uid: "agent", UnionId: unionId, MemberUid: "p1", Amount: 10})
+	if code := errCode(err); code != biz.ForbidGiveScore.Code {
+		t.Fatalf("forbid give code = %d, want %d", code, biz.ForbidGiveScore.Code)
+	}
+	if _, err = u.GiveUnionScore(ctx, &pb.GiveUnionScoreParams{Uid: "owner", UnionId: unionId, MemberUid: "p2", Amount: 10}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUnionService_TakeUnionScore(t *testing.T) {
+	ctx := context.Background()
+	u, unionId := newTestUnion(t)
+	recharge(t, u, unionId, 100)
+	if _, err := u.GiveUnionScore(ctx, &pb.GiveUnionScoreParams{Uid: "owner", UnionId: unionId, MemberUid: "p2", Amount: 100}); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name     string
+		operator string
+		member   string
+		amount   int64
+		code     int
+	}{
+		{"not direct member", "admin", "p2", 10, biz.NotYourMember.Code},
+		{"not enough", "owner", "p2", 101, biz.NotEnoughScore.Code},
+		{"take", "owner", "p2", 60, biz.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := u.TakeUnionScore(ctx, &pb.TakeUnionScoreParams{Uid: tt.operator, UnionId: unionId, MemberUid: tt.member, Amount: tt.amount})
+			if code := errCode(err); code != tt.code {
+				t.Fatalf("code = %d, want %d, err: %v", code, tt.code, err)
+			}
+		})
+	}
+	if s := score(t, u, unionId, "owner"); s != 60 {
+		t.Fatalf("owner score = %d, want 60", s)
+	}
+
+	// 被踢出时剩余积分退回上级
+	if _, err := u.KickUnionMember(ctx, &pb.KickUnionMemberParams{Uid: "owner", UnionId: unionId, MemberUid: "p2"}); err != nil {
+		t.Fatal(err)
+	}
+	if s := score(t, u, unionId, "owner"); s != 100 {
+		t.Fatalf("owner score after kick = %d, want 100", s)
+	}
+}
+
+func TestUnionService_UnionScoreRecords(t *testing.T) {
+	ctx := context.Background()
+	u, unionId := newTestUnion(t)
+	recharge(t, u, unionId, 1000)
+	for _, req := range []*pb.GiveUnionScoreParams{
+		{Uid: "owner", UnionId: unionId, MemberUid: "admin", Amount: 500},
+		{Uid: "admin", UnionId: unionId, MemberUid: "agent", Amount: 300},
+		{Uid: "agent", UnionId: unionId, MemberUid: "p1", Amount: 100},
+	} {
+		if _, err := u.GiveUnionScore(ctx, req); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := u.TakeUnionScore(ctx, &pb.TakeUnionScoreParams{Uid: "agent", UnionId: unionId, MemberUid: "p1", Amount: 40}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		operator string
+		member   string
+		code     int
+		amounts  []int64
+	}{
+		{"self", "p1", "", biz.OK, []int64{-40, 100}},
+		{"superior", "agent", "p1", biz.OK, []int64{-40, 100}},
+		{"owner any member", "owner", "p1", biz.OK, []int64{-40, 100}},
+		{"agent self", "agent", "agent", biz.OK, []int64{40, -100, 300}},
+		{"player other", "p2", "p1", biz.PermissionNotEnough.Code, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := u.UnionScoreRecords(ctx, &pb.UnionScoreRecordsParams{Uid: tt.operator, UnionId: unionId, MemberUid: tt.member})
+			if code := errCode(err); code != tt.code {
+				t.Fatalf("code = %d, want %d, err: %v", code, tt.code, err)
+			}
+			if err != nil {
+				return
+			}
+			if len(res.List) != len(tt.amounts) {
+				t.Fatalf("records = %v, want amounts %v", res.List, tt.amounts)
+			}
+			for i, v := range res.List {
+				if v.Amount != tt.amounts[i] {
+					t.Fatalf("records[%d] amount = %d, want %d", i, v.Amount, tt.amounts[i])
+				}
+			}
+		})
+	}
+
+	res, err := u.UnionScoreRecords(ctx, &pb.UnionScoreRecordsParams{Uid: "p1", UnionId: unionId})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if take := res.List[0]; take.Type != entity.LedgerUnionTake || take.Balance != 60 || take.RelatedUid != "agent" {
+		t.Fatalf("take record = %v", take)
+	}
+
+	daily, err := u.UnionScoreDaily(ctx, &pb.UnionScoreDailyParams{Uid: "agent", UnionId: unionId, MemberUid: "p1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	today := time.Now().Format("2006-01-02")
+	if len(daily.List) != 1 || daily.List[0].Day != today || daily.List[0].Income != 100 || daily.List[0].Expense != -40 || daily.List[0].Count != 2 {
+		t.Fatalf("daily = %v", daily.List)
+	}
+	if _, err = u.UnionScoreDaily(ctx, &pb.UnionScoreDailyParams{Uid: "p1", UnionId: unionId, Days: maxSummaryDays + 1}); errCode(err) != biz.RequestDataError.Code {
+		t.Fatalf("too many days err = %v", err)
+	}
+}
+
+func TestUnionService_ForbidInvite(t *testing.T) {
+	ctx := context.Background()
+	u, unionId := newTestUnion(t)
+	forbid := true
+	if _, err := u.SetUnionSwitch(ctx, &pb.SetUnionSwitchParams{Uid: "owner", UnionId: unionId, ForbidInviteScore: &forbid}); err != nil {
+		t.Fatal(err)
+	}
+	agent, err := u.members.Find(ctx, unionId, "agent")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = u.JoinUnion(ctx, &pb.JoinUnionParams{Uid: "p3", InviteCode: agent.InviteCode})
+	if code := errCode(err); code != biz.ForbidInviteScore.Code {
+		t.Fatalf("join by agent code = %d, want %d", code, biz.ForbidInviteScore.Code)
+	}
+	admin, err := u.members.Find(ctx, unionId, "admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = u.JoinUnion(ctx, &pb.JoinUnionParams{Uid: "p3", InviteCode: admin.InviteCode}); err != nil {
+		t.Fatal(err)
+	}
+}
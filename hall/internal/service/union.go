@@ -0,0 +1,449 @@
+package service
+
+import (
+	"common/biz"
+	"common/database"
+	"common/logs"
+	"common/msError"
+	"common/sensitive"
+	"context"
+	"core/models/entity"
+	"core/repo"
+	"core/session"
+	"crypto/rand"
+	"fmt"
+	"hall/pb"
+	"math/big"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	unionIdStart    = 100000 // 联盟id从100000开始
+	maxUnionNameLen = 12     // 联盟名称最大字数
+	inviteCodeLen   = 8      // 邀请码位数
+	inviteCodeRetry = 5      // 生成邀请码重复时的重试次数
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// UnionService 联盟（牌友圈）：创建、邀请码加入、退出、踢人、设置成员角色、成员列表，以及联盟积分
+// 成员分为盟主、管理员、代理、玩家，通过上级的邀请码加入，成为其直属成员
+// 积分由后台充值给盟主，之后只能在上级和直属成员之间转移
+type UnionService struct {
+	pb.UnimplementedUnionServiceServer
+	users    repo.UserRepository
+	unions   repo.UnionRepository
+	members  repo.UnionMemberRepository
+	counters repo.CounterRepository
+	ledgers  repo.LedgerRepository
+	tx       database.Transactor
+	redis    *database.RedisManager
+}
+
+func NewUnionService(manager *repo.Manager) *UnionService {
+	return &UnionService{
+		users:    manager.Users,
+		unions:   manager.Unions,
+		members:  manager.UnionMembers,
+		counters: manager.Counters,
+		ledgers:  manager.Ledgers,
+		tx:       manager.Tx,
+		redis:    manager.Redis,
+	}
+}
+
+// CreateUnion 创建联盟，每个用户只能创建一个，创建者成为盟主
+func (u *UnionService) CreateUnion(ctx context.Context, req *pb.CreateUnionParams) (*pb.CreateUnionResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if req.Uid == "" || name == "" || utf8.RuneCountInString(name) > maxUnionNameLen {
+		return nil, msError.GrpcError(biz.RequestDataError)
+	}
+	if sensitive.Contains(name) {
+		return nil, msError.GrpcError(biz.ContentIllegal)
+	}
+	_, err := u.unions.FindByOwner(ctx, req.Uid)
+	if err == nil {
+		return nil, msError.GrpcError(biz.AlreadyCreatedUnion)
+	}
+	if err != repo.ErrNotFound {
+		logs.ErrorCtx(ctx, "create union find owner err: %v", err)
+		return nil, msError.GrpcError(biz.SqlError)
+	}
+
+	now := time.Now().UnixMilli()
+	union := &entity.Union{
+		Name:       name,
+		OwnerUid:   req.Uid,
+		CreateTime: now,
+	}
+	owner := &entity.UnionMember{
+		Uid:      req.Uid,
+		Role:     entity.UnionRoleOwner,
+		JoinTime: now,
+	}
+	if union.UnionId, err = u.counters.Next(ctx, "unionId", unionIdStart); err != nil {
+		logs.ErrorCtx(ctx, "create union gen id err: %v", err)
+		return nil, msError.GrpcError(biz.SqlError)
+	}
+	owner.UnionId = union.UnionId
+	if owner.InviteCode, err = u.newInviteCode(ctx); err != nil {
+		logs.ErrorCtx(ctx, "create union gen invite code err: %v", err)
+		return nil, msError.GrpcError(biz.SqlError)
+	}
+	err = u.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := u.unions.Create(ctx, union); err != nil {
+			return err
+		}
+		return u.members.Create(ctx, owner)
+	})
+	if err == repo.ErrDuplicate {
+		// 并发创建，唯一索引兜底
+		return nil, msError.GrpcError(biz.AlreadyCreatedUnion)
+	}
+	if err != nil {
+		logs.ErrorCtx(ctx, "create union err: %v", err)
+		return nil, msError.GrpcError(biz.SqlError)
+	}
+	logs.InfoCtx(ctx, "union %d created by %s", union.UnionId, req.Uid)
+	return &pb.CreateUnionResponse{Union: unionInfo(union, owner, 1)}, nil
+}
+
+// JoinUnion 通过邀请码加入联盟，成为邀请人的直属玩家
+func (u *UnionService) JoinUnion(ctx context.Context, req *pb.JoinUnionParams) (*pb.JoinUnionResponse, error) {
+	if req.Uid == "" || req.InviteCode == "" {
+		return nil, msError.GrpcError(biz.RequestDataError)
+	}
+	inviter, err := u.members.FindByInviteCode(ctx, req.InviteCode)
+	if err == repo.ErrNotFound || (err == nil && !inviter.CanInvite()) {
+		return nil, msError.GrpcError(biz.InviteIdError)
+	}
+	if err != nil {
+		logs.ErrorCtx(ctx, "join union find invite code err: %v", err)
+		return nil, msError.GrpcError(biz.SqlError)
+	}
+	union, err := u.findUnion(ctx, inviter.UnionId)
+	if err != nil {
+		return nil, err
+	}
+	if union.ForbidInviteScore && inviter.Role == entity.UnionRoleAgent {
+		return nil, msError.GrpcError(biz.ForbidInviteScore)
+	}
+	member := &entity.UnionMember{
+		UnionId:     union.UnionId,
+		Uid:         req.Uid,
+		Role:        entity.UnionRolePlayer,
+		SuperiorUid: inviter.Uid,
+		JoinTime:    time.Now().UnixMilli(),
+	}
+	err = u.members.Create(ctx, member)
+	if err == repo.ErrDuplicate {
+		return nil, msError.GrpcError(biz.AlreadyInUnion)
+	}
+	if err != nil {
+		logs.ErrorCtx(ctx, "join union create member err: %v", err)
+		return nil, msError.GrpcError(biz.SqlError)
+	}
+	count, err := u.members.Count(ctx, union.UnionId, "")
+	if err != nil {
+		logs.WarnCtx(ctx, "join union count members err: %v", err)
+	}
+	logs.InfoCtx(ctx, "%s joined union %d, invited by %s", req.Uid, union.UnionId, inviter.Uid)
+	return &pb.JoinUnionResponse{Union: unionInfo(union, member, count)}, nil
+}
+
+// LeaveUnion 退出联盟，直属成员转给自己的上级，盟主不能退出
+func (u *UnionService) LeaveUnion(ctx context.Context, req *pb.LeaveUnionParams) (*pb.LeaveUnionResponse, error) {
+	member, err := u.findMember(ctx, req.UnionId, req.Uid)
+	if err != nil {
+		return nil, err
+	}
+	if member.Role == entity.UnionRoleOwner {
+		return nil, msError.GrpcError(biz.PermissionNotEnough)
+	}
+	if err = u.remove(ctx, member); err == biz.UserDataLocked {
+		return nil, msError.GrpcError(biz.UserDataLocked)
+	}
+	if err != nil {
+		logs.ErrorCtx(ctx, "leave union err: %v", err)
+		return nil, msError.GrpcError(biz.SqlError)
+	}
+	logs.InfoCtx(ctx, "%s left union %d", req.Uid, req.UnionId)
+	return &pb.LeaveUnionResponse{}, nil
+}
+
+// KickUnionMember 踢出成员，盟主、管理员可以踢出角色比自己低的成员，代理只能踢出自己的直属成员
+func (u *UnionService) KickUnionMember(ctx context.Context, req *pb.KickUnionMemberParams) (*pb.KickUnionMemberResponse, error) {
+	if req.MemberUid == "" || req.MemberUid == req.Uid {
+		return nil, msError.GrpcError(biz.RequestDataError)
+	}
+	operator, err := u.findMember(ctx, req.UnionId, req.Uid)
+	if err != nil {
+		return nil, err
+	}
+	member, err := u.findMember(ctx, req.UnionId, req.MemberUid)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkManage(operator, member); err != nil {
+		return nil, err
+	}
+	if err = u.remove(ctx, member); err == biz.UserDataLocked {
+		return nil, msError.GrpcError(biz.UserDataLocked)
+	}
+	if err != nil {
+		logs.ErrorCtx(ctx, "kick union member err: %v", err)
+		return nil, msError.GrpcError(biz.SqlError)
+	}
+	logs.InfoCtx(ctx, "%s kicked from union %d by %s", req.MemberUid, req.UnionId, req.Uid)
+	return &pb.KickUnionMemberResponse{}, nil
+}
+
+// SetUnionMemberRole 设置成员角色，只有盟主可以任免管理员，管理员可以设置代理、玩家
+// 升为代理、管理员时生成邀请码，降为玩家时收回邀请码，直属成员转给其上级
+func (u *UnionService) SetUnionMemberRole(ctx context.Context, req *pb.SetUnionMemberRoleParams) (*pb.SetUnionMemberRoleResponse, error) {
+	role := int(req.Role)
+	if req.MemberUid == "" || req.MemberUid == req.Uid || role <= entity.UnionRoleOwner || role > entity.UnionRolePlayer {
+		return nil, msError.GrpcError(biz.RequestDataError)
+	}
+	operator, err := u.findMember(ctx, req.UnionId, req.Uid)
+	if err != nil {
+		return nil, err
+	}
+	member, err := u.findMember(ctx, req.UnionId, req.MemberUid)
+	if err != nil {
+		return nil, err
+	}
+	if operator.Role > entity.UnionRoleAdmin || operator.Role >= member.Role || operator.Role >= role {
+		return nil, msError.GrpcError(biz.PermissionNotEnough)
+	}
+	if member.Role == role {
+		return &pb.SetUnionMemberRoleResponse{}, nil
+	}
+
+	demote := role == entity.UnionRolePlayer
+	member.Role = role
+	if demote {
+		member.InviteCode = ""
+	} else if member.InviteCode == "" {
+		if member.InviteCode, err = u.newInviteCode(ctx); err != nil {
+			logs.ErrorCtx(ctx, "set union member role gen invite code err: %v", err)
+			return nil, msError.GrpcError(biz.SqlError)
+		}
+	}
+	err = u.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		if demote {
+			if err := u.members.TransferSubordinates(ctx, member.UnionId, member.Uid, member.SuperiorUid); err != nil {
+				return err
+			}
+		}
+		return u.members.UpdateRole(ctx, member.UnionId, member.Uid, member.Role, member.InviteCode)
+	})
+	if err != nil {
+		logs.ErrorCtx(ctx, "set union member role err: %v", err)
+		return nil, msError.GrpcError(biz.SqlError)
+	}
+	logs.InfoCtx(ctx, "union %d member %s set role %d by %s", req.UnionId, req.MemberUid, role, req.Uid)
+	return &pb.SetUnionMemberRoleResponse{}, nil
+}
+
+// UnionMembers 分页查询成员，盟主、管理员可以查询所有成员，代理只能查询直属成员
+func (u *UnionService) UnionMembers(ctx context.Context, req *pb.UnionMembersParams) (*pb.UnionMembersResponse, error) {
+	limit := req.Limit
+	if limit <= 0 || limit > maxPageSize {
+		limit = defaultPageSize
+	}
+	if req.Offset < 0 {
+		return nil, msError.GrpcError(biz.RequestDataError)
+	}
+	operator, err := u.findMember(ctx, req.UnionId, req.Uid)
+	if err != nil {
+		return nil, err
+	}
+	if !operator.CanInvite() {
+		return nil, msError.GrpcError(biz.PermissionNotEnough)
+	}
+	var superiorUid string
+	if req.OnlyDirect || operator.Role == entity.UnionRoleAgent {
+		superiorUid = operator.Uid
+	}
+	total, err := u.members.Count(ctx, req.UnionId, superiorUid)
+	if err != nil {
+		logs.ErrorCtx(ctx, "count union members err: %v", err)
+		return nil, msError.GrpcError(biz.SqlError)
+	}
+	members, err := u.members.List(ctx, req.UnionId, superiorUid, req.Offset, limit)
+	if err != nil {
+		logs.ErrorCtx(ctx, "list union members err: %v", err)
+		return nil, msError.GrpcError(biz.SqlError)
+	}
+
+	uids := make([]string, 0, len(members))
+	for _, v := range members {
+		uids = append(uids, v.Uid)
+	}
+	users, err := u.users.FindByUids(ctx, uids)
+	if err != nil {
+		logs.ErrorCtx(ctx, "list union members find users err: %v", err)
+		return nil, msError.GrpcError(biz.SqlError)
+	}
+	userMap := make(map[string]*entity.User, len(users))
+	for _, v := range users {
+		userMap[v.Uid] = v
+	}
+	list := make([]*pb.UnionMember, 0, len(members))
+	for _, v := range members {
+		m := &pb.UnionMember{
+			Uid:         v.Uid,
+			Role:        int32(v.Role),
+			SuperiorUid: v.SuperiorUid,
+			JoinTime:    v.JoinTime,
+			Score:       v.Score,
+		}
+		if user, ok := userMap[v.Uid]; ok {
+			m.Nickname = user.Nickname
+			m.Avatar = user.Avatar
+		}
+		list = append(list, m)
+	}
+	return &pb.UnionMembersResponse{Total: total, List: list}, nil
+}
+
+// MyUnions 用户加入的所有联盟
+func (u *UnionService) MyUnions(ctx context.Context, req *pb.MyUnionsParams) (*pb.MyUnionsResponse, error) {
+	if req.Uid == "" {
+		return nil, msError.GrpcError(biz.RequestDataError)
+	}
+	members, err := u.members.ListByUid(ctx, req.Uid)
+	if err != nil {
+		logs.ErrorCtx(ctx, "my unions list err: %v", err)
+		return nil, msError.GrpcError(biz.SqlError)
+	}
+	list := make([]*pb.Union, 0, len(members))
+	for _, member := range members {
+		union, err := u.unions.FindByUnionId(ctx, member.UnionId)
+		if err == repo.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			logs.ErrorCtx(ctx, "my unions find union err: %v", err)
+			return nil, msError.GrpcError(biz.SqlError)
+		}
+		count, err := u.members.Count(ctx, member.UnionId, "")
+		if err != nil {
+			logs.ErrorCtx(ctx, "my unions count members err: %v", err)
+			return nil, msError.GrpcError(biz.SqlError)
+		}
+		list = append(list, unionInfo(union, member, count))
+	}
+	return &pb.MyUnionsResponse{List: list}, nil
+}
+
+// checkManage operator是否可以管理member：角色必须比对方高，代理只能管理直属成员
+func checkManage(operator, member *entity.UnionMember) error {
+	if operator.Role >= member.Role {
+		return msError.GrpcError(biz.PermissionNotEnough)
+	}
+	if operator.Role == entity.UnionRoleAgent && member.SuperiorUid != operator.Uid {
+		return msError.GrpcError(biz.NotYourMember)
+	}
+	return nil
+}
+
+// remove 移除成员，直属成员转给其上级，剩余积分退回上级，持有双方的锁避免和积分转移并发
+func (u *UnionService) remove(ctx context.Context, member *entity.UnionMember) error {
+	uids := []string{member.Uid, member.SuperiorUid}
+	return session.LockMembers(ctx, u.redis, member.UnionId, uids, func(ctx context.Context) error {
+		return u.removeTx(ctx, member)
+	})
+}
+
+func (u *UnionService) removeTx(ctx context.Context, member *entity.UnionMember) error {
+	return u.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := u.members.TransferSubordinates(ctx, member.UnionId, member.Uid, member.SuperiorUid); err != nil {
+			return err
+		}
+		// 重新读取积分，避免使用过期的数据
+		m, err := u.members.Find(ctx, member.UnionId, member.Uid)
+		if err != nil {
+			return err
+		}
+		if m.Score > 0 {
+			score, err := u.members.IncrScore(ctx, member.UnionId, member.SuperiorUid, m.Score)
+			if err != nil {
+				return err
+			}
+			if err = u.insertLedgers(ctx, member.UnionId, member.Uid, member.SuperiorUid, m.Score, 0, score, entity.LedgerUnionReturn); err != nil {
+				return err
+			}
+		}
+		return u.members.Delete(ctx, member.UnionId, member.Uid)
+	})
+}
+
+func (u *UnionService) findUnion(ctx context.Context, unionId int64) (*entity.Union, error) {
+	union, err := u.unions.FindByUnionId(ctx, unionId)
+	if err == repo.ErrNotFound {
+		return nil, msError.GrpcError(biz.UnionNotExist)
+	}
+	if err != nil {
+		logs.ErrorCtx(ctx, "find union %d err: %v", unionId, err)
+		return nil, msError.GrpcError(biz.SqlError)
+	}
+	return union, nil
+}
+
+// findMember 用户在联盟中的成员信息，不是成员时返回NotInUnion
+func (u *UnionService) findMember(ctx context.Context, unionId int64, uid string) (*entity.UnionMember, error) {
+	if unionId <= 0 || uid == "" {
+		return nil, msError.GrpcError(biz.RequestDataError)
+	}
+	member, err := u.members.Find(ctx, unionId, uid)
+	if err == repo.ErrNotFound {
+		return nil, msError.GrpcError(biz.NotInUnion)
+	}
+	if err != nil {
+		logs.ErrorCtx(ctx, "find union %d member %s err: %v", unionId, uid, err)
+		return nil, msError.GrpcError(biz.SqlError)
+	}
+	return member, nil
+}
+
+// newInviteCode 生成未被使用的邀请码，极小概率并发生成相同的邀请码时由唯一索引兜底
+func (u *UnionService) newInviteCode(ctx context.Context) (string, error) {
+	max := big.NewInt(1)
+	for i := 0; i < inviteCodeLen; i++ {
+		max.Mul(max, big.NewInt(10))
+	}
+	for i := 0; i < inviteCodeRetry; i++ {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		code := fmt.Sprintf("%0*d", inviteCodeLen, n)
+		_, err = u.members.FindByInviteCode(ctx, code)
+		if err == repo.ErrNotFound {
+			return code, nil
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	return "", fmt.Errorf("invite code conflict after %d retries", inviteCodeRetry)
+}
+
+func unionInfo(union *entity.Union, member *entity.UnionMember, memberCount int64) *pb.Union {
+	return &pb.Union{
+		UnionId:           union.UnionId,
+		Name:              union.Name,
+		OwnerUid:          union.OwnerUid,
+		Role:              int32(member.Role),
+		InviteCode:        member.InviteCode,
+		MemberCount:       memberCount,
+		CreateTime:        union.CreateTime,
+		ForbidGiveScore:   union.ForbidGiveScore,
+		ForbidInviteScore: union.ForbidInviteScore,
+		Score:             member.Score,
+	}
+}
@@ -0,0 +1,279 @@
+package service
+
+import (
+	"common/biz"
+	"common/msError"
+	"common/sensitive"
+	"context"
+	"core/models/entity"
+	"core/repo"
+	"core/repo/repotest"
+	"hall/pb"
+	"testing"
+)
+
+func errCode(err error) int {
+	if err == nil {
+		return biz.OK
+	}
+	return msError.ToError(err).Code
+}
+
+func newTestUnionService(t *testing.T) *UnionService {
+	manager := repotest.New(t)
+	return NewUnionService(manager)
+}
+
+// createUnion 创建联盟，返回联盟id和盟主的邀请码
+func createUnion(t *testing.T, u *UnionService, owner string) (int64, string) {
+	t.Helper()
+	res, err := u.CreateUnion(context.Background(), &pb.CreateUnionParams{Uid: owner, Name: "牌友圈"})
+	if err != nil {
+		t.Fatalf("create union: %v", err)
+	}
+	return res.Union.UnionId, res.Union.InviteCode
+}
+
+func join(t *testing.T, u *UnionService, uid, inviteCode string) {
+	t.Helper()
+	if _, err := u.JoinUnion(context.Background(), &pb.JoinUnionParams{Uid: uid, InviteCode: inviteCode}); err != nil {
+		t.Fatalf("%s join union: %v", uid, err)
+	}
+}
+
+func setRole(t *testing.T, u *UnionService, unionId int64, operator, uid string, role int) string {
+	t.Helper()
+	ctx := context.Background()
+	_, err := u.SetUnionMemberRole(ctx, &pb.SetUnionMemberRoleParams{Uid: operator, UnionId: unionId, MemberUid: uid, Role: int32(role)})
+	if err != nil {
+		t.Fatalf("set %s role %d: %v", uid, role, err)
+	}
+	member, err := u.members.Find(ctx, unionId, uid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return member.InviteCode
+}
+
+func TestUnionService_CreateUnion(t *testing.T) {
+	sensitive.Load([]string{"官方"})
+	t.Cleanup(func() { sensitive.Load(nil) })
+	u := newTestUnionService(t)
+	tests := []struct {
+		name string
+		req  *pb.CreateUnionParams
+		code int
+	}{
+		{"empty name", &pb.CreateUnionParams{Uid: "10000", Name: " "}, biz.RequestDataError.Code},
+		{"name too long", &pb.CreateUnionParams{Uid: "10000", Name: "一二三四五六七八九十一二三"}, biz.RequestDataError.Code},
+		{"sensitive name", &pb.CreateUnionParams{Uid: "10000", Name: "官 方牌友圈"}, biz.ContentIllegal.Code},
+		{"success", &pb.CreateUnionParams{Uid: "10000", Name: "牌友圈"}, biz.OK},
+		{"already created", &pb.CreateUnionParams{Uid: "10000", Name: "牌友圈2"}, biz.AlreadyCreatedUnion.Code},
+		{"another owner", &pb.CreateUnionParams{Uid: "10001", Name: "牌友圈"}, biz.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := u.CreateUnion(context.Background(), tt.req)
+			if code := errCode(err); code != tt.code {
+				t.Fatalf("code = %d, want %d, err: %v", code, tt.code, err)
+			}
+			if err != nil {
+				return
+			}
+			if res.Union.Role != entity.UnionRoleOwner || len(res.Union.InviteCode) != inviteCodeLen || res.Union.MemberCount != 1 {
+				t.Fatalf("union = %v", res.Union)
+			}
+		})
+	}
+}
+
+func TestUnionService_JoinUnion(t *testing.T) {
+	u := newTestUnionService(t)
+	unionId, code := createUnion(t, u, "10000")
+	tests := []struct {
+		name string
+		req  *pb.JoinUnionParams
+		code int
+	}{
+		{"empty invite code", &pb.JoinUnionParams{Uid: "10001"}, biz.RequestDataError.Code},
+		{"wrong invite code", &pb.JoinUnionParams{Uid: "10001", InviteCode: "x"}, biz.InviteIdError.Code},
+		{"success", &pb.JoinUnionParams{Uid: "10001", InviteCode: code}, biz.OK},
+		{"already in union", &pb.JoinUnionParams{Uid: "10001", InviteCode: code}, biz.AlreadyInUnion.Code},
+		{"owner join own union", &pb.JoinUnionParams{Uid: "10000", InviteCode: code}, biz.AlreadyInUnion.Code},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := u.JoinUnion(context.Background(), tt.req)
+			if code := errCode(err); code != tt.code {
+				t.Fatalf("code = %d, want %d, err: %v", code, tt.code, err)
+			}
+		})
+	}
+
+	member, err := u.members.Find(context.Background(), unionId, "10001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if member.Role != entity.UnionRolePlayer || member.SuperiorUid != "10000" || member.InviteCode != "" {
+		t.Fatalf("member = %+v", member)
+	}
+}
+
+// 成员层级：盟主owner - 管理员admin - 代理agent - 玩家p1，盟主直属玩家p2
+func newTestUnion(t *testing.T) (*UnionService, int64) {
+	u := newTestUnionService(t)
+	unionId, code := createUnion(t, u, "owner")
+	join(t, u, "admin", code)
+	adminCode := setRole(t, u, unionId, "owner", "admin", entity.UnionRoleAdmin)
+	join(t, u, "agent", adminCode)
+	agentCode := setRole(t, u, unionId, "admin", "agent", entity.UnionRoleAgent)
+	join(t, u, "p1", agentCode)
+	join(t, u, "p2", code)
+	return u, unionId
+}
+
+func TestUnionService_KickUnionMember(t *testing.T) {
+	u, unionId := newTestUnion(t)
+	tests := []struct {
+		name     string
+		operator string
+		member   string
+		code     int
+	}{
+		{"kick self", "agent", "agent", biz.RequestDataError.Code},
+		{"operator not in union", "other", "p1", biz.NotInUnion.Code},
+		{"member not in union", "agent", "other", biz.NotInUnion.Code},
+		{"player kick player", "p2", "p1", biz.PermissionNotEnough.Code},
+		{"agent kick admin", "agent", "admin", biz.PermissionNotEnough.Code},
+		{"admin kick owner", "admin", "owner", biz.PermissionNotEnough.Code},
+		{"agent kick other's member", "agent", "p2", biz.NotYourMember.Code},
+		{"agent kick own member", "agent", "p1", biz.OK},
+		{"admin kick any lower member", "admin", "p2", biz.OK},
+		{"already kicked", "owner", "p2", biz.NotInUnion.Code},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := u.KickUnionMember(context.Background(), &pb.KickUnionMemberParams{Uid: tt.operator, UnionId: unionId, MemberUid: tt.member})
+			if code := errCode(err); code != tt.code {
+				t.Fatalf("code = %d, want %d, err: %v", code, tt.code, err)
+			}
+		})
+	}
+}
+
+func TestUnionService_LeaveUnion(t *testing.T) {
+	ctx := context.Background()
+	u, unionId := newTestUnion(t)
+	_, err := u.LeaveUnion(ctx, &pb.LeaveUnionParams{Uid: "owner", UnionId: unionId})
+	if code := errCode(err); code != biz.PermissionNotEnough.Code {
+		t.Fatalf("owner leave code = %d, want %d", code, biz.PermissionNotEnough.Code)
+	}
+
+	// 代理退出后，直属成员转给代理的上级
+	if _, err = u.LeaveUnion(ctx, &pb.LeaveUnionParams{Uid: "agent", UnionId: unionId}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = u.members.Find(ctx, unionId, "agent"); err != repo.ErrNotFound {
+		t.Fatalf("find left member err = %v, want ErrNotFound", err)
+	}
+	p1, err := u.members.Find(ctx, unionId, "p1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p1.SuperiorUid != "admin" {
+		t.Fatalf("p1 superior = %s, want admin", p1.SuperiorUid)
+	}
+	_, err = u.LeaveUnion(ctx, &pb.LeaveUnionParams{Uid: "agent", UnionId: unionId})
+	if code := errCode(err); code != biz.NotInUnion.Code {
+		t.Fatalf("leave again code = %d, want %d", code, biz.NotInUnion.Code)
+	}
+}
+
+func TestUnionService_SetUnionMemberRole(t *testing.T) {
+	ctx := context.Background()
+	u, unionId := newTestUnion(t)
+	tests := []struct {
+		name     string
+		operator string
+		member   string
+		role     int
+		code     int
+	}{
+		{"set owner", "owner", "admin", entity.UnionRoleOwner, biz.RequestDataError.Code},
+		{"admin set admin", "admin", "p2", entity.UnionRoleAdmin, biz.PermissionNotEnough.Code},
+		{"agent set agent", "agent", "p1", entity.UnionRoleAgent, biz.PermissionNotEnough.Code},
+		{"admin demote owner", "admin", "owner", entity.UnionRolePlayer, biz.PermissionNotEnough.Code},
+		{"admin set agent", "admin", "p2", entity.UnionRoleAgent, biz.OK},
+		{"admin demote agent", "admin", "agent", entity.UnionRolePlayer, biz.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := u.SetUnionMemberRole(ctx, &pb.SetUnionMemberRoleParams{Uid: tt.operator, UnionId: unionId, MemberUid: tt.member, Role: int32(tt.role)})
+			if code := errCode(err); code != tt.code {
+				t.Fatalf("code = %d, want %d, err: %v", code, tt.code, err)
+			}
+		})
+	}
+
+	// 降为玩家后收回邀请码，直属成员转给其上级
+	agent, err := u.members.Find(ctx, unionId, "agent")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if agent.InviteCode != "" {
+		t.Fatalf("demoted invite code = %s, want empty", agent.InviteCode)
+	}
+	p1, err := u.members.Find(ctx, unionId, "p1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p1.SuperiorUid != "admin" {
+		t.Fatalf("p1 superior = %s, want admin", p1.SuperiorUid)
+	}
+}
+
+func TestUnionService_UnionMembers(t *testing.T) {
+	ctx := context.Background()
+	u, unionId := newTestUnion(t)
+	tests := []struct {
+		name  string
+		req   *pb.UnionMembersParams
+		code  int
+		total int64
+		uids  []string
+	}{
+		{"not in union", &pb.UnionMembersParams{Uid: "other", UnionId: unionId}, biz.NotInUnion.Code, 0, nil},
+		{"player", &pb.UnionMembersParams{Uid: "p1", UnionId: unionId}, biz.PermissionNotEnough.Code, 0, nil},
+		{"owner all", &pb.UnionMembersParams{Uid: "owner", UnionId: unionId}, biz.OK, 5, []string{"owner", "admin", "agent", "p1", "p2"}},
+		{"owner page", &pb.UnionMembersParams{Uid: "owner", UnionId: unionId, Offset: 1, Limit: 2}, biz.OK, 5, []string{"admin", "agent"}},
+		{"owner direct", &pb.UnionMembersParams{Uid: "owner", UnionId: unionId, OnlyDirect: true}, biz.OK, 2, []string{"admin", "p2"}},
+		{"agent only direct", &pb.UnionMembersParams{Uid: "agent", UnionId: unionId}, biz.OK, 1, []string{"p1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := u.UnionMembers(ctx, tt.req)
+			if code := errCode(err); code != tt.code {
+				t.Fatalf("code = %d, want %d, err: %v", code, tt.code, err)
+			}
+			if err != nil {
+				return
+			}
+			if res.Total != tt.total || len(res.List) != len(tt.uids) {
+				t.Fatalf("total = %d, list = %v, want %d %v", res.Total, res.List, tt.total, tt.uids)
+			}
+			for i, v := range res.List {
+				if v.Uid != tt.uids[i] {
+					t.Fatalf("list[%d] = %s, want %s", i, v.Uid, tt.uids[i])
+				}
+			}
+		})
+	}
+
+	res, err := u.MyUnions(ctx, &pb.MyUnionsParams{Uid: "agent"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.List) != 1 || res.List[0].UnionId != unionId || res.List[0].Role != entity.UnionRoleAgent || res.List[0].MemberCount != 5 {
+		t.Fatalf("my unions = %v", res.List)
+	}
+}
@@ -0,0 +1,384 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.25.3
+// source: hall.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	HallService_GameList_FullMethodName      = "/HallService/GameList"
+	HallService_Announcements_FullMethodName = "/HallService/Announcements"
+	HallService_CurrentRoom_FullMethodName   = "/HallService/CurrentRoom"
+	HallService_OnlineCount_FullMethodName   = "/HallService/OnlineCount"
+)
+
+// HallServiceClient is the client API for HallService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type HallServiceClient interface {
+	GameList(ctx context.Context, in *GameListParams, opts ...grpc.CallOption) (*GameListResponse, error)
+	Announcements(ctx context.Context, in *AnnouncementsParams, opts ...grpc.CallOption) (*AnnouncementsResponse, error)
+	CurrentRoom(ctx context.Context, in *CurrentRoomParams, opts ...grpc.CallOption) (*CurrentRoomResponse, error)
+	OnlineCount(ctx context.Context, in *OnlineCountParams, opts ...grpc.CallOption) (*OnlineCountResponse, error)
+}
+
+type hallServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewHallServiceClient(cc grpc.ClientConnInterface) HallServiceClient {
+	return &hallServiceClient{cc}
+}
+
+func (c *hallServiceClient) GameList(ctx context.Context, in *GameListParams, opts ...grpc.CallOption) (*GameListResponse, error) {
+	out := new(GameListResponse)
+	err := c.cc.Invoke(ctx, HallService_GameList_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hallServiceClient) Announcements(ctx context.Context, in *AnnouncementsParams, opts ...grpc.CallOption) (*AnnouncementsResponse, error) {
+	out := new(AnnouncementsResponse)
+	err := c.cc.Invoke(ctx, HallService_Announcements_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hallServiceClient) CurrentRoom(ctx context.Context, in *CurrentRoomParams, opts ...grpc.CallOption) (*CurrentRoomResponse, error) {
+	out := new(CurrentRoomResponse)
+	err := c.cc.Invoke(ctx, HallService_CurrentRoom_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hallServiceClient) OnlineCount(ctx context.Context, in *OnlineCountParams, opts ...grpc.CallOption) (*OnlineCountResponse, error) {
+	out := new(OnlineCountResponse)
+	err := c.cc.Invoke(ctx, HallService_OnlineCount_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// HallServiceServer is the server API for HallService service.
+// All implementations must embed UnimplementedHallServiceServer
+// for forward compatibility
+type HallServiceServer interface {
+	GameList(context.Context, *GameListParams) (*GameListResponse, error)
+	Announcements(context.Context, *AnnouncementsParams) (*AnnouncementsResponse, error)
+	CurrentRoom(context.Context, *CurrentRoomParams) (*CurrentRoomResponse, error)
+	OnlineCount(context.Context, *OnlineCountParams) (*OnlineCountResponse, error)
+	mustEmbedUnimplementedHallServiceServer()
+}
+
+// UnimplementedHallServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedHallServiceServer struct {
+}
+
+func (UnimplementedHallServiceServer) GameList(context.Context, *GameListParams) (*GameListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GameList not implemented")
+}
+func (UnimplementedHallServiceServer) Announcements(context.Context, *AnnouncementsParams) (*AnnouncementsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Announcements not implemented")
+}
+func (UnimplementedHallServiceServer) CurrentRoom(context.Context, *CurrentRoomParams) (*CurrentRoomResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CurrentRoom not implemented")
+}
+func (UnimplementedHallServiceServer) OnlineCount(context.Context, *OnlineCountParams) (*OnlineCountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OnlineCount not implemented")
+}
+func (UnimplementedHallServiceServer) mustEmbedUnimplementedHallServiceServer() {}
+
+// UnsafeHallServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to HallServiceServer will
+// result in compilation errors.
+type UnsafeHallServiceServer interface {
+	mustEmbedUnimplementedHallServiceServer()
+}
+
+func RegisterHallServiceServer(s grpc.ServiceRegistrar, srv HallServiceServer) {
+	s.RegisterService(&HallService_ServiceDesc, srv)
+}
+
+func _HallService_GameList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GameListParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HallServiceServer).GameList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HallService_GameList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HallServiceServer).GameList(ctx, req.(*GameListParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HallService_Announcements_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnnouncementsParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HallServiceServer).Announcements(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HallService_Announcements_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HallServiceServer).Announcements(ctx, req.(*AnnouncementsParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HallService_CurrentRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CurrentRoomParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HallServiceServer).CurrentRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HallService_CurrentRoom_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HallServiceServer).CurrentRoom(ctx, req.(*CurrentRoomParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HallService_OnlineCount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OnlineCountParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HallServiceServer).OnlineCount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HallService_OnlineCount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HallServiceServer).OnlineCount(ctx, req.(*OnlineCountParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// HallService_ServiceDesc is the grpc.ServiceDesc for HallService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var HallService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "HallService",
+	HandlerType: (*HallServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GameList",
+			Handler:    _HallService_GameList_Handler,
+		},
+		{
+			MethodName: "Announcements",
+			Handler:    _HallService_Announcements_Handler,
+		},
+		{
+			MethodName: "CurrentRoom",
+			Handler:    _HallService_CurrentRoom_Handler,
+		},
+		{
+			MethodName: "OnlineCount",
+			Handler:    _HallService_OnlineCount_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "hall.proto",
+}
+
+const (
+	HallAdminService_PublishAnnouncement_FullMethodName = "/HallAdminService/PublishAnnouncement"
+	HallAdminService_DeleteAnnouncement_FullMethodName  = "/HallAdminService/DeleteAnnouncement"
+	HallAdminService_RechargeUnionScore_FullMethodName  = "/HallAdminService/RechargeUnionScore"
+)
+
+// HallAdminServiceClient is the client API for HallAdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type HallAdminServiceClient interface {
+	PublishAnnouncement(ctx context.Context, in *PublishAnnouncementParams, opts ...grpc.CallOption) (*PublishAnnouncementResponse, error)
+	DeleteAnnouncement(ctx context.Context, in *DeleteAnnouncementParams, opts ...grpc.CallOption) (*DeleteAnnouncementResponse, error)
+	RechargeUnionScore(ctx context.Context, in *RechargeUnionScoreParams, opts ...grpc.CallOption) (*RechargeUnionScoreResponse, error)
+}
+
+type hallAdminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewHallAdminServiceClient(cc grpc.ClientConnInterface) HallAdminServiceClient {
+	return &hallAdminServiceClient{cc}
+}
+
+func (c *hallAdminServiceClient) PublishAnnouncement(ctx context.Context, in *PublishAnnouncementParams, opts ...grpc.CallOption) (*PublishAnnouncementResponse, error) {
+	out := new(PublishAnnouncementResponse)
+	err := c.cc.Invoke(ctx, HallAdminService_PublishAnnouncement_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hallAdminServiceClient) DeleteAnnouncement(ctx context.Context, in *DeleteAnnouncementParams, opts ...grpc.CallOption) (*DeleteAnnouncementResponse, error) {
+	out := new(DeleteAnnouncementResponse)
+	err := c.cc.Invoke(ctx, HallAdminService_DeleteAnnouncement_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hallAdminServiceClient) RechargeUnionScore(ctx context.Context, in *RechargeUnionScoreParams, opts ...grpc.CallOption) (*RechargeUnionScoreResponse, error) {
+	out := new(RechargeUnionScoreResponse)
+	err := c.cc.Invoke(ctx, HallAdminService_RechargeUnionScore_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// HallAdminServiceServer is the server API for HallAdminService service.
+// All implementations must embed UnimplementedHallAdminServiceServer
+// for forward compatibility
+type HallAdminServiceServer interface {
+	PublishAnnouncement(context.Context, *PublishAnnouncementParams) (*PublishAnnouncementResponse, error)
+	DeleteAnnouncement(context.Context, *DeleteAnnouncementParams) (*DeleteAnnouncementResponse, error)
+	RechargeUnionScore(context.Context, *RechargeUnionScoreParams) (*RechargeUnionScoreResponse, error)
+	mustEmbedUnimplementedHallAdminServiceServer()
+}
+
+// UnimplementedHallAdminServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedHallAdminServiceServer struct {
+}
+
+func (UnimplementedHallAdminServiceServer) PublishAnnouncement(context.Context, *PublishAnnouncementParams) (*PublishAnnouncementResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PublishAnnouncement not implemented")
+}
+func (UnimplementedHallAdminServiceServer) DeleteAnnouncement(context.Context, *DeleteAnnouncementParams) (*DeleteAnnouncementResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAnnouncement not implemented")
+}
+func (UnimplementedHallAdminServiceServer) RechargeUnionScore(context.Context, *RechargeUnionScoreParams) (*RechargeUnionScoreResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RechargeUnionScore not implemented")
+}
+func (UnimplementedHallAdminServiceServer) mustEmbedUnimplementedHallAdminServiceServer() {}
+
+// UnsafeHallAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to HallAdminServiceServer will
+// result in compilation errors.
+type UnsafeHallAdminServiceServer interface {
+	mustEmbedUnimplementedHallAdminServiceServer()
+}
+
+func RegisterHallAdminServiceServer(s grpc.ServiceRegistrar, srv HallAdminServiceServer) {
+	s.RegisterService(&HallAdminService_ServiceDesc, srv)
+}
+
+func _HallAdminService_PublishAnnouncement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishAnnouncementParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HallAdminServiceServer).PublishAnnouncement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HallAdminService_PublishAnnouncement_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HallAdminServiceServer).PublishAnnouncement(ctx, req.(*PublishAnnouncementParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HallAdminService_DeleteAnnouncement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAnnouncementParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HallAdminServiceServer).DeleteAnnouncement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HallAdminService_DeleteAnnouncement_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HallAdminServiceServer).DeleteAnnouncement(ctx, req.(*DeleteAnnouncementParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HallAdminService_RechargeUnionScore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RechargeUnionScoreParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HallAdminServiceServer).RechargeUnionScore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HallAdminService_RechargeUnionScore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HallAdminServiceServer).RechargeUnionScore(ctx, req.(*RechargeUnionScoreParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// HallAdminService_ServiceDesc is the grpc.ServiceDesc for HallAdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var HallAdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "HallAdminService",
+	HandlerType: (*HallAdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "PublishAnnouncement",
+			Handler:    _HallAdminService_PublishAnnouncement_Handler,
+		},
+		{
+			MethodName: "DeleteAnnouncement",
+			Handler:    _HallAdminService_DeleteAnnouncement_Handler,
+		},
+		{
+			MethodName: "RechargeUnionScore",
+			Handler:    _HallAdminService_RechargeUnionScore_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "hall.proto",
+}
@@ -0,0 +1,2423 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.32.0
+// 	protoc        v4.25.3
+// source: union.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// 联盟信息，role、inviteCode、score为当前用户在联盟中的角色、邀请码和积分
+type Union struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UnionId           int64  `protobuf:"varint,1,opt,name=unionId,proto3" json:"unionId,omitempty"`
+	Name              string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	OwnerUid          string `protobuf:"bytes,3,opt,name=ownerUid,proto3" json:"ownerUid,omitempty"`
+	Role              int32  `protobuf:"varint,4,opt,name=role,proto3" json:"role,omitempty"`
+	InviteCode        string `protobuf:"bytes,5,opt,name=inviteCode,proto3" json:"inviteCode,omitempty"`
+	MemberCount       int64  `protobuf:"varint,6,opt,name=memberCount,proto3" json:"memberCount,omitempty"`
+	CreateTime        int64  `protobuf:"varint,7,opt,name=createTime,proto3" json:"createTime,omitempty"`
+	ForbidGiveScore   bool   `protobuf:"varint,8,opt,name=forbidGiveScore,proto3" json:"forbidGiveScore,omitempty"`
+	ForbidInviteScore bool   `protobuf:"varint,9,opt,name=forbidInviteScore,proto3" json:"forbidInviteScore,omitempty"`
+	Score             int64  `protobuf:"varint,10,opt,name=score,proto3" json:"score,omitempty"`
+}
+
+func (x *Union) Reset() {
+	*x = Union{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_union_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Union) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Union) ProtoMessage() {}
+
+func (x *Union) ProtoReflect() protoreflect.Message {
+	mi := &file_union_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Union.ProtoReflect.Descriptor instead.
+func (*Union) Descriptor() ([]byte, []int) {
+	return file_union_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Union) GetUnionId() int64 {
+	if x != nil {
+		return x.UnionId
+	}
+	return 0
+}
+
+func (x *Union) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Union) GetOwnerUid() string {
+	if x != nil {
+		return x.OwnerUid
+	}
+	return ""
+}
+
+func (x *Union) GetRole() int32 {
+	if x != nil {
+		return x.Role
+	}
+	return 0
+}
+
+func (x *Union) GetInviteCode() string {
+	if x != nil {
+		return x.InviteCode
+	}
+	return ""
+}
+
+func (x *Union) GetMemberCount() int64 {
+	if x != nil {
+		return x.MemberCount
+	}
+	return 0
+}
+
+func (x *Union) GetCreateTime() int64 {
+	if x != nil {
+		return x.CreateTime
+	}
+	return 0
+}
+
+func (x *Union) GetForbidGiveScore() bool {
+	if x != nil {
+		return x.ForbidGiveScore
+	}
+	return false
+}
+
+func (x *Union) GetForbidInviteScore() bool {
+	if x != nil {
+		return x.ForbidInviteScore
+	}
+	return false
+}
+
+func (x *Union) GetScore() int64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type CreateUnionParams struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uid  string `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *CreateUnionParams) Reset() {
+	*x = CreateUnionParams{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_union_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateUnionParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateUnionParams) ProtoMessage() {}
+
+func (x *CreateUnionParams) ProtoReflect() protoreflect.Message {
+	mi := &file_union_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateUnionParams.ProtoReflect.Descriptor instead.
+func (*CreateUnionParams) Descriptor() ([]byte, []int) {
+	return file_union_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateUnionParams) GetUid() string {
+	if x != nil {
+		return x.Uid
+	}
+	return ""
+}
+
+func (x *CreateUnionParams) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type CreateUnionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Union *Union `protobuf:"bytes,1,opt,name=union,proto3" json:"union,omitempty"`
+}
+
+func (x *CreateUnionResponse) Reset() {
+	*x = CreateUnionResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_union_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateUnionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateUnionResponse) ProtoMessage() {}
+
+func (x *CreateUnionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_union_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateUnionResponse.ProtoReflect.Descriptor instead.
+func (*CreateUnionResponse) Descriptor() ([]byte, []int) {
+	return file_union_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateUnionResponse) GetUnion() *Union {
+	if x != nil {
+		return x.Union
+	}
+	return nil
+}
+
+type JoinUnionParams struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uid        string `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	InviteCode string `protobuf:"bytes,2,opt,name=inviteCode,proto3" json:"inviteCode,omitempty"`
+}
+
+func (x *JoinUnionParams) Reset() {
+	*x = JoinUnionParams{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_union_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JoinUnionParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinUnionParams) ProtoMessage() {}
+
+func (x *JoinUnionParams) ProtoReflect() protoreflect.Message {
+	mi := &file_union_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinUnionParams.ProtoReflect.Descriptor instead.
+func (*JoinUnionParams) Descriptor() ([]byte, []int) {
+	return file_union_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *JoinUnionParams) GetUid() string {
+	if x != nil {
+		return x.Uid
+	}
+	return ""
+}
+
+func (x *JoinUnionParams) GetInviteCode() string {
+	if x != nil {
+		return x.InviteCode
+	}
+	return ""
+}
+
+type JoinUnionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Union *Union `protobuf:"bytes,1,opt,name=union,proto3" json:"union,omitempty"`
+}
+
+func (x *JoinUnionResponse) Reset() {
+	*x = JoinUnionResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_union_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JoinUnionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinUnionResponse) ProtoMessage() {}
+
+func (x *JoinUnionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_union_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinUnionResponse.ProtoReflect.Descriptor instead.
+func (*JoinUnionResponse) Descriptor() ([]byte, []int) {
+	return file_union_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *JoinUnionResponse) GetUnion() *Union {
+	if x != nil {
+		return x.Union
+	}
+	return nil
+}
+
+type LeaveUnionParams struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uid     string `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	UnionId int64  `protobuf:"varint,2,opt,name=unionId,proto3" json:"unionId,omitempty"`
+}
+
+func (x *LeaveUnionParams) Reset() {
+	*x = LeaveUnionParams{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_union_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LeaveUnionParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaveUnionParams) ProtoMessage() {}
+
+func (x *LeaveUnionParams) ProtoReflect() protoreflect.Message {
+	mi := &file_union_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaveUnionParams.ProtoReflect.Descriptor instead.
+func (*LeaveUnionParams) Descriptor() ([]byte, []int) {
+	return file_union_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *LeaveUnionParams) GetUid() string {
+	if x != nil {
+		return x.Uid
+	}
+	return ""
+}
+
+func (x *LeaveUnionParams) GetUnionId() int64 {
+	if x != nil {
+		return x.UnionId
+	}
+	return 0
+}
+
+type LeaveUnionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *LeaveUnionResponse) Reset() {
+	*x = LeaveUnionResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_union_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LeaveUnionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaveUnionResponse) ProtoMessage() {}
+
+func (x *LeaveUnionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_union_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaveUnionResponse.ProtoReflect.Descriptor instead.
+func (*LeaveUnionResponse) Descriptor() ([]byte, []int) {
+	return file_union_proto_rawDescGZIP(), []int{6}
+}
+
+type KickUnionMemberParams struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uid       string `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	UnionId   int64  `protobuf:"varint,2,opt,name=unionId,proto3" json:"unionId,omitempty"`
+	MemberUid string `protobuf:"bytes,3,opt,name=memberUid,proto3" json:"memberUid,omitempty"`
+}
+
+func (x *KickUnionMemberParams) Reset() {
+	*x = KickUnionMemberParams{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_union_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KickUnionMemberParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KickUnionMemberParams) ProtoMessage() {}
+
+func (x *KickUnionMemberParams) ProtoReflect() protoreflect.Message {
+	mi := &file_union_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KickUnionMemberParams.ProtoReflect.Descriptor instead.
+func (*KickUnionMemberParams) Descriptor() ([]byte, []int) {
+	return file_union_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *KickUnionMemberParams) GetUid() string {
+	if x != nil {
+		return x.Uid
+	}
+	return ""
+}
+
+func (x *KickUnionMemberParams) GetUnionId() int64 {
+	if x != nil {
+		return x.UnionId
+	}
+	return 0
+}
+
+func (x *KickUnionMemberParams) GetMemberUid() string {
+	if x != nil {
+		return x.MemberUid
+	}
+	return ""
+}
+
+type KickUnionMemberResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *KickUnionMemberResponse) Reset() {
+	*x = KickUnionMemberResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_union_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KickUnionMemberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KickUnionMemberResponse) ProtoMessage() {}
+
+func (x *KickUnionMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_union_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KickUnionMemberResponse.ProtoReflect.Descriptor instead.
+func (*KickUnionMemberResponse) Descriptor() ([]byte, []int) {
+	return file_union_proto_rawDescGZIP(), []int{8}
+}
+
+// role 2管理员 3代理 4玩家
+type SetUnionMemberRoleParams struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uid       string `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	UnionId   int64  `protobuf:"varint,2,opt,name=unionId,proto3" json:"unionId,omitempty"`
+	MemberUid string `protobuf:"bytes,3,opt,name=memberUid,proto3" json:"memberUid,omitempty"`
+	Role      int32  `protobuf:"varint,4,opt,name=role,proto3" json:"role,omitempty"`
+}
+
+func (x *SetUnionMemberRoleParams) Reset() {
+	*x = SetUnionMemberRoleParams{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_union_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetUnionMemberRoleParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetUnionMemberRoleParams) ProtoMessage() {}
+
+func (x *SetUnionMemberRoleParams) ProtoReflect() protoreflect.Message {
+	mi := &file_union_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetUnionMemberRoleParams.ProtoReflect.Descriptor instead.
+func (*SetUnionMemberRoleParams) Descriptor() ([]byte, []int) {
+	return file_union_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SetUnionMemberRoleParams) GetUid() string {
+	if x != nil {
+		return x.Uid
+	}
+	return ""
+}
+
+func (x *SetUnionMemberRoleParams) GetUnionId() int64 {
+	if x != nil {
+		return x.UnionId
+	}
+	return 0
+}
+
+func (x *SetUnionMemberRoleParams) GetMemberUid() string {
+	if x != nil {
+		return x.MemberUid
+	}
+	return ""
+}
+
+func (x *SetUnionMemberRoleParams) GetRole() int32 {
+	if x != nil {
+		return x.Role
+	}
+	return 0
+}
+
+type SetUnionMemberRoleResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SetUnionMemberRoleResponse) Reset() {
+	*x = SetUnionMemberRoleResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_union_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetUnionMemberRoleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetUnionMemberRoleResponse) ProtoMessage() {}
+
+func (x *SetUnionMemberRoleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_union_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetUnionMemberRoleResponse.ProtoReflect.Descriptor instead.
+func (*SetUnionMemberRoleResponse) Descriptor() ([]byte, []int) {
+	return file_union_proto_rawDescGZIP(), []int{10}
+}
+
+type UnionMember struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uid         string `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Nickname    string `protobuf:"bytes,2,opt,name=nickname,proto3" json:"nickname,omitempty"`
+	Avatar      string `protobuf:"bytes,3,opt,name=avatar,proto3" json:"avatar,omitempty"`
+	Role        int32  `protobuf:"varint,4,opt,name=role,proto3" json:"role,omitempty"`
+	SuperiorUid string `protobuf:"bytes,5,opt,name=superiorUid,proto3" json:"superiorUid,omitempty"`
+	JoinTime    int64  `protobuf:"varint,6,opt,name=joinTime,proto3" json:"joinTime,omitempty"`
+	Score       int64  `protobuf:"varint,7,opt,name=score,proto3" json:"score,omitempty"`
+}
+
+func (x *UnionMember) Reset() {
+	*x = UnionMember{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_union_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UnionMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnionMember) ProtoMessage() {}
+
+func (x *UnionMember) ProtoReflect() protoreflect.Message {
+	mi := &file_union_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnionMember.ProtoReflect.Descriptor instead.
+func (*UnionMember) Descriptor() ([]byte, []int) {
+	return file_union_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *UnionMember) GetUid() string {
+	if x != nil {
+		return x.Uid
+	}
+	return ""
+}
+
+func (x *UnionMember) GetNickname() string {
+	if x != nil {
+		return x.Nickname
+	}
+	return ""
+}
+
+func (x *UnionMember) GetAvatar() string {
+	if x != nil {
+		return x.Avatar
+	}
+	return ""
+}
+
+func (x *UnionMember) GetRole() int32 {
+	if x != nil {
+		return x.Role
+	}
+	return 0
+}
+
+func (x *UnionMember) GetSuperiorUid() string {
+	if x != nil {
+		return x.SuperiorUid
+	}
+	return ""
+}
+
+func (x *UnionMember) GetJoinTime() int64 {
+	if x != nil {
+		return x.JoinTime
+	}
+	return 0
+}
+
+func (x *UnionMember) GetScore() int64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+// onlyDirect只查询直属成员，代理只能查询直属成员
+type UnionMembersParams struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uid        string `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	UnionId    int64  `protobuf:"varint,2,opt,name=unionId,proto3" json:"unionId,omitempty"`
+	OnlyDirect bool   `protobuf:"varint,3,opt,name=onlyDirect,proto3" json:"onlyDirect,omitempty"`
+	Offset     int64  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	Limit      int64  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *UnionMembersParams) Reset() {
+	*x = UnionMembersParams{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_union_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UnionMembersParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnionMembersParams) ProtoMessage() {}
+
+func (x *UnionMembersParams) ProtoReflect() protoreflect.Message {
+	mi := &file_union_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnionMembersParams.ProtoReflect.Descriptor instead.
+func (*UnionMembersParams) Descriptor() ([]byte, []int) {
+	return file_union_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *UnionMembersParams) GetUid() string {
+	if x != nil {
+		return x.Uid
+	}
+	return ""
+}
+
+func (x *UnionMembersParams) GetUnionId() int64 {
+	if x != nil {
+		return x.UnionId
+	}
+	return 0
+}
+
+func (x *UnionMembersParams) GetOnlyDirect() bool {
+	if x != nil {
+		return x.OnlyDirect
+	}
+	return false
+}
+
+func (x *UnionMembersParams) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *UnionMembersParams) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type UnionMembersResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Total int64          `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	List  []*UnionMember `protobuf:"bytes,2,rep,name=list,proto3" json:"list,omitempty"`
+}
+
+func (x *UnionMembersResponse) Reset() {
+	*x = UnionMembersResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_union_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UnionMembersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnionMembersResponse) ProtoMessage() {}
+
+func (x *UnionMembersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_union_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnionMembersResponse.ProtoReflect.Descriptor instead.
+func (*UnionMembersResponse) Descriptor() ([]byte, []int) {
+	return file_union_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *UnionMembersResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *UnionMembersResponse) GetList() []*UnionMember {
+	if x != nil {
+		return x.List
+	}
+	return nil
+}
+
+type MyUnionsParams struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uid string `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
+}
+
+func (x *MyUnionsParams) Reset() {
+	*x = MyUnionsParams{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_union_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MyUnionsParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MyUnionsParams) ProtoMessage() {}
+
+func (x *MyUnionsParams) ProtoReflect() protoreflect.Message {
+	mi := &file_union_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MyUnionsParams.ProtoReflect.Descriptor instead.
+func (*MyUnionsParams) Descriptor() ([]byte, []int) {
+	return file_union_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *MyUnionsParams) GetUid() string {
+	if x != nil {
+		return x.Uid
+	}
+	return ""
+}
+
+type MyUnionsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	List []*Union `protobuf:"bytes,1,rep,name=list,proto3" json:"list,omitempty"`
+}
+
+func (x *MyUnionsResponse) Reset() {
+	*x = MyUnionsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_union_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MyUnionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MyUnionsResponse) ProtoMessage() {}
+
+func (x *MyUnionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_union_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MyUnionsResponse.ProtoReflect.Descriptor instead.
+func (*MyUnionsResponse) Descriptor() ([]byte, []int) {
+	return file_union_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *MyUnionsResponse) GetList() []*Union {
+	if x != nil {
+		return x.List
+	}
+	return nil
+}
+
+// 盟主、管理员修改联盟开关，不传的开关不修改
+type SetUnionSwitchParams struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uid               string `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	UnionId           int64  `protobuf:"varint,2,opt,name=unionId,proto3" json:"unionId,omitempty"`
+	ForbidGiveScore   *bool  `protobuf:"varint,3,opt,name=forbidGiveScore,proto3,oneof" json:"forbidGiveScore,omitempty"`
+	ForbidInviteScore *bool  `protobuf:"varint,4,opt,name=forbidInviteScore,proto3,oneof" json:"forbidInviteScore,omitempty"`
+}
+
+func (x *SetUnionSwitchParams) Reset() {
+	*x = SetUnionSwitchParams{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_union_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetUnionSwitchParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetUnionSwitchParams) ProtoMessage() {}
+
+func (x *SetUnionSwitchParams) ProtoReflect() protoreflect.Message {
+	mi := &file_union_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetUnionSwitchParams.ProtoReflect.Descriptor instead.
+func (*SetUnionSwitchParams) Descriptor() ([]byte, []int) {
+	return file_union_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SetUnionSwitchParams) GetUid() string {
+	if x != nil {
+		return x.Uid
+	}
+	return ""
+}
+
+func (x *SetUnionSwitchParams) GetUnionId() int64 {
+	if x != nil {
+		return x.UnionId
+	}
+	return 0
+}
+
+func (x *SetUnionSwitchParams) GetForbidGiveScore() bool {
+	if x != nil && x.ForbidGiveScore != nil {
+		return *x.ForbidGiveScore
+	}
+	return false
+}
+
+func (x *SetUnionSwitchParams) GetForbidInviteScore() bool {
+	if x != nil && x.ForbidInviteScore != nil {
+		return *x.ForbidInviteScore
+	}
+	return false
+}
+
+type SetUnionSwitchResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SetUnionSwitchResponse) Reset() {
+	*x = SetUnionSwitchResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_union_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetUnionSwitchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetUnionSwitchResponse) ProtoMessage() {}
+
+func (x *SetUnionSwitchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_union_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetUnionSwitchResponse.ProtoReflect.Descriptor instead.
+func (*SetUnionSwitchResponse) Descriptor() ([]byte, []int) {
+	return file_union_proto_rawDescGZIP(), []int{17}
+}
+
+// 上级赠送积分给直属成员
+type GiveUnionScoreParams struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uid       string `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	UnionId   int64  `protobuf:"varint,2,opt,name=unionId,proto3" json:"unionId,omitempty"`
+	MemberUid string `protobuf:"bytes,3,opt,name=memberUid,proto3" json:"memberUid,omitempty"`
+	Amount    int64  `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`
+}
+
+func (x *GiveUnionScoreParams) Reset() {
+	*x = GiveUnionScoreParams{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_union_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GiveUnionScoreParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GiveUnionScoreParams) ProtoMessage() {}
+
+func (x *GiveUnionScoreParams) ProtoReflect() protoreflect.Message {
+	mi := &file_union_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GiveUnionScoreParams.ProtoReflect.Descriptor instead.
+func (*GiveUnionScoreParams) Descriptor() ([]byte, []int) {
+	return file_union_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GiveUnionScoreParams) GetUid() string {
+	if x != nil {
+		return x.Uid
+	}
+	return ""
+}
+
+func (x *GiveUnionScoreParams) GetUnionId() int64 {
+	if x != nil {
+		return x.UnionId
+	}
+	return 0
+}
+
+func (x *GiveUnionScoreParams) GetMemberUid() string {
+	if x != nil {
+		return x.MemberUid
+	}
+	return ""
+}
+
+func (x *GiveUnionScoreParams) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+// score为操作人变动后的积分，memberScore为成员变动后的积分
+type GiveUnionScoreResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Score       int64 `protobuf:"varint,1,opt,name=score,proto3" json:"score,omitempty"`
+	MemberScore int64 `protobuf:"varint,2,opt,name=memberScore,proto3" json:"memberScore,omitempty"`
+}
+
+func (x *GiveUnionScoreResponse) Reset() {
+	*x = GiveUnionScoreResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_union_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GiveUnionScoreResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GiveUnionScoreResponse) ProtoMessage() {}
+
+func (x *GiveUnionScoreResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_union_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GiveUnionScoreResponse.ProtoReflect.Descriptor instead.
+func (*GiveUnionScoreResponse) Descriptor() ([]byte, []int) {
+	return file_union_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GiveUnionScoreResponse) GetScore() int64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *GiveUnionScoreResponse) GetMemberScore() int64 {
+	if x != nil {
+		return x.MemberScore
+	}
+	return 0
+}
+
+// 上级收回直属成员的积分
+type TakeUnionScoreParams struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uid       string `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	UnionId   int64  `protobuf:"varint,2,opt,name=unionId,proto3" json:"unionId,omitempty"`
+	MemberUid string `protobuf:"bytes,3,opt,name=memberUid,proto3" json:"memberUid,omitempty"`
+	Amount    int64  `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`
+}
+
+func (x *TakeUnionScoreParams) Reset() {
+	*x = TakeUnionScoreParams{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_union_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TakeUnionScoreParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TakeUnionScoreParams) ProtoMessage() {}
+
+func (x *TakeUnionScoreParams) ProtoReflect() protoreflect.Message {
+	mi := &file_union_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TakeUnionScoreParams.ProtoReflect.Descriptor instead.
+func (*TakeUnionScoreParams) Descriptor() ([]byte, []int) {
+	return file_union_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *TakeUnionScoreParams) GetUid() string {
+	if x != nil {
+		return x.Uid
+	}
+	return ""
+}
+
+func (x *TakeUnionScoreParams) GetUnionId() int64 {
+	if x != nil {
+		return x.UnionId
+	}
+	return 0
+}
+
+func (x *TakeUnionScoreParams) GetMemberUid() string {
+	if x != nil {
+		return x.MemberUid
+	}
+	return ""
+}
+
+func (x *TakeUnionScoreParams) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+type TakeUnionScoreResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Score       int64 `protobuf:"varint,1,opt,name=score,proto3" json:"score,omitempty"`
+	MemberScore int64 `protobuf:"varint,2,opt,name=memberScore,proto3" json:"memberScore,omitempty"`
+}
+
+func (x *TakeUnionScoreResponse) Reset() {
+	*x = TakeUnionScoreResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_union_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TakeUnionScoreResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TakeUnionScoreResponse) ProtoMessage() {}
+
+func (x *TakeUnionScoreResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_union_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TakeUnionScoreResponse.ProtoReflect.Descriptor instead.
+func (*TakeUnionScoreResponse) Descriptor() ([]byte, []int) {
+	return file_union_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *TakeUnionScoreResponse) GetScore() int64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *TakeUnionScoreResponse) GetMemberScore() int64 {
+	if x != nil {
+		return x.MemberScore
+	}
+	return 0
+}
+
+type UnionScoreRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id         string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type       int32  `protobuf:"varint,2,opt,name=type,proto3" json:"type,omitempty"`
+	Amount     int64  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Balance    int64  `protobuf:"varint,4,opt,name=balance,proto3" json:"balance,omitempty"`
+	RelatedUid string `protobuf:"bytes,5,opt,name=relatedUid,proto3" json:"relatedUid,omitempty"`
+	Remark     string `protobuf:"bytes,6,opt,name=remark,proto3" json:"remark,omitempty"`
+	CreateTime int64  `protobuf:"varint,7,opt,name=createTime,proto3" json:"createTime,omitempty"`
+}
+
+func (x *UnionScoreRecord) Reset() {
+	*x = UnionScoreRecord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_union_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UnionScoreRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnionScoreRecord) ProtoMessage() {}
+
+func (x *UnionScoreRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_union_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnionScoreRecord.ProtoReflect.Descriptor instead.
+func (*UnionScoreRecord) Descriptor() ([]byte, []int) {
+	return file_union_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *UnionScoreRecord) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UnionScoreRecord) GetType() int32 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+func (x *UnionScoreRecord) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *UnionScoreRecord) GetBalance() int64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+func (x *UnionScoreRecord) GetRelatedUid() string {
+	if x != nil {
+		return x.RelatedUid
+	}
+	return ""
+}
+
+func (x *UnionScoreRecord) GetRemark() string {
+	if x != nil {
+		return x.Remark
+	}
+	return ""
+}
+
+func (x *UnionScoreRecord) GetCreateTime() int64 {
+	if x != nil {
+		return x.CreateTime
+	}
+	return 0
+}
+
+// memberUid为空时查询自己的积分流水
+type UnionScoreRecordsParams struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uid       string `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	UnionId   int64  `protobuf:"varint,2,opt,name=unionId,proto3" json:"unionId,omitempty"`
+	MemberUid string `protobuf:"bytes,3,opt,name=memberUid,proto3" json:"memberUid,omitempty"`
+	Offset    int64  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	Limit     int64  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *UnionScoreRecordsParams) Reset() {
+	*x = UnionScoreRecordsParams{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_union_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UnionScoreRecordsParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnionScoreRecordsParams) ProtoMessage() {}
+
+func (x *UnionScoreRecordsParams) ProtoReflect() protoreflect.Message {
+	mi := &file_union_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnionScoreRecordsParams.ProtoReflect.Descriptor instead.
+func (*UnionScoreRecordsParams) Descriptor() ([]byte, []int) {
+	return file_union_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *UnionScoreRecordsParams) GetUid() string {
+	if x != nil {
+		return x.Uid
+	}
+	return ""
+}
+
+func (x *UnionScoreRecordsParams) GetUnionId() int64 {
+	if x != nil {
+		return x.UnionId
+	}
+	return 0
+}
+
+func (x *UnionScoreRecordsParams) GetMemberUid() string {
+	if x != nil {
+		return x.MemberUid
+	}
+	return ""
+}
+
+func (x *UnionScoreRecordsParams) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *UnionScoreRecordsParams) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type UnionScoreRecordsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	List []*UnionScoreRecord `protobuf:"bytes,1,rep,name=list,proto3" json:"list,omitempty"`
+}
+
+func (x *UnionScoreRecordsResponse) Reset() {
+	*x = UnionScoreRecordsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_union_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UnionScoreRecordsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnionScoreRecordsResponse) ProtoMessage() {}
+
+func (x *UnionScoreRecordsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_union_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnionScoreRecordsResponse.ProtoReflect.Descriptor instead.
+func (*UnionScoreRecordsResponse) Descriptor() ([]byte, []int) {
+	return file_union_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *UnionScoreRecordsResponse) GetList() []*UnionScoreRecord {
+	if x != nil {
+		return x.List
+	}
+	return nil
+}
+
+type UnionScoreDaily struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Day     string `protobuf:"bytes,1,opt,name=day,proto3" json:"day,omitempty"`
+	Income  int64  `protobuf:"varint,2,opt,name=income,proto3" json:"income,omitempty"`
+	Expense int64  `protobuf:"varint,3,opt,name=expense,proto3" json:"expense,omitempty"`
+	Count   int64  `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *UnionScoreDaily) Reset() {
+	*x = UnionScoreDaily{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_union_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UnionScoreDaily) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnionScoreDaily) ProtoMessage() {}
+
+func (x *UnionScoreDaily) ProtoReflect() protoreflect.Message {
+	mi := &file_union_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnionScoreDaily.ProtoReflect.Descriptor instead.
+func (*UnionScoreDaily) Descriptor() ([]byte, []int) {
+	return file_union_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *UnionScoreDaily) GetDay() string {
+	if x != nil {
+		return x.Day
+	}
+	return ""
+}
+
+func (x *UnionScoreDaily) GetIncome() int64 {
+	if x != nil {
+		return x.Income
+	}
+	return 0
+}
+
+func (x *UnionScoreDaily) GetExpense() int64 {
+	if x != nil {
+		return x.Expense
+	}
+	return 0
+}
+
+func (x *UnionScoreDaily) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// 最近days天（包括今天）每天的积分汇总，没有流水的日期不返回
+type UnionScoreDailyParams struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uid       string `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	UnionId   int64  `protobuf:"varint,2,opt,name=unionId,proto3" json:"unionId,omitempty"`
+	MemberUid string `protobuf:"bytes,3,opt,name=memberUid,proto3" json:"memberUid,omitempty"`
+	Days      int32  `protobuf:"varint,4,opt,name=days,proto3" json:"days,omitempty"`
+}
+
+func (x *UnionScoreDailyParams) Reset() {
+	*x = UnionScoreDailyParams{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_union_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UnionScoreDailyParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnionScoreDailyParams) ProtoMessage() {}
+
+func (x *UnionScoreDailyParams) ProtoReflect() protoreflect.Message {
+	mi := &file_union_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnionScoreDailyParams.ProtoReflect.Descriptor instead.
+func (*UnionScoreDailyParams) Descriptor() ([]byte, []int) {
+	return file_union_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *UnionScoreDailyParams) GetUid() string {
+	if x != nil {
+		return x.Uid
+	}
+	return ""
+}
+
+func (x *UnionScoreDailyParams) GetUnionId() int64 {
+	if x != nil {
+		return x.UnionId
+	}
+	return 0
+}
+
+func (x *UnionScoreDailyParams) GetMemberUid() string {
+	if x != nil {
+		return x.MemberUid
+	}
+	return ""
+}
+
+func (x *UnionScoreDailyParams) GetDays() int32 {
+	if x != nil {
+		return x.Days
+	}
+	return 0
+}
+
+type UnionScoreDailyResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	List []*UnionScoreDaily `protobuf:"bytes,1,rep,name=list,proto3" json:"list,omitempty"`
+}
+
+func (x *UnionScoreDailyResponse) Reset() {
+	*x = UnionScoreDailyResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_union_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UnionScoreDailyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnionScoreDailyResponse) ProtoMessage() {}
+
+func (x *UnionScoreDailyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_union_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnionScoreDailyResponse.ProtoReflect.Descriptor instead.
+func (*UnionScoreDailyResponse) Descriptor() ([]byte, []int) {
+	return file_union_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *UnionScoreDailyResponse) GetList() []*UnionScoreDaily {
+	if x != nil {
+		return x.List
+	}
+	return nil
+}
+
+var File_union_proto protoreflect.FileDescriptor
+
+var file_union_proto_rawDesc = []byte{
+	0x0a, 0x0b, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xb5, 0x02,
+	0x0a, 0x05, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x75, 0x6e, 0x69, 0x6f, 0x6e,
+	0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x49,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x55, 0x69,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x55, 0x69,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x04, 0x72, 0x6f, 0x6c, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x69, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x43,
+	0x6f, 0x64, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x69, 0x6e, 0x76, 0x69, 0x74,
+	0x65, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6d, 0x65, 0x6d, 0x62,
+	0x65, 0x72, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x28, 0x0a, 0x0f, 0x66, 0x6f, 0x72, 0x62, 0x69,
+	0x64, 0x47, 0x69, 0x76, 0x65, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0f, 0x66, 0x6f, 0x72, 0x62, 0x69, 0x64, 0x47, 0x69, 0x76, 0x65, 0x53, 0x63, 0x6f, 0x72,
+	0x65, 0x12, 0x2c, 0x0a, 0x11, 0x66, 0x6f, 0x72, 0x62, 0x69, 0x64, 0x49, 0x6e, 0x76, 0x69, 0x74,
+	0x65, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x66, 0x6f,
+	0x72, 0x62, 0x69, 0x64, 0x49, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05,
+	0x73, 0x63, 0x6f, 0x72, 0x65, 0x22, 0x39, 0x0a, 0x11, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x55,
+	0x6e, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x22, 0x33, 0x0a, 0x13, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x05, 0x75, 0x6e, 0x69, 0x6f, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x06, 0x2e, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x52, 0x05,
+	0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x22, 0x43, 0x0a, 0x0f, 0x4a, 0x6f, 0x69, 0x6e, 0x55, 0x6e, 0x69,
+	0x6f, 0x6e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x69, 0x6e,
+	0x76, 0x69, 0x74, 0x65, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x69, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x43, 0x6f, 0x64, 0x65, 0x22, 0x31, 0x0a, 0x11, 0x4a, 0x6f,
+	0x69, 0x6e, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x1c, 0x0a, 0x05, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x06,
+	0x2e, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x52, 0x05, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x22, 0x3e, 0x0a,
+	0x10, 0x4c, 0x65, 0x61, 0x76, 0x65, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x72, 0x61, 0x6d,
+	0x73, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x75, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0x14, 0x0a,
+	0x12, 0x4c, 0x65, 0x61, 0x76, 0x65, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x61, 0x0a, 0x15, 0x4b, 0x69, 0x63, 0x6b, 0x55, 0x6e, 0x69, 0x6f, 0x6e,
+	0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x10, 0x0a, 0x03,
+	0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x18,
+	0x0a, 0x07, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x07, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x6d, 0x65, 0x6d, 0x62,
+	0x65, 0x72, 0x55, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6d, 0x65, 0x6d,
+	0x62, 0x65, 0x72, 0x55, 0x69, 0x64, 0x22, 0x19, 0x0a, 0x17, 0x4b, 0x69, 0x63, 0x6b, 0x55, 0x6e,
+	0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x78, 0x0a, 0x18, 0x53, 0x65, 0x74, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x6d,
+	0x62, 0x65, 0x72, 0x52, 0x6f, 0x6c, 0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x10, 0x0a,
+	0x03, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12,
+	0x18, 0x0a, 0x07, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x07, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x6d, 0x65, 0x6d,
+	0x62, 0x65, 0x72, 0x55, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6d, 0x65,
+	0x6d, 0x62, 0x65, 0x72, 0x55, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x22, 0x1c, 0x0a, 0x1a, 0x53,
+	0x65, 0x74, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x6f, 0x6c,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xbb, 0x01, 0x0a, 0x0b, 0x55, 0x6e,
+	0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x6e,
+	0x69, 0x63, 0x6b, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6e,
+	0x69, 0x63, 0x6b, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x76, 0x61, 0x74, 0x61,
+	0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x76, 0x61, 0x74, 0x61, 0x72, 0x12,
+	0x12, 0x0a, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x72,
+	0x6f, 0x6c, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x75, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x72, 0x55,
+	0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x75, 0x70, 0x65, 0x72, 0x69,
+	0x6f, 0x72, 0x55, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x6a, 0x6f, 0x69, 0x6e, 0x54, 0x69, 0x6d,
+	0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6a, 0x6f, 0x69, 0x6e, 0x54, 0x69, 0x6d,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x22, 0x8e, 0x01, 0x0a, 0x12, 0x55, 0x6e, 0x69, 0x6f,
+	0x6e, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x10,
+	0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x69, 0x64,
+	0x12, 0x18, 0x0a, 0x07, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x07, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x6f, 0x6e,
+	0x6c, 0x79, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a,
+	0x6f, 0x6e, 0x6c, 0x79, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66,
+	0x66, 0x73, 0x65, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73,
+	0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x4e, 0x0a, 0x14, 0x55, 0x6e, 0x69, 0x6f,
+	0x6e, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x20, 0x0a, 0x04, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x6d, 0x62,
+	0x65, 0x72, 0x52, 0x04, 0x6c, 0x69, 0x73, 0x74, 0x22, 0x22, 0x0a, 0x0e, 0x4d, 0x79, 0x55, 0x6e,
+	0x69, 0x6f, 0x6e, 0x73, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x69, 0x64, 0x22, 0x2e, 0x0a, 0x10,
+	0x4d, 0x79, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x1a, 0x0a, 0x04, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x06,
+	0x2e, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x52, 0x04, 0x6c, 0x69, 0x73, 0x74, 0x22, 0xce, 0x01, 0x0a,
+	0x14, 0x53, 0x65, 0x74, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x53, 0x77, 0x69, 0x74, 0x63, 0x68, 0x50,
+	0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x75, 0x6e, 0x69, 0x6f, 0x6e,
+	0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x49,
+	0x64, 0x12, 0x2d, 0x0a, 0x0f, 0x66, 0x6f, 0x72, 0x62, 0x69, 0x64, 0x47, 0x69, 0x76, 0x65, 0x53,
+	0x63, 0x6f, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x0f, 0x66, 0x6f,
+	0x72, 0x62, 0x69, 0x64, 0x47, 0x69, 0x76, 0x65, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x88, 0x01, 0x01,
+	0x12, 0x31, 0x0a, 0x11, 0x66, 0x6f, 0x72, 0x62, 0x69, 0x64, 0x49, 0x6e, 0x76, 0x69, 0x74, 0x65,
+	0x53, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x48, 0x01, 0x52, 0x11, 0x66,
+	0x6f, 0x72, 0x62, 0x69, 0x64, 0x49, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x53, 0x63, 0x6f, 0x72, 0x65,
+	0x88, 0x01, 0x01, 0x42, 0x12, 0x0a, 0x10, 0x5f, 0x66, 0x6f, 0x72, 0x62, 0x69, 0x64, 0x47, 0x69,
+	0x76, 0x65, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x42, 0x14, 0x0a, 0x12, 0x5f, 0x66, 0x6f, 0x72, 0x62,
+	0x69, 0x64, 0x49, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x22, 0x18, 0x0a,
+	0x16, 0x53, 0x65, 0x74, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x53, 0x77, 0x69, 0x74, 0x63, 0x68, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x78, 0x0a, 0x14, 0x47, 0x69, 0x76, 0x65, 0x55,
+	0x6e, 0x69, 0x6f, 0x6e, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12,
+	0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x69,
+	0x64, 0x12, 0x18, 0x0a, 0x07, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x07, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x6d,
+	0x65, 0x6d, 0x62, 0x65, 0x72, 0x55, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x55, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e,
+	0x74, 0x22, 0x50, 0x0a, 0x16, 0x47, 0x69, 0x76, 0x65, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x53, 0x63,
+	0x6f, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73,
+	0x63, 0x6f, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72,
+	0x65, 0x12, 0x20, 0x0a, 0x0b, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x53, 0x63, 0x6f, 0x72, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x53, 0x63,
+	0x6f, 0x72, 0x65, 0x22, 0x78, 0x0a, 0x14, 0x54, 0x61, 0x6b, 0x65, 0x55, 0x6e, 0x69, 0x6f, 0x6e,
+	0x53, 0x63, 0x6f, 0x72, 0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x75,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x18, 0x0a,
+	0x07, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07,
+	0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x6d, 0x65, 0x6d, 0x62, 0x65,
+	0x72, 0x55, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6d, 0x65, 0x6d, 0x62,
+	0x65, 0x72, 0x55, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x50, 0x0a,
+	0x16, 0x54, 0x61, 0x6b, 0x65, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x20, 0x0a,
+	0x0b, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0b, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x22,
+	0xc0, 0x01, 0x0a, 0x10, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x52, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
+	0x12, 0x18, 0x0a, 0x07, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x07, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65,
+	0x6c, 0x61, 0x74, 0x65, 0x64, 0x55, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x72, 0x65, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x55, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65,
+	0x6d, 0x61, 0x72, 0x6b, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x6d, 0x61,
+	0x72, 0x6b, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x69,
+	0x6d, 0x65, 0x22, 0x91, 0x01, 0x0a, 0x17, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x53, 0x63, 0x6f, 0x72,
+	0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x10,
+	0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x69, 0x64,
+	0x12, 0x18, 0x0a, 0x07, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x07, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x6d, 0x65,
+	0x6d, 0x62, 0x65, 0x72, 0x55, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6d,
+	0x65, 0x6d, 0x62, 0x65, 0x72, 0x55, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73,
+	0x65, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74,
+	0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x42, 0x0a, 0x19, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x53,
+	0x63, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x04, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x11, 0x2e, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x52, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x52, 0x04, 0x6c, 0x69, 0x73, 0x74, 0x22, 0x6b, 0x0a, 0x0f, 0x55, 0x6e,
+	0x69, 0x6f, 0x6e, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x44, 0x61, 0x69, 0x6c, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x64, 0x61, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x64, 0x61, 0x79, 0x12,
+	0x16, 0x0a, 0x06, 0x69, 0x6e, 0x63, 0x6f, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x06, 0x69, 0x6e, 0x63, 0x6f, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x78, 0x70, 0x65, 0x6e,
+	0x73, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x65, 0x78, 0x70, 0x65, 0x6e, 0x73,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x75, 0x0a, 0x15, 0x55, 0x6e, 0x69, 0x6f, 0x6e,
+	0x53, 0x63, 0x6f, 0x72, 0x65, 0x44, 0x61, 0x69, 0x6c, 0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73,
+	0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75,
+	0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x07, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09,
+	0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x55, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x55, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61,
+	0x79, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x64, 0x61, 0x79, 0x73, 0x22, 0x3f,
+	0x0a, 0x17, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x44, 0x61, 0x69, 0x6c,
+	0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x24, 0x0a, 0x04, 0x6c, 0x69, 0x73,
+	0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x53,
+	0x63, 0x6f, 0x72, 0x65, 0x44, 0x61, 0x69, 0x6c, 0x79, 0x52, 0x04, 0x6c, 0x69, 0x73, 0x74, 0x32,
+	0x85, 0x06, 0x0a, 0x0c, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x12, 0x37, 0x0a, 0x0b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x12,
+	0x12, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x72,
+	0x61, 0x6d, 0x73, 0x1a, 0x14, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x55, 0x6e, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x31, 0x0a, 0x09, 0x4a, 0x6f, 0x69,
+	0x6e, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x2e, 0x4a, 0x6f, 0x69, 0x6e, 0x55, 0x6e, 0x69,
+	0x6f, 0x6e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x1a, 0x12, 0x2e, 0x4a, 0x6f, 0x69, 0x6e, 0x55,
+	0x6e, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x34, 0x0a, 0x0a,
+	0x4c, 0x65, 0x61, 0x76, 0x65, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x12, 0x11, 0x2e, 0x4c, 0x65, 0x61,
+	0x76, 0x65, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x1a, 0x13, 0x2e,
+	0x4c, 0x65, 0x61, 0x76, 0x65, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x43, 0x0a, 0x0f, 0x4b, 0x69, 0x63, 0x6b, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x4d,
+	0x65, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x16, 0x2e, 0x4b, 0x69, 0x63, 0x6b, 0x55, 0x6e, 0x69, 0x6f,
+	0x6e, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x1a, 0x18, 0x2e,
+	0x4b, 0x69, 0x63, 0x6b, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4c, 0x0a, 0x12, 0x53, 0x65, 0x74, 0x55, 0x6e,
+	0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x6f, 0x6c, 0x65, 0x12, 0x19, 0x2e,
+	0x53, 0x65, 0x74, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x6f,
+	0x6c, 0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x1a, 0x1b, 0x2e, 0x53, 0x65, 0x74, 0x55, 0x6e,
+	0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3a, 0x0a, 0x0c, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x4d, 0x65,
+	0x6d, 0x62, 0x65, 0x72, 0x73, 0x12, 0x13, 0x2e, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x6d,
+	0x62, 0x65, 0x72, 0x73, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x1a, 0x15, 0x2e, 0x55, 0x6e, 0x69,
+	0x6f, 0x6e, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x2e, 0x0a, 0x08, 0x4d, 0x79, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x0f, 0x2e,
+	0x4d, 0x79, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x73, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x1a, 0x11,
+	0x2e, 0x4d, 0x79, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x40, 0x0a, 0x0e, 0x53, 0x65, 0x74, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x53, 0x77, 0x69,
+	0x74, 0x63, 0x68, 0x12, 0x15, 0x2e, 0x53, 0x65, 0x74, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x53, 0x77,
+	0x69, 0x74, 0x63, 0x68, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x1a, 0x17, 0x2e, 0x53, 0x65, 0x74,
+	0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x53, 0x77, 0x69, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x40, 0x0a, 0x0e, 0x47, 0x69, 0x76, 0x65, 0x55, 0x6e, 0x69, 0x6f, 0x6e,
+	0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x15, 0x2e, 0x47, 0x69, 0x76, 0x65, 0x55, 0x6e, 0x69, 0x6f,
+	0x6e, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x1a, 0x17, 0x2e, 0x47,
+	0x69, 0x76, 0x65, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x40, 0x0a, 0x0e, 0x54, 0x61, 0x6b, 0x65, 0x55, 0x6e, 0x69,
+	0x6f, 0x6e, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x15, 0x2e, 0x54, 0x61, 0x6b, 0x65, 0x55, 0x6e,
+	0x69, 0x6f, 0x6e, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x1a, 0x17,
+	0x2e, 0x54, 0x61, 0x6b, 0x65, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x49, 0x0a, 0x11, 0x55, 0x6e, 0x69, 0x6f, 0x6e,
+	0x53, 0x63, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x18, 0x2e, 0x55,
+	0x6e, 0x69, 0x6f, 0x6e, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73,
+	0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x1a, 0x1a, 0x2e, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x53, 0x63,
+	0x6f, 0x72, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x43, 0x0a, 0x0f, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x53, 0x63, 0x6f, 0x72, 0x65,
+	0x44, 0x61, 0x69, 0x6c, 0x79, 0x12, 0x16, 0x2e, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x53, 0x63, 0x6f,
+	0x72, 0x65, 0x44, 0x61, 0x69, 0x6c, 0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x1a, 0x18, 0x2e,
+	0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x44, 0x61, 0x69, 0x6c, 0x79, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x0c, 0x5a, 0x0a, 0x68, 0x61, 0x6c, 0x6c, 0x2f,
+	0x70, 0x62, 0x3b, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_union_proto_rawDescOnce sync.Once
+	file_union_proto_rawDescData = file_union_proto_rawDesc
+)
+
+func file_union_proto_rawDescGZIP() []byte {
+	file_union_proto_rawDescOnce.Do(func() {
+		file_union_proto_rawDescData = protoimpl.X.CompressGZIP(file_union_proto_rawDescData)
+	})
+	return file_union_proto_rawDescData
+}
+
+var file_union_proto_msgTypes = make([]protoimpl.MessageInfo, 28)
+var file_union_proto_goTypes = []interface{}{
+	(*Union)(nil),                      // 0: Union
+	(*CreateUnionParams)(nil),          // 1: CreateUnionParams
+	(*CreateUnionResponse)(nil),        // 2: CreateUnionResponse
+	(*JoinUnionParams)(nil),            // 3: JoinUnionParams
+	(*JoinUnionResponse)(nil),          // 4: JoinUnionResponse
+	(*LeaveUnionParams)(nil),           // 5: LeaveUnionParams
+	(*LeaveUnionResponse)(nil),         // 6: LeaveUnionResponse
+	(*KickUnionMemberParams)(nil),      // 7: KickUnionMemberParams
+	(*KickUnionMemberResponse)(nil),    // 8: KickUnionMemberResponse
+	(*SetUnionMemberRoleParams)(nil),   // 9: SetUnionMemberRoleParams
+	(*SetUnionMemberRoleResponse)(nil), // 10: SetUnionMemberRoleResponse
+	(*UnionMember)(nil),                // 11: UnionMember
+	(*UnionMembersParams)(nil),         // 12: UnionMembersParams
+	(*UnionMembersResponse)(nil),       // 13: UnionMembersResponse
+	(*MyUnionsParams)(nil),             // 14: MyUnionsParams
+	(*MyUnionsResponse)(nil),           // 15: MyUnionsResponse
+	(*SetUnionSwitchParams)(nil),       // 16: SetUnionSwitchParams
+	(*SetUnionSwitchResponse)(nil),     // 17: SetUnionSwitchResponse
+	(*GiveUnionScoreParams)(nil),       // 18: GiveUnionScoreParams
+	(*GiveUnionScoreResponse)(nil),     // 19: GiveUnionScoreResponse
+	(*TakeUnionScoreParams)(nil),       // 20: TakeUnionScoreParams
+	(*TakeUnionScoreResponse)(nil),     // 21: TakeUnionScoreResponse
+	(*UnionScoreRecord)(nil),           // 22: UnionScoreRecord
+	(*UnionScoreRecordsParams)(nil),    // 23: UnionScoreRecordsParams
+	(*UnionScoreRecordsResponse)(nil),  // 24: UnionScoreRecordsResponse
+	(*UnionScoreDaily)(nil),            // 25: UnionScoreDaily
+	(*UnionScoreDailyParams)(nil),      // 26: UnionScoreDailyParams
+	(*UnionScoreDailyResponse)(nil),    // 27: UnionScoreDailyResponse
+}
+var file_union_proto_depIdxs = []int32{
+	0,  // 0: CreateUnionResponse.union:type_name -> Union
+	0,  // 1: JoinUnionResponse.union:type_name -> Union
+	11, // 2: UnionMembersResponse.list:type_name -> UnionMember
+	0,  // 3: MyUnionsResponse.list:type_name -> Union
+	22, // 4: UnionScoreRecordsResponse.list:type_name -> UnionScoreRecord
+	25, // 5: UnionScoreDailyResponse.list:type_name -> UnionScoreDaily
+	1,  // 6: UnionService.CreateUnion:input_type -> CreateUnionParams
+	3,  // 7: UnionService.JoinUnion:input_type -> JoinUnionParams
+	5,  // 8: UnionService.LeaveUnion:input_type -> LeaveUnionParams
+	7,  // 9: UnionService.KickUnionMember:input_type -> KickUnionMemberParams
+	9,  // 10: UnionService.SetUnionMemberRole:input_type -> SetUnionMemberRoleParams
+	12, // 11: UnionService.UnionMembers:input_type -> UnionMembersParams
+	14, // 12: UnionService.MyUnions:input_type -> MyUnionsParams
+	16, // 13: UnionService.SetUnionSwitch:input_type -> SetUnionSwitchParams
+	18, // 14: UnionService.GiveUnionScore:input_type -> GiveUnionScoreParams
+	20, // 15: UnionService.TakeUnionScore:input_type -> TakeUnionScoreParams
+	23, // 16: UnionService.UnionScoreRecords:input_type -> UnionScoreRecordsParams
+	26, // 17: UnionService.UnionScoreDaily:input_type -> UnionScoreDailyParams
+	2,  // 18: UnionService.CreateUnion:output_type -> CreateUnionResponse
+	4,  // 19: UnionService.JoinUnion:output_type -> JoinUnionResponse
+	6,  // 20: UnionService.LeaveUnion:output_type -> LeaveUnionResponse
+	8,  // 21: UnionService.KickUnionMember:output_type -> KickUnionMemberResponse
+	10, // 22: UnionService.SetUnionMemberRole:output_type -> SetUnionMemberRoleResponse
+	13, // 23: UnionService.UnionMembers:output_type -> UnionMembersResponse
+	15, // 24: UnionService.MyUnions:output_type -> MyUnionsResponse
+	17, // 25: UnionService.SetUnionSwitch:output_type -> SetUnionSwitchResponse
+	19, // 26: UnionService.GiveUnionScore:output_type -> GiveUnionScoreResponse
+	21, // 27: UnionService.TakeUnionScore:output_type -> TakeUnionScoreResponse
+	24, // 28: UnionService.UnionScoreRecords:output_type -> UnionScoreRecordsResponse
+	27, // 29: UnionService.UnionScoreDaily:output_type -> UnionScoreDailyResponse
+	18, // [18:30] is the sub-list for method output_type
+	6,  // [6:18] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_union_proto_init() }
+func file_union_proto_init() {
+	if File_union_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_union_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Union); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_union_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateUnionParams); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_union_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateUnionResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_union_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JoinUnionParams); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_union_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JoinUnionResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_union_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LeaveUnionParams); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_union_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LeaveUnionResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_union_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KickUnionMemberParams); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_union_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KickUnionMemberResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_union_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetUnionMemberRoleParams); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_union_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetUnionMemberRoleResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_union_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UnionMember); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_union_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UnionMembersParams); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_union_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UnionMembersResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_union_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MyUnionsParams); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_union_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MyUnionsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_union_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetUnionSwitchParams); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_union_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetUnionSwitchResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_union_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GiveUnionScoreParams); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_union_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GiveUnionScoreResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_union_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TakeUnionScoreParams); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_union_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TakeUnionScoreResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_union_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UnionScoreRecord); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_union_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UnionScoreRecordsParams); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_union_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UnionScoreRecordsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_union_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UnionScoreDaily); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_union_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UnionScoreDailyParams); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_union_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UnionScoreDailyResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_union_proto_msgTypes[16].OneofWrappers = []interface{}{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_union_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   28,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_union_proto_goTypes,
+		DependencyIndexes: file_union_proto_depIdxs,
+		MessageInfos:      file_union_proto_msgTypes,
+	}.Build()
+	File_union_proto = out.File
+	file_union_proto_rawDesc = nil
+	file_union_proto_goTypes = nil
+	file_union_proto_depIdxs = nil
+}
@@ -0,0 +1,516 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.25.3
+// source: union.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	UnionService_CreateUnion_FullMethodName        = "/UnionService/CreateUnion"
+	UnionService_JoinUnion_FullMethodName          = "/UnionService/JoinUnion"
+	UnionService_LeaveUnion_FullMethodName         = "/UnionService/LeaveUnion"
+	UnionService_KickUnionMember_FullMethodName    = "/UnionService/KickUnionMember"
+	UnionService_SetUnionMemberRole_FullMethodName = "/UnionService/SetUnionMemberRole"
+	UnionService_UnionMembers_FullMethodName       = "/UnionService/UnionMembers"
+	UnionService_MyUnions_FullMethodName           = "/UnionService/MyUnions"
+	UnionService_SetUnionSwitch_FullMethodName     = "/UnionService/SetUnionSwitch"
+	UnionService_GiveUnionScore_FullMethodName     = "/UnionService/GiveUnionScore"
+	UnionService_TakeUnionScore_FullMethodName     = "/UnionService/TakeUnionScore"
+	UnionService_UnionScoreRecords_FullMethodName  = "/UnionService/UnionScoreRecords"
+	UnionService_UnionScoreDaily_FullMethodName    = "/UnionService/UnionScoreDaily"
+)
+
+// UnionServiceClient is the client API for UnionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type UnionServiceClient interface {
+	CreateUnion(ctx context.Context, in *CreateUnionParams, opts ...grpc.CallOption) (*CreateUnionResponse, error)
+	JoinUnion(ctx context.Context, in *JoinUnionParams, opts ...grpc.CallOption) (*JoinUnionResponse, error)
+	LeaveUnion(ctx context.Context, in *LeaveUnionParams, opts ...grpc.CallOption) (*LeaveUnionResponse, error)
+	KickUnionMember(ctx context.Context, in *KickUnionMemberParams, opts ...grpc.CallOption) (*KickUnionMemberResponse, error)
+	SetUnionMemberRole(ctx context.Context, in *SetUnionMemberRoleParams, opts ...grpc.CallOption) (*SetUnionMemberRoleResponse, error)
+	UnionMembers(ctx context.Context, in *UnionMembersParams, opts ...grpc.CallOption) (*UnionMembersResponse, error)
+	MyUnions(ctx context.Context, in *MyUnionsParams, opts ...grpc.CallOption) (*MyUnionsResponse, error)
+	SetUnionSwitch(ctx context.Context, in *SetUnionSwitchParams, opts ...grpc.CallOption) (*SetUnionSwitchResponse, error)
+	GiveUnionScore(ctx context.Context, in *GiveUnionScoreParams, opts ...grpc.CallOption) (*GiveUnionScoreResponse, error)
+	TakeUnionScore(ctx context.Context, in *TakeUnionScoreParams, opts ...grpc.CallOption) (*TakeUnionScoreResponse, error)
+	UnionScoreRecords(ctx context.Context, in *UnionScoreRecordsParams, opts ...grpc.CallOption) (*UnionScoreRecordsResponse, error)
+	UnionScoreDaily(ctx context.Context, in *UnionScoreDailyParams, opts ...grpc.CallOption) (*UnionScoreDailyResponse, error)
+}
+
+type unionServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewUnionServiceClient(cc grpc.ClientConnInterface) UnionServiceClient {
+	return &unionServiceClient{cc}
+}
+
+func (c *unionServiceClient) CreateUnion(ctx context.Context, in *CreateUnionParams, opts ...grpc.CallOption) (*CreateUnionResponse, error) {
+	out := new(CreateUnionResponse)
+	err := c.cc.Invoke(ctx, UnionService_CreateUnion_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *unionServiceClient) JoinUnion(ctx context.Context, in *JoinUnionParams, opts ...grpc.CallOption) (*JoinUnionResponse, error) {
+	out := new(JoinUnionResponse)
+	err := c.cc.Invoke(ctx, UnionService_JoinUnion_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *unionServiceClient) LeaveUnion(ctx context.Context, in *LeaveUnionParams, opts ...grpc.CallOption) (*LeaveUnionResponse, error) {
+	out := new(LeaveUnionResponse)
+	err := c.cc.Invoke(ctx, UnionService_LeaveUnion_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *unionServiceClient) KickUnionMember(ctx context.Context, in *KickUnionMemberParams, opts ...grpc.CallOption) (*KickUnionMemberResponse, error) {
+	out := new(KickUnionMemberResponse)
+	err := c.cc.Invoke(ctx, UnionService_KickUnionMember_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *unionServiceClient) SetUnionMemberRole(ctx context.Context, in *SetUnionMemberRoleParams, opts ...grpc.CallOption) (*SetUnionMemberRoleResponse, error) {
+	out := new(SetUnionMemberRoleResponse)
+	err := c.cc.Invoke(ctx, UnionService_SetUnionMemberRole_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *unionServiceClient) UnionMembers(ctx context.Context, in *UnionMembersParams, opts ...grpc.CallOption) (*UnionMembersResponse, error) {
+	out := new(UnionMembersResponse)
+	err := c.cc.Invoke(ctx, UnionService_UnionMembers_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *unionServiceClient) MyUnions(ctx context.Context, in *MyUnionsParams, opts ...grpc.CallOption) (*MyUnionsResponse, error) {
+	out := new(MyUnionsResponse)
+	err := c.cc.Invoke(ctx, UnionService_MyUnions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *unionServiceClient) SetUnionSwitch(ctx context.Context, in *SetUnionSwitchParams, opts ...grpc.CallOption) (*SetUnionSwitchResponse, error) {
+	out := new(SetUnionSwitchResponse)
+	err := c.cc.Invoke(ctx, UnionService_SetUnionSwitch_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *unionServiceClient) GiveUnionScore(ctx context.Context, in *GiveUnionScoreParams, opts ...grpc.CallOption) (*GiveUnionScoreResponse, error) {
+	out := new(GiveUnionScoreResponse)
+	err := c.cc.Invoke(ctx, UnionService_GiveUnionScore_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *unionServiceClient) TakeUnionScore(ctx context.Context, in *TakeUnionScoreParams, opts ...grpc.CallOption) (*TakeUnionScoreResponse, error) {
+	out := new(TakeUnionScoreResponse)
+	err := c.cc.Invoke(ctx, UnionService_TakeUnionScore_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *unionServiceClient) UnionScoreRecords(ctx context.Context, in *UnionScoreRecordsParams, opts ...grpc.CallOption) (*UnionScoreRecordsResponse, error) {
+	out := new(UnionScoreRecordsResponse)
+	err := c.cc.Invoke(ctx, UnionService_UnionScoreRecords_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *unionServiceClient) UnionScoreDaily(ctx context.Context, in *UnionScoreDailyParams, opts ...grpc.CallOption) (*UnionScoreDailyResponse, error) {
+	out := new(UnionScoreDailyResponse)
+	err := c.cc.Invoke(ctx, UnionService_UnionScoreDaily_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UnionServiceServer is the server API for UnionService service.
+// All implementations must embed UnimplementedUnionServiceServer
+// for forward compatibility
+type UnionServiceServer interface {
+	CreateUnion(context.Context, *CreateUnionParams) (*CreateUnionResponse, error)
+	JoinUnion(context.Context, *JoinUnionParams) (*JoinUnionResponse, error)
+	LeaveUnion(context.Context, *LeaveUnionParams) (*LeaveUnionResponse, error)
+	KickUnionMember(context.Context, *KickUnionMemberParams) (*KickUnionMemberResponse, error)
+	SetUnionMemberRole(context.Context, *SetUnionMemberRoleParams) (*SetUnionMemberRoleResponse, error)
+	UnionMembers(context.Context, *UnionMembersParams) (*UnionMembersResponse, error)
+	MyUnions(context.Context, *MyUnionsParams) (*MyUnionsResponse, error)
+	SetUnionSwitch(context.Context, *SetUnionSwitchParams) (*SetUnionSwitchResponse, error)
+	GiveUnionScore(context.Context, *GiveUnionScoreParams) (*GiveUnionScoreResponse, error)
+	TakeUnionScore(context.Context, *TakeUnionScoreParams) (*TakeUnionScoreResponse, error)
+	UnionScoreRecords(context.Context, *UnionScoreRecordsParams) (*UnionScoreRecordsResponse, error)
+	UnionScoreDaily(context.Context, *UnionScoreDailyParams) (*UnionScoreDailyResponse, error)
+	mustEmbedUnimplementedUnionServiceServer()
+}
+
+// UnimplementedUnionServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedUnionServiceServer struct {
+}
+
+func (UnimplementedUnionServiceServer) CreateUnion(context.Context, *CreateUnionParams) (*CreateUnionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateUnion not implemented")
+}
+func (UnimplementedUnionServiceServer) JoinUnion(context.Context, *JoinUnionParams) (*JoinUnionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method JoinUnion not implemented")
+}
+func (UnimplementedUnionServiceServer) LeaveUnion(context.Context, *LeaveUnionParams) (*LeaveUnionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LeaveUnion not implemented")
+}
+func (UnimplementedUnionServiceServer) KickUnionMember(context.Context, *KickUnionMemberParams) (*KickUnionMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method KickUnionMember not implemented")
+}
+func (UnimplementedUnionServiceServer) SetUnionMemberRole(context.Context, *SetUnionMemberRoleParams) (*SetUnionMemberRoleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetUnionMemberRole not implemented")
+}
+func (UnimplementedUnionServiceServer) UnionMembers(context.Context, *UnionMembersParams) (*UnionMembersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnionMembers not implemented")
+}
+func (UnimplementedUnionServiceServer) MyUnions(context.Context, *MyUnionsParams) (*MyUnionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MyUnions not implemented")
+}
+func (UnimplementedUnionServiceServer) SetUnionSwitch(context.Context, *SetUnionSwitchParams) (*SetUnionSwitchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetUnionSwitch not implemented")
+}
+func (UnimplementedUnionServiceServer) GiveUnionScore(context.Context, *GiveUnionScoreParams) (*GiveUnionScoreResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GiveUnionScore not implemented")
+}
+func (UnimplementedUnionServiceServer) TakeUnionScore(context.Context, *TakeUnionScoreParams) (*TakeUnionScoreResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TakeUnionScore not implemented")
+}
+func (UnimplementedUnionServiceServer) UnionScoreRecords(context.Context, *UnionScoreRecordsParams) (*UnionScoreRecordsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnionScoreRecords not implemented")
+}
+func (UnimplementedUnionServiceServer) UnionScoreDaily(context.Context, *UnionScoreDailyParams) (*UnionScoreDailyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnionScoreDaily not implemented")
+}
+func (UnimplementedUnionServiceServer) mustEmbedUnimplementedUnionServiceServer() {}
+
+// UnsafeUnionServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to UnionServiceServer will
+// result in compilation errors.
+type UnsafeUnionServiceServer interface {
+	mustEmbedUnimplementedUnionServiceServer()
+}
+
+func RegisterUnionServiceServer(s grpc.ServiceRegistrar, srv UnionServiceServer) {
+	s.RegisterService(&UnionService_ServiceDesc, srv)
+}
+
+func _UnionService_CreateUnion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateUnionParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UnionServiceServer).CreateUnion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UnionService_CreateUnion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UnionServiceServer).CreateUnion(ctx, req.(*CreateUnionParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UnionService_JoinUnion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinUnionParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UnionServiceServer).JoinUnion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UnionService_JoinUnion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UnionServiceServer).JoinUnion(ctx, req.(*JoinUnionParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UnionService_LeaveUnion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaveUnionParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UnionServiceServer).LeaveUnion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UnionService_LeaveUnion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UnionServiceServer).LeaveUnion(ctx, req.(*LeaveUnionParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UnionService_KickUnionMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KickUnionMemberParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UnionServiceServer).KickUnionMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UnionService_KickUnionMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UnionServiceServer).KickUnionMember(ctx, req.(*KickUnionMemberParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UnionService_SetUnionMemberRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetUnionMemberRoleParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UnionServiceServer).SetUnionMemberRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UnionService_SetUnionMemberRole_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UnionServiceServer).SetUnionMemberRole(ctx, req.(*SetUnionMemberRoleParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UnionService_UnionMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnionMembersParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UnionServiceServer).UnionMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UnionService_UnionMembers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UnionServiceServer).UnionMembers(ctx, req.(*UnionMembersParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UnionService_MyUnions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MyUnionsParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UnionServiceServer).MyUnions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UnionService_MyUnions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UnionServiceServer).MyUnions(ctx, req.(*MyUnionsParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UnionService_SetUnionSwitch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetUnionSwitchParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UnionServiceServer).SetUnionSwitch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UnionService_SetUnionSwitch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UnionServiceServer).SetUnionSwitch(ctx, req.(*SetUnionSwitchParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UnionService_GiveUnionScore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GiveUnionScoreParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UnionServiceServer).GiveUnionScore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UnionService_GiveUnionScore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UnionServiceServer).GiveUnionScore(ctx, req.(*GiveUnionScoreParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UnionService_TakeUnionScore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TakeUnionScoreParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UnionServiceServer).TakeUnionScore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UnionService_TakeUnionScore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UnionServiceServer).TakeUnionScore(ctx, req.(*TakeUnionScoreParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UnionService_UnionScoreRecords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnionScoreRecordsParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UnionServiceServer).UnionScoreRecords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UnionService_UnionScoreRecords_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UnionServiceServer).UnionScoreRecords(ctx, req.(*UnionScoreRecordsParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UnionService_UnionScoreDaily_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnionScoreDailyParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UnionServiceServer).UnionScoreDaily(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UnionService_UnionScoreDaily_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UnionServiceServer).UnionScoreDaily(ctx, req.(*UnionScoreDailyParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// UnionService_ServiceDesc is the grpc.ServiceDesc for UnionService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var UnionService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "UnionService",
+	HandlerType: (*UnionServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateUnion",
+			Handler:    _UnionService_CreateUnion_Handler,
+		},
+		{
+			MethodName: "JoinUnion",
+			Handler:    _UnionService_JoinUnion_Handler,
+		},
+		{
+			MethodName: "LeaveUnion",
+			Handler:    _UnionService_LeaveUnion_Handler,
+		},
+		{
+			MethodName: "KickUnionMember",
+			Handler:    _UnionService_KickUnionMember_Handler,
+		},
+		{
+			MethodName: "SetUnionMemberRole",
+			Handler:    _UnionService_SetUnionMemberRole_Handler,
+		},
+		{
+			MethodName: "UnionMembers",
+			Handler:    _UnionService_UnionMembers_Handler,
+		},
+		{
+			MethodName: "MyUnions",
+			Handler:    _UnionService_MyUnions_Handler,
+		},
+		{
+			MethodName: "SetUnionSwitch",
+			Handler:    _UnionService_SetUnionSwitch_Handler,
+		},
+		{
+			MethodName: "GiveUnionScore",
+			Handler:    _UnionService_GiveUnionScore_Handler,
+		},
+		{
+			MethodName: "TakeUnionScore",
+			Handler:    _UnionService_TakeUnionScore_Handler,
+		},
+		{
+			MethodName: "UnionScoreRecords",
+			Handler:    _UnionService_UnionScoreRecords_Handler,
+		},
+		{
+			MethodName: "UnionScoreDaily",
+			Handler:    _UnionService_UnionScoreDaily_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "union.proto",
+}
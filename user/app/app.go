@@ -3,15 +3,18 @@ package app
 import (
 	"common/config"
 	"common/discovery"
+	"common/lifecycle"
 	"common/logs"
+	"common/metrics"
+	"common/rpc"
+	"common/sensitive"
+	"common/tracing"
 	"context"
 	"core/repo"
+	"fmt"
 	"google.golang.org/grpc"
-	"net"
-	"os"
-	"os/signal"
-	"syscall"
-	"time"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"user/internal/service"
 	"user/pb"
 )
@@ -20,68 +23,79 @@ import (
 func Run(ctx context.Context) error {
 
 	// 1.初始化日志库
-	logs.InitLog(config.Conf.AppName)
+	logs.InitLog(config.Current().AppName)
 
-	// 2.初始化数据库管理
+	// 2.初始化链路追踪
+	shutdownTrace, err := tracing.Init(config.Current().AppName, config.Current().Trace)
+	if err != nil {
+		return err
+	}
+
+	// 3.加载敏感词，初始化数据库管理
+	sensitive.Load(config.Current().Sensitive)
 	manager := repo.New()
 
-	// 3.获取etcd注册客户端实例
+	// 4.获取etcd注册客户端实例
 	register := discovery.NewRegister()
 
-	// 4.起一个协程启动gRPC服务端
-	server := grpc.NewServer()
-	go func() {
-		listen, err := net.Listen("tcp", config.Conf.Grpc.Addr)
-		if err != nil {
-			logs.Fatal("==> user grpc server listen error: %v", err)
-		}
-		// 4.1 启动成功之后，将该gRPC服务注册到etcd
-		err = register.Register(config.Conf.Etcd)
-		if err != nil {
-			logs.Fatal("==> user grpc server register etcd error: %v", err)
-		}
-
-		// 4.2 注册 account service到grpc
-		pb.RegisterUserServiceServer(server, service.NewAccountService(manager))
+	// 5.创建gRPC服务端，注册 account service 和grpc标准健康检查服务
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(tracing.UnaryServerInterceptor(), metrics.UnaryServerInterceptor()))
+	healthServer := health.NewServer()
+	pb.RegisterUserServiceServer(server, service.NewAccountService(manager))
+	healthpb.RegisterHealthServer(server, healthServer)
 
-		if err = server.Serve(listen); err != nil {
-			logs.Fatal("user grpc server run failed error: %v", err)
-		}
-	}()
+	// admin service 单独监听内网地址并校验令牌，不和业务服务一起注册到etcd
+	adminServer := grpc.NewServer(grpc.ChainUnaryInterceptor(tracing.UnaryServerInterceptor(), metrics.UnaryServerInterceptor(), rpc.AdminAuthInterceptor()))
+	pb.RegisterAdminServiceServer(adminServer, service.NewAdminService(manager))
 
-	// 优雅启停，注册一个名为stop的方法，遇到终止、退出、中断、挂断信号，则结束gRPC server的运行
-	stop := func() {
-		server.Stop()               // 停止grpc服务端
-		register.Close()            // 关闭与etcd的连接
-		manager.Close()             // 关闭所有的数据库连接
-		time.Sleep(3 * time.Second) // 休眠3S，停止必要的服务
-		logs.Info("stop app finish")
+	// 就绪检查：数据库连接和etcd注册状态
+	metrics.RegisterChecker(
+		metrics.NewChecker("mongo", manager.Mongo.Ping),
+		metrics.NewChecker("redis", manager.Redis.Ping),
+		metrics.NewChecker("etcd", register.Check),
+	)
+	metricServer, err := metrics.NewServer(fmt.Sprintf("0.0.0.0:%d", config.Current().MetricPort))
+	if err != nil {
+		return err
 	}
-	c := make(chan os.Signal, 1)
-	// 信号监听
-	signal.Notify(c, syscall.SIGTERM, syscall.SIGQUIT, syscall.SIGINT, syscall.SIGHUP)
-	for {
-		select {
-		// 上下文事件完成
-		case <-ctx.Done():
-			stop()
-			return nil
-		// 收到终止信号
-		case s := <-c:
-			logs.Warn("get a signal %s", s.String())
-			switch s {
-			case syscall.SIGTERM, syscall.SIGQUIT, syscall.SIGINT:
-				stop()
-				logs.Warn("user grpc server exit")
-				return nil
-			case syscall.SIGHUP:
-				logs.Warn("hangup!!")
-				return nil
-			default:
-				return nil
-			}
 
-		}
+	// 6.按顺序启动各组件，停止时逆序：先从etcd注销，再等待grpc处理完存量请求，最后关闭数据库连接
+	lc := lifecycle.New(config.Current().AppName)
+	lc.Append(lc.HttpServer("metrics", metricServer))
+	lc.Append(lifecycle.Hook{
+		Name:   "trace",
+		OnStop: shutdownTrace,
+	})
+	lc.Append(lifecycle.Hook{
+		Name: "database",
+		OnStop: func(ctx context.Context) error {
+			manager.Close()
+			return nil
+		},
+	})
+	lc.Append(lc.GrpcServer("grpc", server, config.Current().Grpc.Addr))
+	if addr := config.Current().Grpc.AdminAddr; addr != "" {
+		lc.Append(lc.GrpcServer("admin", adminServer, addr))
 	}
-
+	lc.Append(lifecycle.Hook{
+		Name: "etcd",
+		OnStart: func(ctx context.Context) error {
+			// gRPC服务启动成功之后，再注册到etcd
+			if err := register.Register(config.Current().Etcd); err != nil {
+				return err
+			}
+			healthServer.Resume()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			healthServer.Shutdown() // 健康检查置为NOT_SERVING
+			register.Close()        // 从etcd注销，不再有新请求进来
+			return nil
+		},
+	})
+	lc.OnReload(func() {
+		logs.SetLevel(config.Current().Log.Level)
+		sensitive.Load(config.Current().Sensitive)
+	})
+	return lc.Run(ctx)
 }
@@ -1,32 +1,186 @@
 package service
 
 import (
+	"common/biz"
+	"common/database"
 	"common/logs"
+	"common/msError"
 	"context"
+	"core/models/entity"
 	"core/repo"
+	"errors"
+	"strconv"
+	"time"
 	"user/pb"
+
+	"golang.org/x/crypto/bcrypt"
 )
 
+// uid从10000开始
+const uidStart = 10000
+
+// 重复提交注册的幂等时间
+const registerIdempotencyTTL = 10 * time.Minute
+
 // 创建账号
 type AccountService struct {
 	pb.UnimplementedUserServiceServer
+	accounts repo.AccountRepository
+	users    repo.UserRepository
+	counters repo.CounterRepository
+	redis    *database.RedisManager
+	tx       database.Transactor
+	// 按账号幂等，客户端重复提交注册时返回第一次注册的uid
+	registers *database.Idempotency
 }
 
-
 /**
 	账户service中可能涉及数据库操作，所以将repoManager放进来
  */
 func NewAccountService(manager *repo.Manager) *AccountService {
- 	return &AccountService{}
+	return &AccountService{
+		accounts:  manager.Accounts,
+		users:     manager.Users,
+		counters:  manager.Counters,
+		redis:     manager.Redis,
+		tx:        manager.Tx,
+		registers: manager.Redis.NewIdempotency("register", registerIdempotencyTTL),
+	}
 }
 
 func (a *AccountService) Register(ctx context.Context, req *pb.RegisterParams) (*pb.RegisterResponse, error) {
+	logs.InfoCtx(ctx, "register server called ...")
+	if req.Account == "" || req.Password == "" {
+		return nil, msError.GrpcError(biz.RequestDataError)
+	}
 
-	// 这里写注册的业务逻辑
+	uid, done, err := a.registers.Begin(ctx, req.Account)
+	if errors.Is(err, database.ErrRequestProcessing) {
+		return nil, msError.GrpcError(biz.UserDataLocked)
+	}
+	if err != nil {
+		logs.ErrorCtx(ctx, "register begin idempotency err: %v", err)
+		return nil, msError.GrpcError(biz.Fail)
+	}
+	if done {
+		return a.replayRegister(ctx, req, uid)
+	}
+	uid, err = a.register(ctx, req)
+	if err != nil {
+		_ = a.registers.Abort(context.Background(), req.Account)
+		return nil, err
+	}
+	_ = a.registers.Complete(context.Background(), req.Account, uid)
+	return &pb.RegisterResponse{
+		Uid: uid,
+	}, nil
+}
 
-	logs.Info("register server called ...")
+// replayRegister 重复提交时密码相同才返回第一次注册的uid，否则为账号已存在
+func (a *AccountService) replayRegister(ctx context.Context, req *pb.RegisterParams, uid string) (*pb.RegisterResponse, error) {
+	account, err := a.accounts.FindByAccount(ctx, req.Account)
+	if err != nil {
+		logs.ErrorCtx(ctx, "register replay find account err: %v", err)
+		return nil, msError.GrpcError(biz.SqlError)
+	}
+	if account.Uid != uid || bcrypt.CompareHashAndPassword([]byte(account.Password), []byte(req.Password)) != nil {
+		return nil, msError.GrpcError(biz.AccountExist)
+	}
+	logs.InfoCtx(ctx, "register resubmitted, account: %s, uid: %s", req.Account, uid)
 	return &pb.RegisterResponse{
-		Uid: "10000",
+		Uid: uid,
 	}, nil
 }
 
+// register 保存账号和用户信息，返回uid
+func (a *AccountService) register(ctx context.Context, req *pb.RegisterParams) (string, error) {
+	// 1.账号已存在
+	_, err := a.accounts.FindByAccount(ctx, req.Account)
+	if err == nil {
+		return "", msError.GrpcError(biz.AccountExist)
+	}
+	if err != repo.ErrNotFound {
+		logs.ErrorCtx(ctx, "register find account err: %v", err)
+		return "", msError.GrpcError(biz.SqlError)
+	}
+
+	// 2.生成uid，保存账号和用户信息
+	password, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", msError.GrpcError(biz.Fail)
+	}
+	seq, err := a.counters.Next(ctx, "uid", uidStart)
+	if err != nil {
+		logs.ErrorCtx(ctx, "register gen uid err: %v", err)
+		return "", msError.GrpcError(biz.SqlError)
+	}
+	uid := strconv.FormatInt(seq, 10)
+	now := time.Now().UnixMilli()
+	// 账号和用户信息在同一个事务中创建，用户信息创建失败时不会留下没有用户的账号
+	var accountExist bool
+	err = a.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		err := a.accounts.Create(ctx, &entity.Account{
+			Uid:           uid,
+			Account:       req.Account,
+			Password:      string(password),
+			LoginPlatform: req.LoginPlatform,
+			CreateTime:    now,
+		})
+		if err != nil {
+			accountExist = err == repo.ErrDuplicate
+			return err
+		}
+		return a.users.Create(ctx, &entity.User{
+			Uid:        uid,
+			Nickname:   "用户" + uid,
+			CreateTime: now,
+			UpdateTime: now,
+		})
+	})
+	if accountExist {
+		// 并发注册同一个账号，唯一索引兜底
+		return "", msError.GrpcError(biz.AccountExist)
+	}
+	if err != nil {
+		logs.ErrorCtx(ctx, "register create account err: %v", err)
+		return "", msError.GrpcError(biz.SqlError)
+	}
+
+	return uid, nil
+}
+
+func (a *AccountService) Login(ctx context.Context, req *pb.LoginParams) (*pb.LoginResponse, error) {
+	if req.Account == "" || req.Password == "" {
+		return nil, msError.GrpcError(biz.RequestDataError)
+	}
+
+	// 账号不存在和密码错误返回相同的错误，避免被用来探测账号是否存在
+	account, err := a.accounts.FindByAccount(ctx, req.Account)
+	if err == repo.ErrNotFound {
+		return nil, msError.GrpcError(biz.AccountOrPasswordError)
+	}
+	if err != nil {
+		logs.ErrorCtx(ctx, "login find account err: %v", err)
+		return nil, msError.GrpcError(biz.SqlError)
+	}
+	if bcrypt.CompareHashAndPassword([]byte(account.Password), []byte(req.Password)) != nil {
+		return nil, msError.GrpcError(biz.AccountOrPasswordError)
+	}
+	// 密码正确后再判断冻结，避免暴露账号状态
+	if account.Blocked(time.Now().UnixMilli()) {
+		return nil, msError.GrpcError(biz.BlockedAccount)
+	}
+
+	// 记录最后登录时间，只修改这一个字段，不会覆盖同时进行的金币、房间修改
+	err = a.users.SetLastLoginTime(ctx, account.Uid, time.Now().UnixMilli())
+	if err == repo.ErrNotFound {
+		return nil, msError.GrpcError(biz.NotFindUser)
+	}
+	if err != nil {
+		logs.ErrorCtx(ctx, "login update user err: %v", err)
+	}
+
+	return &pb.LoginResponse{
+		Uid: account.Uid,
+	}, nil
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"common/biz"
+	"common/msError"
+	"context"
+	"core/models/entity"
+	"core/repo"
+	"core/repo/repotest"
+	"testing"
+	"user/pb"
+)
+
+func newTestAccountService(t *testing.T) *AccountService {
+	manager := repotest.New(t)
+	return NewAccountService(manager)
+}
+
+// 把grpc错误转换成biz错误码，成功时为biz.OK
+func errCode(err error) int {
+	if err == nil {
+		return biz.OK
+	}
+	return msError.ToError(err).Code
+}
+
+func TestAccountService_Register(t *testing.T) {
+	ctx := context.Background()
+	a := newTestAccountService(t)
+	if _, err := a.Register(ctx, &pb.RegisterParams{Account: "exist", Password: "123456"}); err != nil {
+		t.Fatalf("register exist account: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		req  *pb.RegisterParams
+		code int
+	}{
+		{"success", &pb.RegisterParams{Account: "new", Password: "123456"}, biz.OK},
+		{"account exist", &pb.RegisterParams{Account: "exist", Password: "654321"}, biz.AccountExist.Code},
+		{"empty account", &pb.RegisterParams{Password: "123456"}, biz.RequestDataError.Code},
+		{"empty password", &pb.RegisterParams{Account: "nopassword"}, biz.RequestDataError.Code},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := a.Register(ctx, tt.req)
+			if code := errCode(err); code != tt.code {
+				t.Fatalf("code = %d, want %d, err: %v", code, tt.code, err)
+			}
+			if tt.code == biz.OK && res.Uid == "" {
+				t.Fatal("uid is empty")
+			}
+		})
+	}
+}
+
+func TestAccountService_RegisterUid(t *testing.T) {
+	ctx := context.Background()
+	a := newTestAccountService(t)
+	first, err := a.Register(ctx, &pb.RegisterParams{Account: "a1", Password: "123456"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := a.Register(ctx, &pb.RegisterParams{Account: "a2", Password: "123456"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Uid != "10000" || second.Uid != "10001" {
+		t.Fatalf("uid = %s, %s, want 10000, 10001", first.Uid, second.Uid)
+	}
+	// 注册时同时创建用户信息
+	if _, err := a.users.FindByUid(ctx, first.Uid); err != nil {
+		t.Fatalf("find user: %v", err)
+	}
+}
+
+// 创建用户信息失败时账号一起回滚，重新注册可以成功
+func TestAccountService_RegisterRollback(t *testing.T) {
+	ctx := context.Background()
+	a := newTestAccountService(t)
+	// 占用第一个uid，创建用户信息时冲突
+	if err := a.users.Create(ctx, &entity.User{Uid: "10000"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.Register(ctx, &pb.RegisterParams{Account: "a1", Password: "123456"}); errCode(err) != biz.SqlError.Code {
+		t.Fatalf("register err = %v", err)
+	}
+	if _, err := a.accounts.FindByAccount(ctx, "a1"); err != repo.ErrNotFound {
+		t.Fatalf("orphan account err = %v", err)
+	}
+	res, err := a.Register(ctx, &pb.RegisterParams{Account: "a1", Password: "123456"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = a.Login(ctx, &pb.LoginParams{Account: "a1", Password: "123456"}); err != nil {
+		t.Fatalf("login %s err = %v", res.Uid, err)
+	}
+}
+
+// 重复提交相同的账号密码返回第一次注册的uid，不会生成新的uid
+func TestAccountService_RegisterResubmit(t *testing.T) {
+	ctx := context.Background()
+	a := newTestAccountService(t)
+	first, err := a.Register(ctx, &pb.RegisterParams{Account: "a1", Password: "123456"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := a.Register(ctx, &pb.RegisterParams{Account: "a1", Password: "123456"})
+	if err != nil || again.Uid != first.Uid {
+		t.Fatalf("resubmit = %v, %v, want %s", again, err, first.Uid)
+	}
+	if _, err = a.Register(ctx, &pb.RegisterParams{Account: "a1", Password: "654321"}); errCode(err) != biz.AccountExist.Code {
+		t.Fatalf("other password err = %v", err)
+	}
+	second, err := a.Register(ctx, &pb.RegisterParams{Account: "a2", Password: "123456"})
+	if err != nil || second.Uid != "10001" {
+		t.Fatalf("next uid = %v, %v", second, err)
+	}
+}
+
+func TestAccountService_Login(t *testing.T) {
+	ctx := context.Background()
+	a := newTestAccountService(t)
+	registered, err := a.Register(ctx, &pb.RegisterParams{Account: "player", Password: "123456"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		req  *pb.LoginParams
+		code int
+	}{
+		{"success", &pb.LoginParams{Account: "player", Password: "123456"}, biz.OK},
+		{"wrong password", &pb.LoginParams{Account: "player", Password: "000000"}, biz.AccountOrPasswordError.Code},
+		{"account not exist", &pb.LoginParams{Account: "nobody", Password: "123456"}, biz.AccountOrPasswordError.Code},
+		{"empty password", &pb.LoginParams{Account: "player"}, biz.RequestDataError.Code},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := a.Login(ctx, tt.req)
+			if code := errCode(err); code != tt.code {
+				t.Fatalf("code = %d, want %d, err: %v", code, tt.code, err)
+			}
+			if tt.code == biz.OK && res.Uid != registered.Uid {
+				t.Fatalf("uid = %s, want %s", res.Uid, registered.Uid)
+			}
+		})
+	}
+
+	// 登录成功后记录登录时间
+	user, err := a.users.FindByUid(ctx, registered.Uid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.LastLoginTime == 0 {
+		t.Fatal("last login time not updated")
+	}
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"common/biz"
+	"common/database"
+	"common/jwts"
+	"common/logs"
+	"common/msError"
+	"context"
+	"core/models/entity"
+	"core/repo"
+	"core/session"
+	"time"
+	"user/pb"
+)
+
+// 分页查询默认、最大条数
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// AdminService 后台管理：冻结、解冻账号，全平台维护
+type AdminService struct {
+	pb.UnimplementedAdminServiceServer
+	accounts repo.AccountRepository
+	redis    *database.RedisManager
+}
+
+func NewAdminService(manager *repo.Manager) *AdminService {
+	return &AdminService{
+		accounts: manager.Accounts,
+		redis:    manager.Redis,
+	}
+}
+
+// BlockAccount 冻结账号，同时吊销已签发的token并踢下线
+func (a *AdminService) BlockAccount(ctx context.Context, req *pb.BlockAccountParams) (*pb.BlockAccountResponse, error) {
+	now := time.Now().UnixMilli()
+	if req.Uid == "" || req.Reason == "" || (req.ExpireTime != 0 && req.ExpireTime <= now) {
+		return nil, msError.GrpcError(biz.RequestDataError)
+	}
+	if _, err := a.findAccount(ctx, req.Uid); err != nil {
+		return nil, err
+	}
+	// 只修改冻结信息，不会覆盖同时进行的手机号绑定
+	err := a.accounts.SetBlock(ctx, req.Uid, &entity.Block{
+		Reason:     req.Reason,
+		Operator:   req.Operator,
+		ExpireTime: req.ExpireTime,
+		CreateTime: now,
+	})
+	if err != nil {
+		logs.ErrorCtx(ctx, "block account update err: %v", err)
+		return nil, msError.GrpcError(biz.SqlError)
+	}
+
+	// 冻结已经生效，吊销失败时返回错误让调用方重试
+	if err = jwts.Revoke(ctx, a.redis, req.Uid); err != nil {
+		logs.ErrorCtx(ctx, "block account revoke token err: %v", err)
+		return nil, msError.GrpcError(biz.Fail)
+	}
+	if err = session.Kick(ctx, a.redis, req.Uid, req.Reason); err != nil {
+		logs.ErrorCtx(ctx, "block account kick err: %v", err)
+	}
+	logs.InfoCtx(ctx, "account %s blocked by %s, reason: %s, expire: %d", req.Uid, req.Operator, req.Reason, req.ExpireTime)
+	return &pb.BlockAccountResponse{}, nil
+}
+
+// UnblockAccount 解冻账号，冻结前签发的token不会恢复，需要重新登录
+func (a *AdminService) UnblockAccount(ctx context.Context, req *pb.UnblockAccountParams) (*pb.UnblockAccountResponse, error) {
+	if req.Uid == "" {
+		return nil, msError.GrpcError(biz.RequestDataError)
+	}
+	account, err := a.findAccount(ctx, req.Uid)
+	if err != nil {
+		return nil, err
+	}
+	if account.Block == nil {
+		return &pb.UnblockAccountResponse{}, nil
+	}
+	if err = a.accounts.SetBlock(ctx, req.Uid, nil); err != nil {
+		logs.ErrorCtx(ctx, "unblock account update err: %v", err)
+		return nil, msError.GrpcError(biz.SqlError)
+	}
+	logs.InfoCtx(ctx, "account %s unblocked by %s", req.Uid, req.Operator)
+	return &pb.UnblockAccountResponse{}, nil
+}
+
+// ListBlockedAccounts 冻结中的账号，按冻结时间倒序
+func (a *AdminService) ListBlockedAccounts(ctx context.Context, req *pb.ListBlockedAccountsParams) (*pb.ListBlockedAccountsResponse, error) {
+	limit := req.Limit
+	if limit <= 0 || limit > maxPageSize {
+		limit = defaultPageSize
+	}
+	if req.Offset < 0 {
+		return nil, msError.GrpcError(biz.RequestDataError)
+	}
+	accounts, err := a.accounts.ListBlocked(ctx, time.Now().UnixMilli(), req.Offset, limit)
+	if err != nil {
+		logs.ErrorCtx(ctx, "list blocked accounts err: %v", err)
+		return nil, msError.GrpcError(biz.SqlError)
+	}
+	list := make([]*pb.BlockedAccount, 0, len(accounts))
+	for _, v := range accounts {
+		list = append(list, &pb.BlockedAccount{
+			Uid:        v.Uid,
+			Account:    v.Account,
+			Reason:     v.Block.Reason,
+			Operator:   v.Block.Operator,
+			ExpireTime: v.Block.ExpireTime,
+			CreateTime: v.Block.CreateTime,
+		})
+	}
+	return &pb.ListBlockedAccountsResponse{List: list}, nil
+}
+
+func (a *AdminService) findAccount(ctx context.Context, uid string) (*entity.Account, error) {
+	account, err := a.accounts.FindByUid(ctx, uid)
+	if err == repo.ErrNotFound {
+		return nil, msError.GrpcError(biz.NotFindUser)
+	}
+	if err != nil {
+		logs.ErrorCtx(ctx, "find account %s err: %v", uid, err)
+		return nil, msError.GrpcError(biz.SqlError)
+	}
+	return account, nil
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"common/biz"
+	"common/config"
+	"common/jwts"
+	"context"
+	"core/repo/repotest"
+	"core/session"
+	"errors"
+	"testing"
+	"time"
+	"user/pb"
+)
+
+func TestAdminService_BlockAccount(t *testing.T) {
+	config.Set(&config.Config{Jwt: config.JwtConf{Kid: "k1", Secret: "secret", Exp: 7, AccessExp: 30}})
+	ctx := context.Background()
+	manager := repotest.New(t)
+	a := NewAccountService(manager)
+	admin := NewAdminService(manager)
+	uid := register(t, a, "player")
+	token, err := jwts.Issue(ctx, manager.Redis, uid, "device")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 冻结时通知connector踢下线
+	kicked := make(chan *session.KickMessage, 1)
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go session.SubscribeKick(subCtx, manager.Redis, func(msg *session.KickMessage) { kicked <- msg })
+	time.Sleep(50 * time.Millisecond)
+
+	tests := []struct {
+		name string
+		req  *pb.BlockAccountParams
+		code int
+	}{
+		{"empty reason", &pb.BlockAccountParams{Uid: uid}, biz.RequestDataError.Code},
+		{"expired", &pb.BlockAccountParams{Uid: uid, Reason: "外挂", ExpireTime: 1}, biz.RequestDataError.Code},
+		{"user not exist", &pb.BlockAccountParams{Uid: "1", Reason: "外挂"}, biz.NotFindUser.Code},
+		{"success", &pb.BlockAccountParams{Uid: uid, Reason: "外挂", Operator: "admin"}, biz.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := admin.BlockAccount(ctx, tt.req)
+			if code := errCode(err); code != tt.code {
+				t.Fatalf("code = %d, want %d, err: %v", code, tt.code, err)
+			}
+		})
+	}
+
+	select {
+	case msg := <-kicked:
+		if msg.Uid != uid {
+			t.Fatalf("kick uid = %s, want %s", msg.Uid, uid)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("kick message not received")
+	}
+	if _, err := jwts.Verify(ctx, manager.Redis, token.AccessToken); !errors.Is(err, jwts.ErrTokenRevoked) {
+		t.Fatalf("verify err = %v, want ErrTokenRevoked", err)
+	}
+	_, err = a.Login(ctx, &pb.LoginParams{Account: "player", Password: "123456"})
+	if code := errCode(err); code != biz.BlockedAccount.Code {
+		t.Fatalf("login code = %d, want %d", code, biz.BlockedAccount.Code)
+	}
+	list, err := admin.ListBlockedAccounts(ctx, &pb.ListBlockedAccountsParams{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.List) != 1 || list.List[0].Uid != uid || list.List[0].Reason != "外挂" {
+		t.Fatalf("blocked list = %v", list.List)
+	}
+
+	// 解冻后可以重新登录
+	if _, err = admin.UnblockAccount(ctx, &pb.UnblockAccountParams{Uid: uid, Operator: "admin"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = a.Login(ctx, &pb.LoginParams{Account: "player", Password: "123456"}); err != nil {
+		t.Fatalf("login after unblock: %v", err)
+	}
+	list, err = admin.ListBlockedAccounts(ctx, &pb.ListBlockedAccountsParams{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.List) != 0 {
+		t.Fatalf("blocked list = %v, want empty", list.List)
+	}
+}
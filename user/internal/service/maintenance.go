@@ -0,0 +1,75 @@
+package service
+
+import (
+	"common/biz"
+	"common/logs"
+	"common/msError"
+	"context"
+	"core/session"
+	"strings"
+	"time"
+	"user/pb"
+)
+
+// 维护提示的最大字数
+const maxMaintenanceMessageLen = 200
+
+// SetMaintenance 设置全平台维护，重复设置时覆盖之前的计划
+func (a *AdminService) SetMaintenance(ctx context.Context, req *pb.SetMaintenanceParams) (*pb.SetMaintenanceResponse, error) {
+	now := time.Now().UnixMilli()
+	startTime := req.StartTime
+	if startTime == 0 {
+		startTime = now
+	}
+	message := strings.TrimSpace(req.Message)
+	if message == "" || len([]rune(message)) > maxMaintenanceMessageLen ||
+		(req.EndTime != 0 && req.EndTime <= startTime) {
+		return nil, msError.GrpcError(biz.RequestDataError)
+	}
+	m := &session.Maintenance{
+		StartTime: startTime,
+		EndTime:   req.EndTime,
+		Message:   message,
+		Uids:      req.WhitelistUids,
+		Ips:       req.WhitelistIps,
+		Operator:  req.Operator,
+	}
+	if err := session.SetMaintenance(ctx, a.redis, m); err != nil {
+		logs.ErrorCtx(ctx, "set maintenance err: %v", err)
+		return nil, msError.GrpcError(biz.Fail)
+	}
+	logs.InfoCtx(ctx, "maintenance scheduled by %s, start: %d, end: %d", req.Operator, startTime, req.EndTime)
+	return &pb.SetMaintenanceResponse{StartTime: startTime}, nil
+}
+
+// CancelMaintenance 取消维护或结束维护
+func (a *AdminService) CancelMaintenance(ctx context.Context, req *pb.CancelMaintenanceParams) (*pb.CancelMaintenanceResponse, error) {
+	if err := session.CancelMaintenance(ctx, a.redis); err != nil {
+		logs.ErrorCtx(ctx, "cancel maintenance err: %v", err)
+		return nil, msError.GrpcError(biz.Fail)
+	}
+	logs.InfoCtx(ctx, "maintenance cancelled by %s", req.Operator)
+	return &pb.CancelMaintenanceResponse{}, nil
+}
+
+// GetMaintenance 当前的维护计划
+func (a *AdminService) GetMaintenance(ctx context.Context, req *pb.GetMaintenanceParams) (*pb.GetMaintenanceResponse, error) {
+	m, err := session.GetMaintenance(ctx, a.redis)
+	if err != nil {
+		logs.ErrorCtx(ctx, "get maintenance err: %v", err)
+		return nil, msError.GrpcError(biz.Fail)
+	}
+	if m == nil {
+		return &pb.GetMaintenanceResponse{}, nil
+	}
+	return &pb.GetMaintenanceResponse{
+		Scheduled:     true,
+		Active:        m.Active(),
+		StartTime:     m.StartTime,
+		EndTime:       m.EndTime,
+		Message:       m.Message,
+		WhitelistUids: m.Uids,
+		WhitelistIps:  m.Ips,
+		Operator:      m.Operator,
+	}, nil
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"common/biz"
+	"context"
+	"core/repo/repotest"
+	"core/session"
+	"testing"
+	"time"
+	"user/pb"
+)
+
+func TestAdminService_Maintenance(t *testing.T) {
+	ctx := context.Background()
+	manager := repotest.New(t)
+	admin := NewAdminService(manager)
+	start := time.Now().Add(10 * time.Minute).UnixMilli()
+
+	// 设置维护时通知connector推送倒计时
+	notified := make(chan *session.Maintenance, 1)
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go session.SubscribeMaintenance(subCtx, manager.Redis, func(m *session.Maintenance) { notified <- m })
+	time.Sleep(50 * time.Millisecond)
+
+	tests := []struct {
+		name string
+		req  *pb.SetMaintenanceParams
+		code int
+	}{
+		{"empty message", &pb.SetMaintenanceParams{StartTime: start, Message: " "}, biz.RequestDataError.Code},
+		{"end before start", &pb.SetMaintenanceParams{StartTime: start, EndTime: start, Message: "停服更新"}, biz.RequestDataError.Code},
+		{"success", &pb.SetMaintenanceParams{StartTime: start, Message: "停服更新", WhitelistUids: []string{"10000"}, Operator: "admin"}, biz.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := admin.SetMaintenance(ctx, tt.req)
+			if code := errCode(err); code != tt.code {
+				t.Fatalf("code = %d, want %d, err: %v", code, tt.code, err)
+			}
+		})
+	}
+	select {
+	case m := <-notified:
+		if m == nil || m.StartTime != start {
+			t.Fatalf("notified = %+v", m)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("maintenance message not received")
+	}
+
+	res, err := admin.GetMaintenance(ctx, &pb.GetMaintenanceParams{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Scheduled || res.Active || res.Message != "停服更新" || len(res.WhitelistUids) != 1 {
+		t.Fatalf("maintenance = %v", res)
+	}
+	// 还没开始时不拒绝请求
+	if err = session.CheckMaintenance(ctx, manager.Redis, "20000", ""); err != nil {
+		t.Fatalf("check before start err = %v", err)
+	}
+
+	// startTime为0时立即开始
+	if _, err = admin.SetMaintenance(ctx, &pb.SetMaintenanceParams{Message: "停服更新", WhitelistUids: []string{"10000"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err = session.CheckMaintenance(ctx, manager.Redis, "20000", ""); err != biz.ServerMaintenance {
+		t.Fatalf("check after start err = %v", err)
+	}
+	if err = session.CheckMaintenance(ctx, manager.Redis, "10000", ""); err != nil {
+		t.Fatalf("check whitelisted err = %v", err)
+	}
+
+	if _, err = admin.CancelMaintenance(ctx, &pb.CancelMaintenanceParams{Operator: "admin"}); err != nil {
+		t.Fatal(err)
+	}
+	if res, err = admin.GetMaintenance(ctx, &pb.GetMaintenanceParams{}); err != nil || res.Scheduled {
+		t.Fatalf("maintenance after cancel = %v, err = %v", res, err)
+	}
+}
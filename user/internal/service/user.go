@@ -0,0 +1,182 @@
+package service
+
+import (
+	"common/biz"
+	"common/database"
+	"common/logs"
+	"common/msError"
+	"common/sensitive"
+	"context"
+	"core/models/entity"
+	"core/repo"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+	"user/pb"
+)
+
+const (
+	maxNicknameLen  = 12          // 昵称最大字数
+	maxSignatureLen = 30          // 签名最大字数
+	maxAvatarLen    = 256         // 头像地址最大长度
+	smsCodeKey      = "sms:code:" // 短信验证码在redis中的key前缀，后接手机号，由短信服务写入
+)
+
+var phoneRegexp = regexp.MustCompile(`^1[3-9]\d{9}$`)
+
+// GetUserInfo 获取用户信息
+func (a *AccountService) GetUserInfo(ctx context.Context, req *pb.GetUserInfoParams) (*pb.GetUserInfoResponse, error) {
+	user, account, err := a.findUser(ctx, req.Uid)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetUserInfoResponse{
+		Info: userInfo(user, account),
+	}, nil
+}
+
+// UpdateUserInfo 修改昵称、头像、性别、签名，昵称包含敏感词时拒绝修改，签名中的敏感词替换成*
+func (a *AccountService) UpdateUserInfo(ctx context.Context, req *pb.UpdateUserInfoParams) (*pb.UpdateUserInfoResponse, error) {
+	user, account, err := a.findUser(ctx, req.Uid)
+	if err != nil {
+		return nil, err
+	}
+	if req.Nickname != nil {
+		nickname := strings.TrimSpace(*req.Nickname)
+		if nickname == "" || utf8.RuneCountInString(nickname) > maxNicknameLen {
+			return nil, msError.GrpcError(biz.RequestDataError)
+		}
+		if sensitive.Contains(nickname) {
+			return nil, msError.GrpcError(biz.ContentIllegal)
+		}
+		user.Nickname = nickname
+	}
+	if req.Avatar != nil {
+		if len(*req.Avatar) > maxAvatarLen {
+			return nil, msError.GrpcError(biz.RequestDataError)
+		}
+		user.Avatar = *req.Avatar
+	}
+	if req.Sex != nil {
+		if *req.Sex < 0 || *req.Sex > 2 {
+			return nil, msError.GrpcError(biz.RequestDataError)
+		}
+		user.Sex = int(*req.Sex)
+	}
+	if req.Signature != nil {
+		signature := strings.TrimSpace(*req.Signature)
+		if utf8.RuneCountInString(signature) > maxSignatureLen {
+			return nil, msError.GrpcError(biz.RequestDataError)
+		}
+		user.Signature = sensitive.Replace(signature)
+	}
+	user.UpdateTime = time.Now().UnixMilli()
+	if err = a.users.UpdateProfile(ctx, user); err != nil {
+		logs.ErrorCtx(ctx, "update user info err: %v", err)
+		return nil, msError.GrpcError(biz.SqlError)
+	}
+	return &pb.UpdateUserInfoResponse{
+		Info: userInfo(user, account),
+	}, nil
+}
+
+// BindPhone 绑定手机号，已绑定过的需要传入旧手机号才能换绑
+func (a *AccountService) BindPhone(ctx context.Context, req *pb.BindPhoneParams) (*pb.BindPhoneResponse, error) {
+	if !phoneRegexp.MatchString(req.Phone) || req.SmsCode == "" {
+		return nil, msError.GrpcError(biz.RequestDataError)
+	}
+	// 1.校验短信验证码，取出的同时删除，验证码只能使用一次，校验失败也作废，不能用来探测手机号的绑定状态
+	code, err := a.redis.GetDel(ctx, smsCodeKey+req.Phone)
+	if database.IsNil(err) || (err == nil && code != req.SmsCode) {
+		return nil, msError.GrpcError(biz.SmsCodeError)
+	}
+	if err != nil {
+		logs.ErrorCtx(ctx, "bind phone get sms code err: %v", err)
+		return nil, msError.GrpcError(biz.Fail)
+	}
+
+	account, err := a.accounts.FindByUid(ctx, req.Uid)
+	if err == repo.ErrNotFound {
+		return nil, msError.GrpcError(biz.NotFindUser)
+	}
+	if err != nil {
+		logs.ErrorCtx(ctx, "bind phone find account err: %v", err)
+		return nil, msError.GrpcError(biz.SqlError)
+	}
+	if account.Phone == req.Phone {
+		return nil, msError.GrpcError(biz.PhoneAlreadyBind)
+	}
+	if account.Phone != "" && account.Phone != req.OldPhone {
+		return nil, msError.GrpcError(biz.NotFindBindPhone)
+	}
+
+	// 2.手机号已被其他账号绑定
+	_, err = a.accounts.FindByPhone(ctx, req.Phone)
+	if err == nil {
+		return nil, msError.GrpcError(biz.PhoneAlreadyBind)
+	}
+	if err != repo.ErrNotFound {
+		logs.ErrorCtx(ctx, "bind phone find phone err: %v", err)
+		return nil, msError.GrpcError(biz.SqlError)
+	}
+
+	// 3.只修改手机号，不会覆盖同时进行的冻结；唯一索引兜底并发绑定同一个手机号
+	err = a.accounts.SetPhone(ctx, account.Uid, req.Phone)
+	if err == repo.ErrDuplicate {
+		return nil, msError.GrpcError(biz.PhoneAlreadyBind)
+	}
+	if err != nil {
+		logs.ErrorCtx(ctx, "bind phone update account err: %v", err)
+		return nil, msError.GrpcError(biz.SqlError)
+	}
+	return &pb.BindPhoneResponse{
+		Phone: maskPhone(req.Phone),
+	}, nil
+}
+
+// findUser 查询用户信息和账号
+func (a *AccountService) findUser(ctx context.Context, uid string) (*entity.User, *entity.Account, error) {
+	if uid == "" {
+		return nil, nil, msError.GrpcError(biz.RequestDataError)
+	}
+	user, err := a.users.FindByUid(ctx, uid)
+	if err == repo.ErrNotFound {
+		return nil, nil, msError.GrpcError(biz.NotFindUser)
+	}
+	if err != nil {
+		logs.ErrorCtx(ctx, "find user %s err: %v", uid, err)
+		return nil, nil, msError.GrpcError(biz.SqlError)
+	}
+	account, err := a.accounts.FindByUid(ctx, uid)
+	if err == repo.ErrNotFound {
+		return nil, nil, msError.GrpcError(biz.NotFindUser)
+	}
+	if err != nil {
+		logs.ErrorCtx(ctx, "find account %s err: %v", uid, err)
+		return nil, nil, msError.GrpcError(biz.SqlError)
+	}
+	return user, account, nil
+}
+
+func userInfo(user *entity.User, account *entity.Account) *pb.UserInfo {
+	return &pb.UserInfo{
+		Uid:       user.Uid,
+		Nickname:  user.Nickname,
+		Avatar:    user.Avatar,
+		Sex:       int32(user.Sex),
+		Signature: user.Signature,
+		Gold:      user.Gold,
+		VipLevel:  int32(user.VipLevel),
+		Phone:     maskPhone(account.Phone),
+		RoomId:    user.RoomId,
+	}
+}
+
+// maskPhone 手机号脱敏，138****1234
+func maskPhone(phone string) string {
+	if len(phone) != 11 {
+		return phone
+	}
+	return phone[:3] + "****" + phone[7:]
+}
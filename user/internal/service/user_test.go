@@ -0,0 +1,118 @@
+package service
+
+import (
+	"common/biz"
+	"common/sensitive"
+	"context"
+	"testing"
+	"time"
+	"user/pb"
+)
+
+// 注册一个测试用户，返回uid
+func register(t *testing.T, a *AccountService, account string) string {
+	t.Helper()
+	res, err := a.Register(context.Background(), &pb.RegisterParams{Account: account, Password: "123456"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res.Uid
+}
+
+func TestAccountService_UpdateUserInfo(t *testing.T) {
+	sensitive.Load([]string{"傻逼"})
+	t.Cleanup(func() { sensitive.Load(nil) })
+	ctx := context.Background()
+	a := newTestAccountService(t)
+	uid := register(t, a, "player")
+
+	str := func(s string) *string { return &s }
+	sex := func(v int32) *int32 { return &v }
+	tests := []struct {
+		name string
+		req  *pb.UpdateUserInfoParams
+		code int
+	}{
+		{"nickname", &pb.UpdateUserInfoParams{Uid: uid, Nickname: str("雀神")}, biz.OK},
+		{"sensitive nickname", &pb.UpdateUserInfoParams{Uid: uid, Nickname: str("傻 逼")}, biz.ContentIllegal.Code},
+		{"empty nickname", &pb.UpdateUserInfoParams{Uid: uid, Nickname: str("  ")}, biz.RequestDataError.Code},
+		{"invalid sex", &pb.UpdateUserInfoParams{Uid: uid, Sex: sex(3)}, biz.RequestDataError.Code},
+		{"user not exist", &pb.UpdateUserInfoParams{Uid: "1", Sex: sex(1)}, biz.NotFindUser.Code},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := a.UpdateUserInfo(ctx, tt.req)
+			if code := errCode(err); code != tt.code {
+				t.Fatalf("code = %d, want %d, err: %v", code, tt.code, err)
+			}
+		})
+	}
+
+	// 签名中的敏感词替换成*，未传的字段不修改
+	if _, err := a.UpdateUserInfo(ctx, &pb.UpdateUserInfoParams{Uid: uid, Signature: str("你是傻逼")}); err != nil {
+		t.Fatal(err)
+	}
+	res, err := a.GetUserInfo(ctx, &pb.GetUserInfoParams{Uid: uid})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Info.Signature != "你是**" || res.Info.Nickname != "雀神" {
+		t.Fatalf("info = %+v", res.Info)
+	}
+}
+
+func TestAccountService_BindPhone(t *testing.T) {
+	ctx := context.Background()
+	a := newTestAccountService(t)
+	uid := register(t, a, "player")
+	other := register(t, a, "other")
+	sendCode := func(phone string) {
+		if err := a.redis.Set(ctx, smsCodeKey+phone, "1234", time.Minute); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sendCode("13800000000")
+	if _, err := a.BindPhone(ctx, &pb.BindPhoneParams{Uid: other, Phone: "13800000000", SmsCode: "1234"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// send为true时先发送验证码，验证码校验后即作废
+	tests := []struct {
+		name string
+		send bool
+		req  *pb.BindPhoneParams
+		code int
+	}{
+		{"invalid phone", true, &pb.BindPhoneParams{Uid: uid, Phone: "123", SmsCode: "1234"}, biz.RequestDataError.Code},
+		{"bound by other without code", false, &pb.BindPhoneParams{Uid: uid, Phone: "13800000000", SmsCode: "1234"}, biz.SmsCodeError.Code},
+		{"bound by other", true, &pb.BindPhoneParams{Uid: uid, Phone: "13800000000", SmsCode: "1234"}, biz.PhoneAlreadyBind.Code},
+		{"wrong code", true, &pb.BindPhoneParams{Uid: uid, Phone: "13900000000", SmsCode: "0000"}, biz.SmsCodeError.Code},
+		{"code invalid after wrong", false, &pb.BindPhoneParams{Uid: uid, Phone: "13900000000", SmsCode: "1234"}, biz.SmsCodeError.Code},
+		{"success", true, &pb.BindPhoneParams{Uid: uid, Phone: "13900000000", SmsCode: "1234"}, biz.OK},
+		{"bind again", true, &pb.BindPhoneParams{Uid: uid, Phone: "13900000000", SmsCode: "1234"}, biz.PhoneAlreadyBind.Code},
+		{"change without old phone", true, &pb.BindPhoneParams{Uid: uid, Phone: "13700000000", SmsCode: "1234"}, biz.NotFindBindPhone.Code},
+		{"change phone", true, &pb.BindPhoneParams{Uid: uid, Phone: "13700000000", SmsCode: "1234", OldPhone: "13900000000"}, biz.OK},
+		{"code used", false, &pb.BindPhoneParams{Uid: other, Phone: "13700000000", SmsCode: "1234"}, biz.SmsCodeError.Code},
+		{"user not exist", true, &pb.BindPhoneParams{Uid: "1", Phone: "13600000000", SmsCode: "1234"}, biz.NotFindUser.Code},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.send {
+				sendCode(tt.req.Phone)
+			}
+			_, err := a.BindPhone(ctx, tt.req)
+			if code := errCode(err); code != tt.code {
+				t.Fatalf("code = %d, want %d, err: %v", code, tt.code, err)
+			}
+		})
+	}
+
+	res, err := a.GetUserInfo(ctx, &pb.GetUserInfoParams{Uid: uid})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Info.Phone != "137****0000" {
+		t.Fatalf("phone = %s, want 137****0000", res.Info.Phone)
+	}
+}
@@ -2,10 +2,8 @@ package main
 
 import (
 	"common/config"
-	"common/metrics"
 	"context"
 	"flag"
-	"fmt"
 	"log"
 	"os"
 	"user/app"
@@ -19,15 +17,7 @@ func main() {
 	flag.Parse()
 	config.InitConfig(*configFile)
 
-	// 2.启动监控协程
-	go func() {
-		err := metrics.Serve(fmt.Sprintf("0.0.0.0:%d", config.Conf.MetricPort))
-		if err != nil {
-			panic(err)
-		}
-	}()
-
-	//3.启动grpc服务端
+	//2.启动grpc服务端和监控服务
 	err := app.Run(context.Background())
 	if err != nil {
 		log.Println(err)
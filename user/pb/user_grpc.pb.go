@@ -19,7 +19,11 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	UserService_Register_FullMethodName = "/UserService/Register"
+	UserService_Register_FullMethodName       = "/UserService/Register"
+	UserService_Login_FullMethodName          = "/UserService/Login"
+	UserService_GetUserInfo_FullMethodName    = "/UserService/GetUserInfo"
+	UserService_UpdateUserInfo_FullMethodName = "/UserService/UpdateUserInfo"
+	UserService_BindPhone_FullMethodName      = "/UserService/BindPhone"
 )
 
 // UserServiceClient is the client API for UserService service.
@@ -27,6 +31,10 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type UserServiceClient interface {
 	Register(ctx context.Context, in *RegisterParams, opts ...grpc.CallOption) (*RegisterResponse, error)
+	Login(ctx context.Context, in *LoginParams, opts ...grpc.CallOption) (*LoginResponse, error)
+	GetUserInfo(ctx context.Context, in *GetUserInfoParams, opts ...grpc.CallOption) (*GetUserInfoResponse, error)
+	UpdateUserInfo(ctx context.Context, in *UpdateUserInfoParams, opts ...grpc.CallOption) (*UpdateUserInfoResponse, error)
+	BindPhone(ctx context.Context, in *BindPhoneParams, opts ...grpc.CallOption) (*BindPhoneResponse, error)
 }
 
 type userServiceClient struct {
@@ -46,11 +54,51 @@ func (c *userServiceClient) Register(ctx context.Context, in *RegisterParams, op
 	return out, nil
 }
 
+func (c *userServiceClient) Login(ctx context.Context, in *LoginParams, opts ...grpc.CallOption) (*LoginResponse, error) {
+	out := new(LoginResponse)
+	err := c.cc.Invoke(ctx, UserService_Login_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetUserInfo(ctx context.Context, in *GetUserInfoParams, opts ...grpc.CallOption) (*GetUserInfoResponse, error) {
+	out := new(GetUserInfoResponse)
+	err := c.cc.Invoke(ctx, UserService_GetUserInfo_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) UpdateUserInfo(ctx context.Context, in *UpdateUserInfoParams, opts ...grpc.CallOption) (*UpdateUserInfoResponse, error) {
+	out := new(UpdateUserInfoResponse)
+	err := c.cc.Invoke(ctx, UserService_UpdateUserInfo_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) BindPhone(ctx context.Context, in *BindPhoneParams, opts ...grpc.CallOption) (*BindPhoneResponse, error) {
+	out := new(BindPhoneResponse)
+	err := c.cc.Invoke(ctx, UserService_BindPhone_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UserServiceServer is the server API for UserService service.
 // All implementations must embed UnimplementedUserServiceServer
 // for forward compatibility
 type UserServiceServer interface {
 	Register(context.Context, *RegisterParams) (*RegisterResponse, error)
+	Login(context.Context, *LoginParams) (*LoginResponse, error)
+	GetUserInfo(context.Context, *GetUserInfoParams) (*GetUserInfoResponse, error)
+	UpdateUserInfo(context.Context, *UpdateUserInfoParams) (*UpdateUserInfoResponse, error)
+	BindPhone(context.Context, *BindPhoneParams) (*BindPhoneResponse, error)
 	mustEmbedUnimplementedUserServiceServer()
 }
 
@@ -61,6 +109,18 @@ type UnimplementedUserServiceServer struct {
 func (UnimplementedUserServiceServer) Register(context.Context, *RegisterParams) (*RegisterResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Register not implemented")
 }
+func (UnimplementedUserServiceServer) Login(context.Context, *LoginParams) (*LoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
+}
+func (UnimplementedUserServiceServer) GetUserInfo(context.Context, *GetUserInfoParams) (*GetUserInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserInfo not implemented")
+}
+func (UnimplementedUserServiceServer) UpdateUserInfo(context.Context, *UpdateUserInfoParams) (*UpdateUserInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateUserInfo not implemented")
+}
+func (UnimplementedUserServiceServer) BindPhone(context.Context, *BindPhoneParams) (*BindPhoneResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BindPhone not implemented")
+}
 func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
 
 // UnsafeUserServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -92,6 +152,78 @@ func _UserService_Register_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).Login(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_Login_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).Login(ctx, req.(*LoginParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetUserInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserInfoParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetUserInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetUserInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetUserInfo(ctx, req.(*GetUserInfoParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_UpdateUserInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateUserInfoParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UpdateUserInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UpdateUserInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UpdateUserInfo(ctx, req.(*UpdateUserInfoParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_BindPhone_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BindPhoneParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).BindPhone(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_BindPhone_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).BindPhone(ctx, req.(*BindPhoneParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // UserService_ServiceDesc is the grpc.ServiceDesc for UserService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -103,6 +235,297 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Register",
 			Handler:    _UserService_Register_Handler,
 		},
+		{
+			MethodName: "Login",
+			Handler:    _UserService_Login_Handler,
+		},
+		{
+			MethodName: "GetUserInfo",
+			Handler:    _UserService_GetUserInfo_Handler,
+		},
+		{
+			MethodName: "UpdateUserInfo",
+			Handler:    _UserService_UpdateUserInfo_Handler,
+		},
+		{
+			MethodName: "BindPhone",
+			Handler:    _UserService_BindPhone_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "user.proto",
+}
+
+const (
+	AdminService_BlockAccount_FullMethodName        = "/AdminService/BlockAccount"
+	AdminService_UnblockAccount_FullMethodName      = "/AdminService/UnblockAccount"
+	AdminService_ListBlockedAccounts_FullMethodName = "/AdminService/ListBlockedAccounts"
+	AdminService_SetMaintenance_FullMethodName      = "/AdminService/SetMaintenance"
+	AdminService_CancelMaintenance_FullMethodName   = "/AdminService/CancelMaintenance"
+	AdminService_GetMaintenance_FullMethodName      = "/AdminService/GetMaintenance"
+)
+
+// AdminServiceClient is the client API for AdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AdminServiceClient interface {
+	BlockAccount(ctx context.Context, in *BlockAccountParams, opts ...grpc.CallOption) (*BlockAccountResponse, error)
+	UnblockAccount(ctx context.Context, in *UnblockAccountParams, opts ...grpc.CallOption) (*UnblockAccountResponse, error)
+	ListBlockedAccounts(ctx context.Context, in *ListBlockedAccountsParams, opts ...grpc.CallOption) (*ListBlockedAccountsResponse, error)
+	SetMaintenance(ctx context.Context, in *SetMaintenanceParams, opts ...grpc.CallOption) (*SetMaintenanceResponse, error)
+	CancelMaintenance(ctx context.Context, in *CancelMaintenanceParams, opts ...grpc.CallOption) (*CancelMaintenanceResponse, error)
+	GetMaintenance(ctx context.Context, in *GetMaintenanceParams, opts ...grpc.CallOption) (*GetMaintenanceResponse, error)
+}
+
+type adminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAdminServiceClient(cc grpc.ClientConnInterface) AdminServiceClient {
+	return &adminServiceClient{cc}
+}
+
+func (c *adminServiceClient) BlockAccount(ctx context.Context, in *BlockAccountParams, opts ...grpc.CallOption) (*BlockAccountResponse, error) {
+	out := new(BlockAccountResponse)
+	err := c.cc.Invoke(ctx, AdminService_BlockAccount_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) UnblockAccount(ctx context.Context, in *UnblockAccountParams, opts ...grpc.CallOption) (*UnblockAccountResponse, error) {
+	out := new(UnblockAccountResponse)
+	err := c.cc.Invoke(ctx, AdminService_UnblockAccount_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ListBlockedAccounts(ctx context.Context, in *ListBlockedAccountsParams, opts ...grpc.CallOption) (*ListBlockedAccountsResponse, error) {
+	out := new(ListBlockedAccountsResponse)
+	err := c.cc.Invoke(ctx, AdminService_ListBlockedAccounts_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) SetMaintenance(ctx context.Context, in *SetMaintenanceParams, opts ...grpc.CallOption) (*SetMaintenanceResponse, error) {
+	out := new(SetMaintenanceResponse)
+	err := c.cc.Invoke(ctx, AdminService_SetMaintenance_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) CancelMaintenance(ctx context.Context, in *CancelMaintenanceParams, opts ...grpc.CallOption) (*CancelMaintenanceResponse, error) {
+	out := new(CancelMaintenanceResponse)
+	err := c.cc.Invoke(ctx, AdminService_CancelMaintenance_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetMaintenance(ctx context.Context, in *GetMaintenanceParams, opts ...grpc.CallOption) (*GetMaintenanceResponse, error) {
+	out := new(GetMaintenanceResponse)
+	err := c.cc.Invoke(ctx, AdminService_GetMaintenance_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServiceServer is the server API for AdminService service.
+// All implementations must embed UnimplementedAdminServiceServer
+// for forward compatibility
+type AdminServiceServer interface {
+	BlockAccount(context.Context, *BlockAccountParams) (*BlockAccountResponse, error)
+	UnblockAccount(context.Context, *UnblockAccountParams) (*UnblockAccountResponse, error)
+	ListBlockedAccounts(context.Context, *ListBlockedAccountsParams) (*ListBlockedAccountsResponse, error)
+	SetMaintenance(context.Context, *SetMaintenanceParams) (*SetMaintenanceResponse, error)
+	CancelMaintenance(context.Context, *CancelMaintenanceParams) (*CancelMaintenanceResponse, error)
+	GetMaintenance(context.Context, *GetMaintenanceParams) (*GetMaintenanceResponse, error)
+	mustEmbedUnimplementedAdminServiceServer()
+}
+
+// UnimplementedAdminServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedAdminServiceServer struct {
+}
+
+func (UnimplementedAdminServiceServer) BlockAccount(context.Context, *BlockAccountParams) (*BlockAccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BlockAccount not implemented")
+}
+func (UnimplementedAdminServiceServer) UnblockAccount(context.Context, *UnblockAccountParams) (*UnblockAccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnblockAccount not implemented")
+}
+func (UnimplementedAdminServiceServer) ListBlockedAccounts(context.Context, *ListBlockedAccountsParams) (*ListBlockedAccountsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBlockedAccounts not implemented")
+}
+func (UnimplementedAdminServiceServer) SetMaintenance(context.Context, *SetMaintenanceParams) (*SetMaintenanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetMaintenance not implemented")
+}
+func (UnimplementedAdminServiceServer) CancelMaintenance(context.Context, *CancelMaintenanceParams) (*CancelMaintenanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelMaintenance not implemented")
+}
+func (UnimplementedAdminServiceServer) GetMaintenance(context.Context, *GetMaintenanceParams) (*GetMaintenanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMaintenance not implemented")
+}
+func (UnimplementedAdminServiceServer) mustEmbedUnimplementedAdminServiceServer() {}
+
+// UnsafeAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AdminServiceServer will
+// result in compilation errors.
+type UnsafeAdminServiceServer interface {
+	mustEmbedUnimplementedAdminServiceServer()
+}
+
+func RegisterAdminServiceServer(s grpc.ServiceRegistrar, srv AdminServiceServer) {
+	s.RegisterService(&AdminService_ServiceDesc, srv)
+}
+
+func _AdminService_BlockAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockAccountParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).BlockAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_BlockAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).BlockAccount(ctx, req.(*BlockAccountParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_UnblockAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnblockAccountParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).UnblockAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_UnblockAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).UnblockAccount(ctx, req.(*UnblockAccountParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ListBlockedAccounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBlockedAccountsParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListBlockedAccounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ListBlockedAccounts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListBlockedAccounts(ctx, req.(*ListBlockedAccountsParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_SetMaintenance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetMaintenanceParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).SetMaintenance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_SetMaintenance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).SetMaintenance(ctx, req.(*SetMaintenanceParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_CancelMaintenance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelMaintenanceParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).CancelMaintenance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_CancelMaintenance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).CancelMaintenance(ctx, req.(*CancelMaintenanceParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetMaintenance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMaintenanceParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetMaintenance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_GetMaintenance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetMaintenance(ctx, req.(*GetMaintenanceParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AdminService_ServiceDesc is the grpc.ServiceDesc for AdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "AdminService",
+	HandlerType: (*AdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "BlockAccount",
+			Handler:    _AdminService_BlockAccount_Handler,
+		},
+		{
+			MethodName: "UnblockAccount",
+			Handler:    _AdminService_UnblockAccount_Handler,
+		},
+		{
+			MethodName: "ListBlockedAccounts",
+			Handler:    _AdminService_ListBlockedAccounts_Handler,
+		},
+		{
+			MethodName: "SetMaintenance",
+			Handler:    _AdminService_SetMaintenance_Handler,
+		},
+		{
+			MethodName: "CancelMaintenance",
+			Handler:    _AdminService_CancelMaintenance_Handler,
+		},
+		{
+			MethodName: "GetMaintenance",
+			Handler:    _AdminService_GetMaintenance_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "user.proto",